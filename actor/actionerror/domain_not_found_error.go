@@ -3,15 +3,19 @@ package actionerror
 import "fmt"
 
 // DomainNotFoundError is an error wrapper that represents the case
-// when the domain is not found.
+// when the domain is not found. Suggestion, when set, is the name of an
+// existing domain that is close to Name.
 type DomainNotFoundError struct {
-	Name string
-	GUID string
+	Name       string
+	GUID       string
+	Suggestion string
 }
 
 // Error method to display the error message.
 func (e DomainNotFoundError) Error() string {
 	switch {
+	case e.Name != "" && e.Suggestion != "":
+		return fmt.Sprintf("Domain %s not found; did you mean %s?", e.Name, e.Suggestion)
 	case e.Name != "":
 		return fmt.Sprintf("Domain %s not found", e.Name)
 	case e.GUID != "":
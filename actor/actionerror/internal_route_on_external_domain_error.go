@@ -0,0 +1,13 @@
+package actionerror
+
+import "fmt"
+
+// InternalRouteOnExternalDomainError is returned when an internal route is
+// requested on a domain that is not internal.
+type InternalRouteOnExternalDomainError struct {
+	Domain string
+}
+
+func (e InternalRouteOnExternalDomainError) Error() string {
+	return fmt.Sprintf("cannot create an internal route on external domain %s", e.Domain)
+}
@@ -0,0 +1,13 @@
+package actionerror
+
+import "fmt"
+
+// InvalidHostnameError is returned when a hostname has no characters that can
+// be used in a route, so sanitizing it leaves nothing behind.
+type InvalidHostnameError struct {
+	Original string
+}
+
+func (e InvalidHostnameError) Error() string {
+	return fmt.Sprintf("hostname '%s' does not contain any valid characters", e.Original)
+}
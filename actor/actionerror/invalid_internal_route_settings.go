@@ -0,0 +1,13 @@
+package actionerror
+
+import "fmt"
+
+// InvalidInternalRouteSettings is returned when a route on an internal domain
+// has a path or a port. Internal routes are only reached by host name.
+type InvalidInternalRouteSettings struct {
+	Domain string
+}
+
+func (e InvalidInternalRouteSettings) Error() string {
+	return fmt.Sprintf("routes on internal domain %s cannot have a path or port", e.Domain)
+}
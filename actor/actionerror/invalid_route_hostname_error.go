@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// InvalidRouteHostnameError is returned when a route's hostname cannot be
+// converted into the ASCII (punycode) form used by the Cloud Controller.
+type InvalidRouteHostnameError struct {
+	Hostname string
+	Err      error
+}
+
+func (e InvalidRouteHostnameError) Error() string {
+	return fmt.Sprintf("Invalid hostname %s: %s", e.Hostname, e.Err)
+}
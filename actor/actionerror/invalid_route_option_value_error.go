@@ -0,0 +1,19 @@
+package actionerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidRouteOptionValueError is returned when a route sets an option to a
+// value the option does not allow.
+type InvalidRouteOptionValueError struct {
+	Route   string
+	Option  string
+	Value   string
+	Allowed []string
+}
+
+func (e InvalidRouteOptionValueError) Error() string {
+	return fmt.Sprintf("route %s: invalid value %s for route option %s, must be one of: %s", e.Route, e.Value, e.Option, strings.Join(e.Allowed, ", "))
+}
@@ -0,0 +1,18 @@
+package actionerror
+
+import "fmt"
+
+// InvalidRoutePathError is returned when a route's path, once any
+// percent-encoded characters are decoded, contains a character that the
+// Cloud Controller does not allow. Position is the 1-based position of the
+// character in the decoded path.
+type InvalidRoutePathError struct {
+	Route     string
+	Path      string
+	Character rune
+	Position  int
+}
+
+func (e InvalidRoutePathError) Error() string {
+	return fmt.Sprintf("route '%s' path '%s' has invalid character %q at position %d", e.Route, e.Path, e.Character, e.Position)
+}
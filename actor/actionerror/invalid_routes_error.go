@@ -0,0 +1,27 @@
+package actionerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidRoute is a route that failed validation, along with the reason it
+// failed.
+type InvalidRoute struct {
+	Route string
+	Err   error
+}
+
+// InvalidRoutesError is returned when more than one route fails validation.
+// It lists every invalid route rather than only the first one found.
+type InvalidRoutesError struct {
+	Routes []InvalidRoute
+}
+
+func (e InvalidRoutesError) Error() string {
+	var reasons []string
+	for _, route := range e.Routes {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", route.Route, route.Err))
+	}
+	return fmt.Sprintf("invalid routes: %s", strings.Join(reasons, "; "))
+}
@@ -0,0 +1,13 @@
+package actionerror
+
+import "fmt"
+
+// InvertedRoutePortRangeError is returned when a route's port range starts
+// after it ends.
+type InvertedRoutePortRangeError struct {
+	Route string
+}
+
+func (e InvertedRoutePortRangeError) Error() string {
+	return fmt.Sprintf("route %s has a port range whose start port is greater than its end port", e.Route)
+}
@@ -0,0 +1,17 @@
+package actionerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiplePrimaryRoutesError is returned when more than one of an
+// application's routes is marked as its primary route.
+type MultiplePrimaryRoutesError struct {
+	AppName string
+	Routes  []string
+}
+
+func (e MultiplePrimaryRoutesError) Error() string {
+	return fmt.Sprintf("application %s has more than one primary route: %s", e.AppName, strings.Join(e.Routes, ", "))
+}
@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// PortWithHTTPDomainError is returned when a route on an HTTP domain specifies
+// a port.
+type PortWithHTTPDomainError struct {
+	Route  string
+	Domain string
+}
+
+func (e PortWithHTTPDomainError) Error() string {
+	return fmt.Sprintf("route '%s' specifies a port, but '%s' is an HTTP domain", e.Route, e.Domain)
+}
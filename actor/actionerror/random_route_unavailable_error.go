@@ -0,0 +1,13 @@
+package actionerror
+
+import "fmt"
+
+// RandomRouteUnavailableError is returned when every random route that was
+// generated for an application is already taken.
+type RandomRouteUnavailableError struct {
+	Attempts int
+}
+
+func (e RandomRouteUnavailableError) Error() string {
+	return fmt.Sprintf("could not find an available random route after %d attempts", e.Attempts)
+}
@@ -0,0 +1,17 @@
+package actionerror
+
+import "fmt"
+
+// RouteMappedToBothApplicationsError is returned when a route was mapped to
+// the application it was moved to but could not be unmapped from the
+// application it was moved from, leaving it mapped to both.
+type RouteMappedToBothApplicationsError struct {
+	Route       string
+	FromAppGUID string
+	ToAppGUID   string
+	Err         error
+}
+
+func (e RouteMappedToBothApplicationsError) Error() string {
+	return fmt.Sprintf("route %s is mapped to both app %s and app %s: %s", e.Route, e.FromAppGUID, e.ToAppGUID, e.Err)
+}
@@ -0,0 +1,16 @@
+package actionerror
+
+import "fmt"
+
+// RouteOptionsNotSupportedError is returned when options are set on a route
+// but the targeted Cloud Controller is older than the minimum version that
+// supports them.
+type RouteOptionsNotSupportedError struct {
+	Route          string
+	CurrentVersion string
+	MinimumVersion string
+}
+
+func (e RouteOptionsNotSupportedError) Error() string {
+	return fmt.Sprintf("route %s: route options require CC API version %s or higher, targeted API is %s", e.Route, e.MinimumVersion, e.CurrentVersion)
+}
@@ -0,0 +1,18 @@
+package actionerror
+
+import "fmt"
+
+// RouteOwnedByOtherOrganizationError is returned when a route is already in
+// use outside of the targeted space. Organization is the name of the
+// organization that owns the route's domain, when it could be found.
+type RouteOwnedByOtherOrganizationError struct {
+	Route        string
+	Organization string
+}
+
+func (e RouteOwnedByOtherOrganizationError) Error() string {
+	if e.Organization != "" {
+		return fmt.Sprintf("route %s is already in use by organization %s", e.Route, e.Organization)
+	}
+	return fmt.Sprintf("route %s is already in use by another organization or space", e.Route)
+}
@@ -0,0 +1,19 @@
+package actionerror
+
+import "fmt"
+
+// RoutePathConflictError is returned when a route with a path cannot be
+// mapped because the same route, path included, is already mapped to another
+// application. App is the name of that application, when it is known.
+type RoutePathConflictError struct {
+	Route string
+	Path  string
+	App   string
+}
+
+func (e RoutePathConflictError) Error() string {
+	if e.App != "" {
+		return fmt.Sprintf("route %s: path %s is already mapped to application %s", e.Route, e.Path, e.App)
+	}
+	return fmt.Sprintf("route %s: path %s is already mapped to another application", e.Route, e.Path)
+}
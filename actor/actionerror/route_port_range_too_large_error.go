@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// RoutePortRangeTooLargeError is returned when a route's port range contains
+// more ports than are allowed for a single route.
+type RoutePortRangeTooLargeError struct {
+	Route    string
+	MaxPorts int
+}
+
+func (e RoutePortRangeTooLargeError) Error() string {
+	return fmt.Sprintf("route %s has a port range with more than %d ports", e.Route, e.MaxPorts)
+}
@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// RouteProtocolNotSupportedByDomainError is returned when a route's protocol
+// cannot be used with its domain's type, such as http2 with a TCP domain.
+type RouteProtocolNotSupportedByDomainError struct {
+	Protocol string
+	Domain   string
+}
+
+func (e RouteProtocolNotSupportedByDomainError) Error() string {
+	return fmt.Sprintf("cannot use route protocol %s with domain %s", e.Protocol, e.Domain)
+}
@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// RouteQueryOrFragmentError is returned when a route contains a query string
+// or a fragment. The Cloud Controller only stores a route's host, domain,
+// port and path.
+type RouteQueryOrFragmentError struct {
+	Route string
+}
+
+func (e RouteQueryOrFragmentError) Error() string {
+	return fmt.Sprintf("route %s cannot contain a query string or fragment", e.Route)
+}
@@ -0,0 +1,15 @@
+package actionerror
+
+import "fmt"
+
+// RouteQuotaExceededError is returned when a route cannot be created because
+// the space or organization has reached its route quota. Message is the
+// Cloud Controller's description of the quota that was exceeded.
+type RouteQuotaExceededError struct {
+	Route   string
+	Message string
+}
+
+func (e RouteQuotaExceededError) Error() string {
+	return fmt.Sprintf("route %s: %s", e.Route, e.Message)
+}
@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// RouteRequiresV3APIError is returned when a route sets labels, annotations
+// or options but the targeted Cloud Controller has no V3 API to set them
+// with.
+type RouteRequiresV3APIError struct {
+	Route string
+}
+
+func (e RouteRequiresV3APIError) Error() string {
+	return fmt.Sprintf("route %s: labels, annotations and options require the CC V3 API", e.Route)
+}
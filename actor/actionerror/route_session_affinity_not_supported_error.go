@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// RouteSessionAffinityNotSupportedError is returned when session affinity is
+// requested for a route. The Cloud Controller API has no route field for
+// session affinity, so it cannot be set.
+type RouteSessionAffinityNotSupportedError struct {
+	Route string
+}
+
+func (e RouteSessionAffinityNotSupportedError) Error() string {
+	return fmt.Sprintf("route %s: session affinity is not supported by the CC API", e.Route)
+}
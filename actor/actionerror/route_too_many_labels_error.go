@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// RouteTooManyLabelsError is returned when a route's hostname has more
+// dot-separated labels than are allowed.
+type RouteTooManyLabelsError struct {
+	Route     string
+	MaxLabels int
+}
+
+func (e RouteTooManyLabelsError) Error() string {
+	return fmt.Sprintf("route '%s' has more than %d labels", e.Route, e.MaxLabels)
+}
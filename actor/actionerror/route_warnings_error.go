@@ -0,0 +1,13 @@
+package actionerror
+
+import "strings"
+
+// RouteWarningsError is returned by the route actions when WarningsAsErrors
+// is set and they produced warnings.
+type RouteWarningsError struct {
+	Warnings []string
+}
+
+func (e RouteWarningsError) Error() string {
+	return "route operations produced warnings: " + strings.Join(e.Warnings, "; ")
+}
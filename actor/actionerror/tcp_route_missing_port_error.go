@@ -0,0 +1,13 @@
+package actionerror
+
+import "fmt"
+
+// TCPRouteMissingPortError is returned when a TCP route does not specify a
+// port and a random port was not requested.
+type TCPRouteMissingPortError struct {
+	Route string
+}
+
+func (e TCPRouteMissingPortError) Error() string {
+	return fmt.Sprintf("TCP route '%s' does not specify a port", e.Route)
+}
@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// TooManyRoutesError is returned when an application would have more routes
+// than are allowed.
+type TooManyRoutesError struct {
+	Routes    int
+	MaxRoutes int
+}
+
+func (e TooManyRoutesError) Error() string {
+	return fmt.Sprintf("%d routes exceeds the limit of %d routes per application", e.Routes, e.MaxRoutes)
+}
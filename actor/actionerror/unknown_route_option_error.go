@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// UnknownRouteOptionError is returned when a route sets an option that the
+// Cloud Controller does not support.
+type UnknownRouteOptionError struct {
+	Route  string
+	Option string
+}
+
+func (e UnknownRouteOptionError) Error() string {
+	return fmt.Sprintf("route %s: unknown route option %s", e.Route, e.Option)
+}
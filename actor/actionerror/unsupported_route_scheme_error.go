@@ -0,0 +1,14 @@
+package actionerror
+
+import "fmt"
+
+// UnsupportedRouteSchemeError is returned when a route starts with a scheme,
+// such as ws://, that routes cannot be given.
+type UnsupportedRouteSchemeError struct {
+	Route  string
+	Scheme string
+}
+
+func (e UnsupportedRouteSchemeError) Error() string {
+	return fmt.Sprintf("route '%s' has unsupported scheme '%s'", e.Route, e.Scheme)
+}
@@ -0,0 +1,13 @@
+package actionerror
+
+import "fmt"
+
+// WildcardHostNotAllowedError is returned when a route with the wildcard host
+// '*' is on a domain that does not allow wildcard hosts.
+type WildcardHostNotAllowedError struct {
+	Domain string
+}
+
+func (e WildcardHostNotAllowedError) Error() string {
+	return fmt.Sprintf("domain '%s' does not allow wildcard hosts", e.Domain)
+}
@@ -2,25 +2,211 @@
 // push.
 package pushaction
 
-import "regexp"
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/util/words/generator"
+)
 
 // Warnings is a list of warnings returned back from the cloud controller
 type Warnings []string
 
 // Actor handles all business logic for Cloud Controller v2 operations.
 type Actor struct {
-	V2Actor           V2Actor
-	SharedActor       SharedActor
-	startWithProtocol *regexp.Regexp
+	V2Actor     V2Actor
+	SharedActor SharedActor
+
+	// V3Actor makes the route requests that are only available through the V3
+	// API, such as setting route labels, annotations and options. It is nil when
+	// the targeted Cloud Controller has no V3 API.
+	V3Actor V3Actor
+
+	// RouteConcurrency is the maximum number of route requests that will be
+	// in flight at the same time.
+	RouteConcurrency int
+
+	// DryRun prevents CreateRoutes and MapRoutes from creating or mapping any
+	// routes. They instead return the configuration that would have resulted
+	// and the routes that would have been created or mapped; see
+	// CreateRoutesDryRun and MapRoutesDryRun.
+	DryRun bool
+
+	// AtomicMapRoutes makes MapRoutes roll back the mappings it made when
+	// mapping a route fails, so that the application's routes are left
+	// unchanged. Routes it unmapped to change their app port are mapped again
+	// with their old port. No mapped routes are then returned, and the
+	// warnings from the rollback are returned with those from mapping.
+	AtomicMapRoutes bool
+
+	// SkipDefaultRoute prevents a default route from being generated, created
+	// or mapped for applications that do not specify any routes. The
+	// application's existing routes are left as they are.
+	SkipDefaultRoute bool
+
+	// DomainCache, when set, caches domain lookups made by CalculateRoutes.
+	// It is nil by default, so every lookup is made; callers opt in with
+	// NewDomainCache.
+	DomainCache *DomainCache
+
+	// DefaultDomainOverride, when set, is returned by DefaultDomain instead of
+	// looking up the organization's default domain. It lets automation choose
+	// the default domain without changing the manifest.
+	DefaultDomainOverride *v2action.Domain
+
+	// DomainBatchSize is the maximum number of domain names looked up in a
+	// single request. Zero looks up all of the names in one request.
+	DomainBatchSize int
+
+	// DomainPreference is the order in which domain types are preferred when
+	// more than one domain has the name given in a manifest. Domains whose
+	// type is not listed are only used when no listed type matches.
+	DomainPreference []constant.DomainType
+
+	// MaxHostnameLength is the length that hostnames generated from an
+	// application's name are truncated to. Zero disables truncation.
+	MaxHostnameLength int
+
+	// AllowedHostnameCharacters are characters that are kept in hostnames
+	// generated from an application's name instead of being dropped. It is
+	// empty by default; foundations that allowed underscores in hosts can set
+	// it to "_".
+	AllowedHostnameCharacters string
+
+	// MaxRouteLabels is the maximum number of dot-separated labels a route's
+	// hostname may have. CalculateRoutes treats a route with more as
+	// invalid. Zero disables the limit.
+	MaxRouteLabels int
+
+	// MaxRoutesPerApp is the maximum number of routes an application may
+	// have, counting the existing routes passed to CalculateRoutes.
+	// CalculateRoutes and CreateRoutes return a TooManyRoutesError, before any
+	// route is looked up or created, when there are more. Zero disables the
+	// limit.
+	MaxRoutesPerApp int
+
+	// MapRouteRetries is the number of times mapping a route to an
+	// application is retried after a transient Cloud Controller failure.
+	MapRouteRetries int
+
+	// MapRouteRetryBackoff is how long to wait before the first route mapping
+	// retry. The wait doubles after each retry.
+	MapRouteRetryBackoff time.Duration
+
+	// WordGenerator generates the random host suffixes used for random
+	// routes. When it is nil, a generator using the CLI's word lists is used;
+	// the lists are only loaded once a random host is needed.
+	WordGenerator generator.WordGenerator
+
+	// RouteSchemes are the schemes, such as the http in
+	// http://app.example.com, that a route may start with. They are compared
+	// case insensitively and dropped when the route is parsed. A route that
+	// starts with any other scheme is invalid. When empty, the
+	// DefaultRouteSchemes are used.
+	RouteSchemes []string
+
+	// SkipRouteValidation skips the v2action.Route Validate check that
+	// CalculateRoutes makes on each route. It is meant for automation that
+	// already knows its routes are valid. The Cloud Controller still checks
+	// every route, so a malformed route surfaces as a Cloud Controller error
+	// when it is created or mapped instead.
+	SkipRouteValidation bool
+
+	// FailOnRouteInOtherSpace makes CalculateRoutes return a
+	// RouteInDifferentSpaceError, before any route is looked up in the space,
+	// when a desired route already exists in another space of the
+	// organization. Otherwise such routes are only flagged with
+	// ExistsInOtherSpace.
+	FailOnRouteInOtherSpace bool
+
+	// WarnOnHostInMultipleDomains makes CalculateRoutes return a warning for
+	// each host that its routes use with more than one domain, such as
+	// app.example.com and app.example.org, which is often a manifest mistake.
+	WarnOnHostInMultipleDomains bool
+
+	// InternalDomainName is the name of the domain that the Cloud Controller
+	// reserves for internal routes. A domain with this name is treated as
+	// internal even when the Cloud Controller does not report it as internal,
+	// so that the internal route rules apply to it. Empty disables the check.
+	InternalDomainName string
+
+	// Resolver, when set, is used by CalculateRoutes to check that the
+	// private HTTP domains of the routes resolve in DNS, so that DNS
+	// misconfiguration is caught before any route is created. A domain that
+	// does not resolve results in a warning, not an error.
+	Resolver Resolver
+
+	// WarningsAsErrors makes MapRoutes, CreateRoutes and CalculateRoutes
+	// return a RouteWarningsError listing their warnings when they produce
+	// any SeverityWarning warnings, for automation that must not push past
+	// them. SeverityInfo notes are not counted. The work is still done and
+	// its results are returned along with the error.
+	WarningsAsErrors bool
+
+	// Metrics, when set, records the duration of each route request made to
+	// the V2Actor. When it is also a CounterMetrics, the DomainCache hits and
+	// misses are counted.
+	Metrics Metrics
+
+	// missingDomains are the domain names that were not found during the
+	// current push. It is only set on the Actor returned by ForPush.
+	missingDomains *missingDomains
 }
 
+// DefaultRouteConcurrency is the RouteConcurrency used by NewActor.
+const DefaultRouteConcurrency = 5
+
+// DefaultDomainBatchSize is the DomainBatchSize used by NewActor.
+const DefaultDomainBatchSize = 50
+
+// DefaultDomainPreference is the DomainPreference used by NewActor. Private
+// domains owned by or shared with the organization are preferred over shared
+// domains.
+var DefaultDomainPreference = []constant.DomainType{constant.PrivateDomain, constant.SharedDomain}
+
+// DefaultRouteSchemes is the RouteSchemes used by NewActor.
+var DefaultRouteSchemes = []string{"http", "https", "tcp"}
+
+// ProtocolRegexp matches the DefaultRouteSchemes at the start of a route.
+//
+// Deprecated: routes are checked against the Actor's RouteSchemes instead.
 const ProtocolRegexp = "^https?://|^tcp://"
 
+// DefaultInternalDomainName is the InternalDomainName used by NewActor.
+const DefaultInternalDomainName = "apps.internal"
+
+// DefaultMaxRouteLabels is the MaxRouteLabels used by NewActor.
+const DefaultMaxRouteLabels = 128
+
+// DefaultMapRouteRetries is the MapRouteRetries used by NewActor.
+const DefaultMapRouteRetries = 3
+
+// DefaultMapRouteRetryBackoff is the MapRouteRetryBackoff used by NewActor.
+const DefaultMapRouteRetryBackoff = time.Second
+
+// DefaultMaxHostnameLength is the MaxHostnameLength used by NewActor. It is
+// the longest DNS label the Cloud Controller accepts.
+const DefaultMaxHostnameLength = 63
+
+// MaxRandomRouteAttempts is the number of random hosts GetGeneratedRoute
+// tries before giving up on finding an available random route.
+const MaxRandomRouteAttempts = 5
+
 // NewActor returns a new actor.
-func NewActor(v2Actor V2Actor, sharedActor SharedActor) *Actor {
+func NewActor(v2Actor V2Actor, v3Actor V3Actor, sharedActor SharedActor) *Actor {
 	return &Actor{
-		V2Actor:           v2Actor,
-		SharedActor:       sharedActor,
-		startWithProtocol: regexp.MustCompilePOSIX(ProtocolRegexp),
+		V2Actor:              v2Actor,
+		V3Actor:              v3Actor,
+		SharedActor:          sharedActor,
+		RouteConcurrency:     DefaultRouteConcurrency,
+		DomainBatchSize:      DefaultDomainBatchSize,
+		DomainPreference:     DefaultDomainPreference,
+		MaxHostnameLength:    DefaultMaxHostnameLength,
+		MaxRouteLabels:       DefaultMaxRouteLabels,
+		MapRouteRetries:      DefaultMapRouteRetries,
+		MapRouteRetryBackoff: DefaultMapRouteRetryBackoff,
+		RouteSchemes:         DefaultRouteSchemes,
+		InternalDomainName:   DefaultInternalDomainName,
 	}
 }
@@ -1,6 +1,7 @@
 package pushaction
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
@@ -20,6 +21,11 @@ type ApplicationConfig struct {
 	DesiredRoutes []v2action.Route
 	NoRoute       bool
 
+	// DeploymentGUID, when set, scopes the routes MapRoutes maps to the
+	// application's deployment with this GUID instead of the whole
+	// application.
+	DeploymentGUID string
+
 	CurrentServices map[string]v2action.ServiceInstance
 	DesiredServices map[string]v2action.ServiceInstance
 
@@ -44,6 +50,10 @@ func (actor Actor) ConvertToApplicationConfigs(orgGUID string, spaceGUID string,
 	var configs []ApplicationConfig
 	var warnings Warnings
 
+	if actor.missingDomains == nil {
+		actor = actor.ForPush()
+	}
+
 	log.Infof("iterating through %d app configuration(s)", len(apps))
 	for _, app := range apps {
 		absPath, err := filepath.EvalSymlinks(app.Path)
@@ -124,12 +134,34 @@ func (actor Actor) configureRoutes(manifestApp manifest.Application, orgGUID str
 	)
 
 	if len(manifestApp.Routes) > 0 {
-		config.DesiredRoutes, warnings, err = actor.CalculateRoutes(manifestApp.Routes, orgGUID, spaceGUID, config.CurrentRoutes)
-		return config, warnings, err
+		var routeWarnings RouteWarnings
+		config.DesiredRoutes, routeWarnings, err = actor.CalculateRoutes(context.TODO(), manifestApp.Routes, orgGUID, spaceGUID, config.CurrentRoutes, manifestApp.RandomRoute)
+		warnings = routeWarnings.Strings()
+		if err != nil {
+			return config, warnings, err
+		}
+
+		config.DesiredRoutes = actor.applyRouteAppPorts(config.DesiredRoutes, manifestApp.RouteAppPorts)
+		config.DesiredRoutes = actor.applyRouteMetadata(config.DesiredRoutes, manifestApp.RouteMetadata)
+		config.DesiredRoutes = actor.applyRouteSessionAffinity(config.DesiredRoutes, manifestApp.RouteSessionAffinity)
+		config.DesiredRoutes, err = actor.applyRouteOptions(config.DesiredRoutes, manifestApp.RouteOptions)
+		if err != nil {
+			log.Errorln("applying route options:", err)
+			return config, warnings, err
+		}
+		config.DesiredRoutes = actor.applyPrimaryRoutes(config.DesiredRoutes, manifestApp.PrimaryRoutes)
+		config.DesiredRoutes = actor.applyEphemeralRoutes(config.DesiredRoutes, manifestApp.EphemeralRoutes)
+		return config, warnings, nil
+	}
+
+	if actor.SkipDefaultRoute {
+		log.Debugln("skipping default route")
+		config.DesiredRoutes = config.CurrentRoutes
+		return config, nil, nil
 	}
 
 	// if routes aren't provided in the manifest
-	desiredRoute, warnings, err := actor.GetGeneratedRoute(manifestApp, orgGUID, spaceGUID, config.CurrentRoutes)
+	desiredRoute, _, warnings, err := actor.GetGeneratedRoute(manifestApp, orgGUID, spaceGUID, config.CurrentRoutes)
 	if err != nil {
 		log.Errorln("getting default route:", err)
 		return config, warnings, err
@@ -176,6 +208,18 @@ func (actor Actor) configureExistingApp(config ApplicationConfig, app manifest.A
 		return config, warnings, err
 	}
 
+	// The app ports of the current routes only matter when the manifest sets
+	// app ports, so the route mappings are not looked up otherwise.
+	if len(app.RouteAppPorts) > 0 {
+		routeMappings, mappingWarnings, err := actor.V2Actor.GetApplicationRouteMappings(foundApp.GUID)
+		warnings = append(warnings, mappingWarnings...)
+		if err != nil {
+			log.Errorln("existing route mappings lookup:", err)
+			return config, warnings, err
+		}
+		routes = actor.applyRouteMappings(routes, routeMappings)
+	}
+
 	serviceInstances, serviceWarnings, err := actor.V2Actor.GetServiceInstancesByApplication(foundApp.GUID)
 	warnings = append(warnings, serviceWarnings...)
 	if err != nil {
@@ -193,6 +237,23 @@ func (actor Actor) configureExistingApp(config ApplicationConfig, app manifest.A
 	return config, warnings, nil
 }
 
+// applyRouteMappings sets the app port of each route to the one it is mapped
+// to, as given by routeMappings. A route mapped to more than one port is given
+// the first of them.
+func (Actor) applyRouteMappings(routes []v2action.Route, routeMappings []v2action.RouteMapping) []v2action.Route {
+	mappedRoutes := make([]v2action.Route, 0, len(routes))
+	for _, route := range routes {
+		for _, routeMapping := range routeMappings {
+			if routeMapping.RouteGUID == route.GUID {
+				route.AppPort = routeMapping.AppPort
+				break
+			}
+		}
+		mappedRoutes = append(mappedRoutes, route)
+	}
+	return mappedRoutes
+}
+
 func (actor Actor) configureResources(config ApplicationConfig, dockerImagePath string) (ApplicationConfig, error) {
 	if dockerImagePath == "" {
 		info, err := os.Stat(config.Path)
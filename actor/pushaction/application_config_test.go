@@ -30,7 +30,7 @@ var _ = Describe("Application Config", func() {
 	BeforeEach(func() {
 		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
 		fakeSharedActor = new(pushactionfakes.FakeSharedActor)
-		actor = NewActor(fakeV2Actor, fakeSharedActor)
+		actor = NewActor(fakeV2Actor, nil, fakeSharedActor)
 	})
 
 	Describe("ApplicationConfig", func() {
@@ -234,6 +234,46 @@ var _ = Describe("Application Config", func() {
 						Expect(fakeV2Actor.GetServiceInstancesByApplicationCallCount()).To(Equal(1))
 						Expect(fakeV2Actor.GetServiceInstancesByApplicationArgsForCall(0)).To(Equal("some-app-guid"))
 					})
+
+					It("does not look up the route mappings when the manifest sets no app ports", func() {
+						Expect(fakeV2Actor.GetApplicationRouteMappingsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the application's routes are mapped to app ports", func() {
+					BeforeEach(func() {
+						manifestApps[0].RouteAppPorts = map[string]int{"some-route.example.com": 8080}
+						fakeV2Actor.GetApplicationRouteMappingsReturns([]v2action.RouteMapping{
+							{GUID: "route-mapping-guid", AppGUID: app.GUID, RouteGUID: route.GUID, AppPort: types.NullInt{IsSet: true, Value: 8080}},
+						}, v2action.Warnings{"route-mapping-warning"}, nil)
+					})
+
+					It("sets the app ports of the current routes", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings).To(ContainElement("route-mapping-warning"))
+
+						mappedRoute := route
+						mappedRoute.AppPort = types.NullInt{IsSet: true, Value: 8080}
+						Expect(firstConfig.CurrentRoutes).To(ConsistOf(mappedRoute))
+
+						Expect(fakeV2Actor.GetApplicationRouteMappingsCallCount()).To(Equal(1))
+						Expect(fakeV2Actor.GetApplicationRouteMappingsArgsForCall(0)).To(Equal(app.GUID))
+					})
+				})
+
+				Context("when retrieving the application's route mappings errors", func() {
+					var expectedErr error
+
+					BeforeEach(func() {
+						expectedErr = errors.New("route mappings error")
+						manifestApps[0].RouteAppPorts = map[string]int{"some-route.example.com": 8080}
+						fakeV2Actor.GetApplicationRouteMappingsReturns(nil, v2action.Warnings{"route-mapping-warning"}, expectedErr)
+					})
+
+					It("returns the error and warnings", func() {
+						Expect(executeErr).To(MatchError(expectedErr))
+						Expect(warnings).To(ConsistOf("some-app-warning-1", "some-app-warning-2", "app-route-warnings", "route-mapping-warning"))
+					})
 				})
 
 				Context("when retrieving the application's services errors", func() {
@@ -371,7 +411,7 @@ var _ = Describe("Application Config", func() {
 							"env2": "2",
 							"env3": "9",
 						},
-						GUID: "some-app-guid",
+						GUID:                    "some-app-guid",
 						HealthCheckHTTPEndpoint: "/some-endpoint",
 						HealthCheckTimeout:      5,
 						HealthCheckType:         "port",
@@ -632,6 +672,263 @@ var _ = Describe("Application Config", func() {
 					Expect(warnings).To(ConsistOf("domain-warnings-1", "domains-warnings-2"))
 				})
 			})
+
+			Context("when some of the routes have metadata", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetApplicationByNameAndSpaceReturns(v2action.Application{}, nil, actionerror.ApplicationNotFoundError{})
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+					manifestApps[0].RouteMetadata = map[string]manifest.Metadata{
+						"route-2.private-domain.com": {
+							Labels:      map[string]string{"env": "production"},
+							Annotations: map[string]string{"owner": "some-team"},
+						},
+					}
+				})
+
+				It("sets the metadata on the matching desired routes", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(firstConfig.DesiredRoutes).To(ConsistOf(v2action.Route{
+						Domain:    domain,
+						Host:      "route-1",
+						SpaceGUID: spaceGUID,
+					}, v2action.Route{
+						Domain:    domain,
+						Host:      "route-2",
+						SpaceGUID: spaceGUID,
+						Metadata: v2action.Metadata{
+							Labels:      map[string]string{"env": "production"},
+							Annotations: map[string]string{"owner": "some-team"},
+						},
+					}))
+				})
+			})
+
+			Context("when some of the routes set session affinity", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetApplicationByNameAndSpaceReturns(v2action.Application{}, nil, actionerror.ApplicationNotFoundError{})
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+					manifestApps[0].RouteSessionAffinity = map[string]bool{
+						"route-1.private-domain.com": false,
+						"route-2.private-domain.com": true,
+					}
+				})
+
+				It("sets the session affinity on the matching desired routes", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(firstConfig.DesiredRoutes).To(ConsistOf(v2action.Route{
+						Domain:          domain,
+						Host:            "route-1",
+						SpaceGUID:       spaceGUID,
+						SessionAffinity: types.NullBool{IsSet: true, Value: false},
+					}, v2action.Route{
+						Domain:          domain,
+						Host:            "route-2",
+						SpaceGUID:       spaceGUID,
+						SessionAffinity: types.NullBool{IsSet: true, Value: true},
+					}))
+				})
+
+				Context("when the same route is given session affinity more than once", func() {
+					BeforeEach(func() {
+						manifestApps[0].RouteSessionAffinity = map[string]bool{
+							"route-1.private-domain.com": true,
+							"Route-1.private-domain.com": true,
+							"ROUTE-1.private-domain.com": false,
+						}
+					})
+
+					It("uses the session affinity given first in sorted order", func() {
+						for i := 0; i < 20; i++ {
+							configs, _, err := actor.ConvertToApplicationConfigs(orgGUID, spaceGUID, noStart, manifestApps)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(configs[0].DesiredRoutes).To(ContainElement(v2action.Route{
+								Domain:          domain,
+								Host:            "route-1",
+								SpaceGUID:       spaceGUID,
+								SessionAffinity: types.NullBool{IsSet: true, Value: false},
+							}))
+						}
+					})
+				})
+			})
+
+			Context("when some of the routes set options", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetApplicationByNameAndSpaceReturns(v2action.Application{}, nil, actionerror.ApplicationNotFoundError{})
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+					manifestApps[0].RouteOptions = map[string]map[string]string{
+						"route-2.private-domain.com": {"loadbalancing": "least-connection"},
+					}
+				})
+
+				It("sets the options on the matching desired routes", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(firstConfig.DesiredRoutes).To(ConsistOf(v2action.Route{
+						Domain:    domain,
+						Host:      "route-1",
+						SpaceGUID: spaceGUID,
+					}, v2action.Route{
+						Domain:    domain,
+						Host:      "route-2",
+						SpaceGUID: spaceGUID,
+						Options:   map[string]string{"loadbalancing": "least-connection"},
+					}))
+				})
+
+				Context("when the same route is given options more than once", func() {
+					BeforeEach(func() {
+						manifestApps[0].RouteOptions = map[string]map[string]string{
+							"route-2.private-domain.com": {"loadbalancing": "least-connection"},
+							"Route-2.private-domain.com": {"loadbalancing": "least-connection"},
+							"ROUTE-2.private-domain.com": {"loadbalancing": "round-robin"},
+						}
+					})
+
+					It("uses the options given first in sorted order", func() {
+						for i := 0; i < 20; i++ {
+							configs, _, err := actor.ConvertToApplicationConfigs(orgGUID, spaceGUID, noStart, manifestApps)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(configs[0].DesiredRoutes).To(ContainElement(v2action.Route{
+								Domain:    domain,
+								Host:      "route-2",
+								SpaceGUID: spaceGUID,
+								Options:   map[string]string{"loadbalancing": "round-robin"},
+							}))
+						}
+					})
+				})
+
+				Context("when a route sets an unknown option", func() {
+					BeforeEach(func() {
+						manifestApps[0].RouteOptions = map[string]map[string]string{
+							"route-2.private-domain.com": {"retries": "3"},
+						}
+					})
+
+					It("returns an UnknownRouteOptionError", func() {
+						Expect(executeErr).To(MatchError(actionerror.UnknownRouteOptionError{
+							Route:  "route-2.private-domain.com",
+							Option: "retries",
+						}))
+					})
+				})
+
+				Context("when a route sets an option to an invalid value", func() {
+					BeforeEach(func() {
+						manifestApps[0].RouteOptions = map[string]map[string]string{
+							"route-1.private-domain.com": {"loadbalancing": "random"},
+						}
+					})
+
+					It("returns an InvalidRouteOptionValueError", func() {
+						Expect(executeErr).To(MatchError(actionerror.InvalidRouteOptionValueError{
+							Route:   "route-1.private-domain.com",
+							Option:  "loadbalancing",
+							Value:   "random",
+							Allowed: []string{"round-robin", "least-connection"},
+						}))
+					})
+				})
+			})
+
+			Context("when one of the routes is primary", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetApplicationByNameAndSpaceReturns(v2action.Application{}, nil, actionerror.ApplicationNotFoundError{})
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+					manifestApps[0].PrimaryRoutes = []string{"route-2.private-domain.com"}
+				})
+
+				It("marks the matching desired route as primary", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(firstConfig.DesiredRoutes).To(ConsistOf(v2action.Route{
+						Domain:    domain,
+						Host:      "route-1",
+						SpaceGUID: spaceGUID,
+					}, v2action.Route{
+						Domain:    domain,
+						Host:      "route-2",
+						SpaceGUID: spaceGUID,
+						Primary:   true,
+					}))
+				})
+			})
+
+			Context("when one of the routes is ephemeral", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetApplicationByNameAndSpaceReturns(v2action.Application{}, nil, actionerror.ApplicationNotFoundError{})
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+					manifestApps[0].EphemeralRoutes = []string{"route-1.private-domain.com"}
+				})
+
+				It("marks the matching desired route as ephemeral", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(firstConfig.DesiredRoutes).To(ConsistOf(v2action.Route{
+						Domain:    domain,
+						Host:      "route-1",
+						SpaceGUID: spaceGUID,
+						Ephemeral: true,
+					}, v2action.Route{
+						Domain:    domain,
+						Host:      "route-2",
+						SpaceGUID: spaceGUID,
+					}))
+				})
+			})
+
+			Context("when some of the routes have app ports", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetApplicationByNameAndSpaceReturns(v2action.Application{}, nil, actionerror.ApplicationNotFoundError{})
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+					manifestApps[0].RouteAppPorts = map[string]int{
+						"route-1.private-domain.com": 8080,
+						"route-2.private-domain.com": 9090,
+					}
+				})
+
+				It("sets the app ports on the matching desired routes", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(firstConfig.DesiredRoutes).To(ConsistOf(v2action.Route{
+						Domain:    domain,
+						Host:      "route-1",
+						SpaceGUID: spaceGUID,
+						AppPort:   types.NullInt{IsSet: true, Value: 8080},
+					}, v2action.Route{
+						Domain:    domain,
+						Host:      "route-2",
+						SpaceGUID: spaceGUID,
+						AppPort:   types.NullInt{IsSet: true, Value: 9090},
+					}))
+				})
+
+				Context("when the same route is given an app port more than once", func() {
+					BeforeEach(func() {
+						manifestApps[0].RouteAppPorts = map[string]int{
+							"route-1.private-domain.com": 8080,
+							"Route-1.private-domain.com": 7070,
+							"ROUTE-1.private-domain.com": 6060,
+						}
+					})
+
+					It("uses the app port given first in sorted order", func() {
+						for i := 0; i < 20; i++ {
+							configs, _, err := actor.ConvertToApplicationConfigs(orgGUID, spaceGUID, noStart, manifestApps)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(configs[0].DesiredRoutes).To(ContainElement(v2action.Route{
+								Domain:    domain,
+								Host:      "route-1",
+								SpaceGUID: spaceGUID,
+								AppPort:   types.NullInt{IsSet: true, Value: 6060},
+							}))
+						}
+					})
+				})
+			})
 		})
 
 		Context("when routes are not defined", func() {
@@ -745,6 +1042,43 @@ var _ = Describe("Application Config", func() {
 					})
 				})
 			})
+
+			Context("when SkipDefaultRoute is set", func() {
+				BeforeEach(func() {
+					actor.SkipDefaultRoute = true
+				})
+
+				It("keeps the current routes without generating a default route", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("app-route-warnings"))
+					Expect(firstConfig.DesiredRoutes).To(ConsistOf(existingRoute))
+
+					Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(0))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when several applications use the default domain", func() {
+			BeforeEach(func() {
+				actor.DomainCache = NewDomainCache(DefaultDomainCacheTTL)
+				manifestApps = append(manifestApps, manifest.Application{
+					Name: "some-other-app",
+					Path: filesPath,
+				})
+				fakeV2Actor.GetApplicationByNameAndSpaceReturns(v2action.Application{}, nil, actionerror.ApplicationNotFoundError{})
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+			})
+
+			It("looks up the default domain once and returns its warnings once", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings"))
+				Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(1))
+
+				Expect(configs).To(HaveLen(2))
+				Expect(configs[0].DesiredRoutes).To(ConsistOf(v2action.Route{Domain: domain, Host: appName, SpaceGUID: spaceGUID}))
+				Expect(configs[1].DesiredRoutes).To(ConsistOf(v2action.Route{Domain: domain, Host: "some-other-app", SpaceGUID: spaceGUID}))
+			})
 		})
 
 		Context("when scanning for files", func() {
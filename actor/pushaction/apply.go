@@ -1,9 +1,11 @@
 package pushaction
 
 import (
+	"context"
 	"os"
 
 	"code.cloudfoundry.org/cli/actor/actionerror"
+	"code.cloudfoundry.org/cli/actor/v2action"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 
 	log "github.com/sirupsen/logrus"
@@ -41,7 +43,7 @@ func (actor Actor) Apply(config ApplicationConfig, progressBar ProgressBar) (<-c
 		if config.NoRoute {
 			if len(config.CurrentRoutes) > 0 {
 				eventStream <- UnmappingRoutes
-				config, warnings, err = actor.UnmapRoutes(config)
+				config, warnings, err = actor.UnmapRoutes(context.TODO(), config)
 				warningsStream <- warnings
 				if err != nil {
 					errorStream <- err
@@ -51,21 +53,24 @@ func (actor Actor) Apply(config ApplicationConfig, progressBar ProgressBar) (<-c
 		} else {
 			eventStream <- CreatingAndMappingRoutes
 
-			var createdRoutes bool
-			config, createdRoutes, warnings, err = actor.CreateRoutes(config)
-			warningsStream <- warnings
+			var (
+				createdRoutes []v2action.Route
+				routeWarnings RouteWarnings
+			)
+			config, createdRoutes, routeWarnings, err = actor.CreateRoutes(context.TODO(), config, nil)
+			warningsStream <- routeWarnings.AtLeast(SeverityWarning).Strings()
 			if err != nil {
 				errorStream <- err
 				return
 			}
-			if createdRoutes {
+			if len(createdRoutes) > 0 {
 				log.Debugf("updated desired routes: %#v", config.DesiredRoutes)
 				eventStream <- CreatedRoutes
 			}
 
 			var boundRoutes bool
-			config, boundRoutes, warnings, err = actor.MapRoutes(config)
-			warningsStream <- warnings
+			config, boundRoutes, _, routeWarnings, err = actor.MapRoutes(context.TODO(), config, nil)
+			warningsStream <- routeWarnings.AtLeast(SeverityWarning).Strings()
 			if err != nil {
 				errorStream <- err
 				return
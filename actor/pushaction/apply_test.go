@@ -60,7 +60,7 @@ var _ = Describe("Apply", func() {
 	BeforeEach(func() {
 		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
 		fakeSharedActor = new(pushactionfakes.FakeSharedActor)
-		actor = NewActor(fakeV2Actor, fakeSharedActor)
+		actor = NewActor(fakeV2Actor, nil, fakeSharedActor)
 		config = ApplicationConfig{
 			DesiredApplication: Application{
 				Application: v2action.Application{
@@ -440,6 +440,7 @@ var _ = Describe("Apply", func() {
 
 					BeforeEach(func() {
 						expectedErr = errors.New("dios mio")
+						actor.RouteConcurrency = 1
 						fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmapping-route-warnings-1", "unmapping-route-warnings-2"}, expectedErr)
 					})
 
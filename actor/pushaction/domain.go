@@ -1,14 +1,44 @@
 package pushaction
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/actor/actionerror"
 	"code.cloudfoundry.org/cli/actor/v2action"
 	log "github.com/sirupsen/logrus"
 )
 
+// ForPush returns a copy of the actor for a single push. Domain names that the
+// copy does not find when looking up the domain named by a manifest are
+// remembered, and looking them up again returns a DomainNotFoundError without
+// another request. Nothing is remembered by the actor itself, so each push
+// starts with an empty set of missing domains.
+func (actor Actor) ForPush() Actor {
+	actor.missingDomains = newMissingDomains()
+	return actor
+}
+
 // DefaultDomain looks up the shared and then private domains and returns back
-// the first one in the list as the default.
+// the first one in the list that is not internal as the default. The default
+// domain is stored in the DomainCache, so that pushing several applications to
+// the same organization only looks it up, and returns its warnings, once.
+// When DefaultDomainOverride is set, it is returned for every organization
+// without any lookup.
 func (actor Actor) DefaultDomain(orgGUID string) (v2action.Domain, Warnings, error) {
+	if actor.DefaultDomainOverride != nil {
+		log.WithField("domain", actor.DefaultDomainOverride.Name).Debug("using default domain override")
+		return *actor.DefaultDomainOverride, nil, nil
+	}
+
+	if actor.DomainCache != nil {
+		domain, cached := actor.DomainCache.LookupDefault(orgGUID)
+		actor.countDomainCacheLookup(cached)
+		if cached {
+			log.WithField("domain", domain.Name).Debug("using default domain from cache")
+			return domain, nil, nil
+		}
+	}
+
 	log.Infoln("getting org domains for org GUID:", orgGUID)
 	// the domains object contains all the shared domains AND all domains private to this org
 	domains, warnings, err := actor.V2Actor.GetOrganizationDomains(orgGUID)
@@ -17,11 +47,233 @@ func (actor Actor) DefaultDomain(orgGUID string) (v2action.Domain, Warnings, err
 		return v2action.Domain{}, Warnings(warnings), err
 	}
 
-	if len(domains) == 0 {
-		log.Error("no domains found")
-		return v2action.Domain{}, Warnings(warnings), actionerror.NoDomainsFoundError{OrganizationGUID: orgGUID}
+	for _, domain := range domains {
+		domain = actor.markInternalDomain(domain)
+		if domain.IsInternal() {
+			continue
+		}
+
+		log.Debugf("selecting first external domain as default domain: %#v", domains)
+		if actor.DomainCache != nil {
+			actor.DomainCache.StoreDefault(orgGUID, domain)
+		}
+		return domain, Warnings(warnings), nil
 	}
 
-	log.Debugf("selecting first domain as default domain: %#v", domains)
-	return domains[0], Warnings(warnings), nil
+	log.Error("no domains found")
+	return v2action.Domain{}, Warnings(warnings), actionerror.NoDomainsFoundError{OrganizationGUID: orgGUID}
+}
+
+// getDomainsByName returns the domains with the provided names in the
+// organization, keyed by name. Names that are in the DomainCache are not
+// looked up again; when all of them are cached, no lookup is made. Each name
+// counts as a DomainCache hit or miss in Metrics. The
+// remaining names are looked up DomainBatchSize at a time to keep request URLs
+// short.
+func (actor Actor) getDomainsByName(names []string, orgGUID string) (map[string]v2action.Domain, Warnings, error) {
+	nameToFoundDomain := map[string]v2action.Domain{}
+
+	uncachedNames := names
+	if actor.DomainCache != nil {
+		uncachedNames = nil
+		for _, name := range names {
+			domain, exists, cached := actor.DomainCache.Lookup(orgGUID, name)
+			actor.countDomainCacheLookup(cached)
+			switch {
+			case !cached:
+				uncachedNames = append(uncachedNames, name)
+			case exists:
+				log.WithField("domain", domain.Name).Debug("using domain from cache")
+				nameToFoundDomain[domain.Name] = domain
+			}
+		}
+
+		if len(uncachedNames) == 0 {
+			return nameToFoundDomain, nil, nil
+		}
+	}
+
+	foundDomains, warnings, err := actor.lookupDomainsByName(uncachedNames, orgGUID)
+	if err != nil {
+		return nil, warnings, err
+	}
+	for name, domain := range foundDomains {
+		nameToFoundDomain[name] = domain
+	}
+	return nameToFoundDomain, warnings, nil
+}
+
+// lookupDomainsByName looks up the domains with the provided names in the
+// organization, keyed by name, without consulting the DomainCache. The results
+// are stored in the DomainCache, replacing any that were cached before. The
+// names are looked up DomainBatchSize at a time to keep request URLs short.
+func (actor Actor) lookupDomainsByName(names []string, orgGUID string) (map[string]v2action.Domain, Warnings, error) {
+	nameToFoundDomain := map[string]v2action.Domain{}
+
+	var allWarnings Warnings
+	for _, batch := range actor.domainBatches(names) {
+		observed := actor.observe(GetDomainsByNameAndOrganizationOperation)
+		foundDomains, warnings, err := actor.V2Actor.GetDomainsByNameAndOrganization(batch, orgGUID)
+		observed()
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			return nil, allWarnings, err
+		}
+		for i := range foundDomains {
+			foundDomains[i] = actor.markInternalDomain(foundDomains[i])
+		}
+		if actor.DomainCache != nil {
+			actor.DomainCache.Store(orgGUID, batch, foundDomains)
+		}
+
+		for _, foundDomain := range foundDomains {
+			log.WithField("domain", foundDomain.Name).Debug("found domain")
+			nameToFoundDomain[foundDomain.Name] = foundDomain
+		}
+	}
+	return nameToFoundDomain, allWarnings, nil
+}
+
+// domainBatches splits names into batches of at most DomainBatchSize names.
+// When DomainBatchSize is not positive, all of the names are in one batch.
+// There are no batches when there are no names, so nothing is looked up.
+func (actor Actor) domainBatches(names []string) [][]string {
+	if len(names) == 0 {
+		return nil
+	}
+	if actor.DomainBatchSize <= 0 || len(names) <= actor.DomainBatchSize {
+		return [][]string{names}
+	}
+
+	var batches [][]string
+	for len(names) > actor.DomainBatchSize {
+		batches = append(batches, names[:actor.DomainBatchSize])
+		names = names[actor.DomainBatchSize:]
+	}
+	return append(batches, names)
+}
+
+// preferredDomain returns the first of the domains with the most preferred
+// type in DomainPreference. When none of the domains has a listed type, the
+// first domain is returned.
+func (actor Actor) preferredDomain(domains []v2action.Domain) v2action.Domain {
+	for _, domainType := range actor.DomainPreference {
+		for _, domain := range domains {
+			if domain.Type == domainType {
+				return domain
+			}
+		}
+	}
+	return domains[0]
+}
+
+// markInternalDomain returns the domain marked as internal when its name is
+// the InternalDomainName.
+func (actor Actor) markInternalDomain(domain v2action.Domain) v2action.Domain {
+	if actor.InternalDomainName != "" && strings.EqualFold(domain.Name, actor.InternalDomainName) {
+		domain.Internal = true
+	}
+	return domain
+}
+
+// maxDomainSuggestionCandidates is the most domains an organization can have
+// for withDomainSuggestion to look for a close match among them.
+const maxDomainSuggestionCandidates = 100
+
+// maxDomainSuggestionDistance is the most edits a domain name can be from the
+// missing domain's name to be suggested.
+const maxDomainSuggestionDistance = 3
+
+// withDomainSuggestion returns the error with the name of the organization's
+// domain that is closest to the missing domain's name as its suggestion, when
+// the error is a DomainNotFoundError for a name. A domain is close when its
+// name is at most maxDomainSuggestionDistance edits, and at most a third of
+// the missing name's length, away from the missing name, ignoring case. Nothing
+// is suggested when the organization has more than
+// maxDomainSuggestionCandidates domains. Looking for a suggestion is best
+// effort: its warnings and errors are only logged and any other error is
+// returned unchanged.
+func (actor Actor) withDomainSuggestion(err error, orgGUID string) error {
+	notFoundErr, ok := err.(actionerror.DomainNotFoundError)
+	if !ok || notFoundErr.Name == "" {
+		return err
+	}
+
+	domains, warnings, lookupErr := actor.V2Actor.GetOrganizationDomains(orgGUID)
+	if lookupErr != nil {
+		log.WithField("warnings", warnings).Errorln("looking up domains to suggest:", lookupErr)
+		return err
+	}
+	if len(domains) > maxDomainSuggestionCandidates {
+		log.WithField("domains", len(domains)).Debug("too many domains to suggest one")
+		return err
+	}
+
+	maxDistance := maxDomainSuggestionDistance
+	if third := len(notFoundErr.Name) / 3; third < maxDistance {
+		maxDistance = third
+	}
+
+	name := strings.ToLower(notFoundErr.Name)
+	bestDistance := maxDistance + 1
+	for _, domain := range domains {
+		if domain.Name == notFoundErr.Name {
+			continue
+		}
+		if distance := editDistance(name, strings.ToLower(domain.Name), maxDistance); distance < bestDistance {
+			bestDistance = distance
+			notFoundErr.Suggestion = domain.Name
+		}
+	}
+	if notFoundErr.Suggestion != "" {
+		log.WithField("suggestion", notFoundErr.Suggestion).Debug("suggesting domain")
+	}
+	return notFoundErr
+}
+
+// editDistance returns the Levenshtein distance between a and b, counted in
+// bytes. Once the distance is known to be more than limit, limit+1 is returned
+// without finishing the computation.
+func editDistance(a string, b string, limit int) int {
+	if len(a)-len(b) > limit || len(b)-len(a) > limit {
+		return limit + 1
+	}
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if current[j] < rowMin {
+				rowMin = current[j]
+			}
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		previous, current = current, previous
+	}
+	if previous[len(b)] > limit {
+		return limit + 1
+	}
+	return previous[len(b)]
+}
+
+// minInt returns the smallest of the provided values.
+func minInt(first int, rest ...int) int {
+	for _, value := range rest {
+		if value < first {
+			first = value
+		}
+	}
+	return first
 }
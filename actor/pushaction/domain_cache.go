@@ -0,0 +1,136 @@
+package pushaction
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+)
+
+// DefaultDomainCacheTTL is a suitable TTL for a DomainCache that lives as long
+// as a single push.
+const DefaultDomainCacheTTL = 5 * time.Minute
+
+// DomainCache caches the results of looking up domains by name in an
+// organization, including names that were not found, as well as each
+// organization's default domain. It is safe for concurrent use.
+type DomainCache struct {
+	ttl time.Duration
+
+	mutex    sync.RWMutex
+	entries  map[domainCacheKey]domainCacheEntry
+	defaults map[string]domainCacheEntry
+}
+
+type domainCacheKey struct {
+	orgGUID string
+	name    string
+}
+
+type domainCacheEntry struct {
+	domain  v2action.Domain
+	exists  bool
+	expires time.Time
+}
+
+// NewDomainCache returns a DomainCache whose entries expire after ttl.
+func NewDomainCache(ttl time.Duration) *DomainCache {
+	return &DomainCache{
+		ttl:      ttl,
+		entries:  map[domainCacheKey]domainCacheEntry{},
+		defaults: map[string]domainCacheEntry{},
+	}
+}
+
+// Lookup returns the cached domain with the provided name in the provided
+// organization. The first bool is whether the domain exists and the second is
+// whether the lookup was cached at all.
+func (cache *DomainCache) Lookup(orgGUID string, name string) (v2action.Domain, bool, bool) {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	entry, found := cache.entries[domainCacheKey{orgGUID: orgGUID, name: name}]
+	if !found || time.Now().After(entry.expires) {
+		return v2action.Domain{}, false, false
+	}
+	return entry.domain, entry.exists, true
+}
+
+// Store records the result of looking up names in the provided organization.
+// Every name without a matching domain in domains is cached as not existing.
+func (cache *DomainCache) Store(orgGUID string, names []string, domains []v2action.Domain) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	expires := time.Now().Add(cache.ttl)
+	for _, name := range names {
+		cache.entries[domainCacheKey{orgGUID: orgGUID, name: name}] = domainCacheEntry{expires: expires}
+	}
+	for _, domain := range domains {
+		cache.entries[domainCacheKey{orgGUID: orgGUID, name: domain.Name}] = domainCacheEntry{
+			domain:  domain,
+			exists:  true,
+			expires: expires,
+		}
+	}
+}
+
+// LookupDefault returns the cached default domain of the provided
+// organization and whether it was cached.
+func (cache *DomainCache) LookupDefault(orgGUID string) (v2action.Domain, bool) {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	entry, found := cache.defaults[orgGUID]
+	if !found || time.Now().After(entry.expires) {
+		return v2action.Domain{}, false
+	}
+	return entry.domain, true
+}
+
+// StoreDefault records domain as the default domain of the provided
+// organization.
+func (cache *DomainCache) StoreDefault(orgGUID string, domain v2action.Domain) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.defaults[orgGUID] = domainCacheEntry{
+		domain:  domain,
+		exists:  true,
+		expires: time.Now().Add(cache.ttl),
+	}
+}
+
+// missingDomains remembers the domain names that were not found in an
+// organization during a single push; see Actor.ForPush. It is safe for
+// concurrent use. A nil missingDomains remembers nothing.
+type missingDomains struct {
+	mutex sync.RWMutex
+	names map[domainCacheKey]bool
+}
+
+func newMissingDomains() *missingDomains {
+	return &missingDomains{names: map[domainCacheKey]bool{}}
+}
+
+// contains returns true when the name was not found in the organization.
+func (missing *missingDomains) contains(orgGUID string, name string) bool {
+	if missing == nil {
+		return false
+	}
+
+	missing.mutex.RLock()
+	defer missing.mutex.RUnlock()
+	return missing.names[domainCacheKey{orgGUID: orgGUID, name: name}]
+}
+
+// add records that the name was not found in the organization.
+func (missing *missingDomains) add(orgGUID string, name string) {
+	if missing == nil {
+		return
+	}
+
+	missing.mutex.Lock()
+	defer missing.mutex.Unlock()
+	missing.names[domainCacheKey{orgGUID: orgGUID, name: name}] = true
+}
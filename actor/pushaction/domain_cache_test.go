@@ -0,0 +1,118 @@
+package pushaction_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/v2action"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DomainCache", func() {
+	var (
+		cache  *DomainCache
+		domain v2action.Domain
+	)
+
+	BeforeEach(func() {
+		cache = NewDomainCache(time.Minute)
+		domain = v2action.Domain{GUID: "some-domain-guid", Name: "example.com"}
+	})
+
+	Context("when nothing has been stored", func() {
+		It("misses", func() {
+			_, _, cached := cache.Lookup("some-org-guid", "example.com")
+			Expect(cached).To(BeFalse())
+		})
+	})
+
+	Context("when lookups have been stored", func() {
+		BeforeEach(func() {
+			cache.Store("some-org-guid", []string{"example.com", "missing.example.com"}, []v2action.Domain{domain})
+		})
+
+		It("returns the domains that were found", func() {
+			cachedDomain, exists, cached := cache.Lookup("some-org-guid", "example.com")
+			Expect(cached).To(BeTrue())
+			Expect(exists).To(BeTrue())
+			Expect(cachedDomain).To(Equal(domain))
+		})
+
+		It("remembers the names that were not found", func() {
+			_, exists, cached := cache.Lookup("some-org-guid", "missing.example.com")
+			Expect(cached).To(BeTrue())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("misses for other organizations", func() {
+			_, _, cached := cache.Lookup("some-other-org-guid", "example.com")
+			Expect(cached).To(BeFalse())
+		})
+	})
+
+	Context("when the entries have expired", func() {
+		BeforeEach(func() {
+			cache = NewDomainCache(time.Millisecond)
+			cache.Store("some-org-guid", []string{"example.com"}, []v2action.Domain{domain})
+			time.Sleep(5 * time.Millisecond)
+		})
+
+		It("misses", func() {
+			_, _, cached := cache.Lookup("some-org-guid", "example.com")
+			Expect(cached).To(BeFalse())
+		})
+	})
+
+	Describe("default domains", func() {
+		It("misses when nothing has been stored", func() {
+			_, cached := cache.LookupDefault("some-org-guid")
+			Expect(cached).To(BeFalse())
+		})
+
+		It("returns the stored default domain of the organization", func() {
+			cache.StoreDefault("some-org-guid", domain)
+
+			cachedDomain, cached := cache.LookupDefault("some-org-guid")
+			Expect(cached).To(BeTrue())
+			Expect(cachedDomain).To(Equal(domain))
+
+			_, cached = cache.LookupDefault("some-other-org-guid")
+			Expect(cached).To(BeFalse())
+		})
+
+		It("misses when the default domain has expired", func() {
+			cache = NewDomainCache(time.Millisecond)
+			cache.StoreDefault("some-org-guid", domain)
+			time.Sleep(5 * time.Millisecond)
+
+			_, cached := cache.LookupDefault("some-org-guid")
+			Expect(cached).To(BeFalse())
+		})
+	})
+
+	It("can be used concurrently", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				name := fmt.Sprintf("domain-%d.com", i)
+				cache.Store("some-org-guid", []string{name}, []v2action.Domain{{Name: name}})
+				_, exists, cached := cache.Lookup("some-org-guid", name)
+				Expect(cached).To(BeTrue())
+				Expect(exists).To(BeTrue())
+			}(i)
+		}
+		wg.Wait()
+	})
+
+	It("is not used unless the caller opts in", func() {
+		Expect(NewActor(nil, nil, nil).DomainCache).To(BeNil())
+	})
+})
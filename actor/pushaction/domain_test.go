@@ -20,7 +20,7 @@ var _ = Describe("Domains", func() {
 
 	BeforeEach(func() {
 		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
-		actor = NewActor(fakeV2Actor, nil)
+		actor = NewActor(fakeV2Actor, nil, nil)
 	})
 
 	Describe("DefaultDomain", func() {
@@ -71,6 +71,154 @@ var _ = Describe("Domains", func() {
 				Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(1))
 				Expect(fakeV2Actor.GetOrganizationDomainsArgsForCall(0)).To(Equal(orgGUID))
 			})
+
+			Context("when there is a DomainCache", func() {
+				BeforeEach(func() {
+					actor.DomainCache = NewDomainCache(DefaultDomainCacheTTL)
+				})
+
+				It("uses the cached default domain for later lookups in the organization", func() {
+					cachedDomain, cachedWarnings, err := actor.DefaultDomain(orgGUID)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(cachedWarnings).To(BeEmpty())
+					Expect(cachedDomain).To(Equal(defaultDomain))
+
+					Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(1))
+				})
+			})
+
+			It("looks up the default domain of other organizations", func() {
+				_, otherWarnings, err := actor.DefaultDomain("some-other-org-guid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(otherWarnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings"))
+
+				Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(2))
+				Expect(fakeV2Actor.GetOrganizationDomainsArgsForCall(1)).To(Equal("some-other-org-guid"))
+			})
+
+			Context("when the cache is disabled", func() {
+				BeforeEach(func() {
+					actor.DomainCache = nil
+				})
+
+				It("looks up the default domain every time", func() {
+					_, _, err := actor.DefaultDomain(orgGUID)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when there is a DefaultDomainOverride", func() {
+			BeforeEach(func() {
+				actor.DefaultDomainOverride = &v2action.Domain{
+					Name: "override-domain.com",
+					GUID: "some-override-domain-guid",
+				}
+				actor.DomainCache = NewDomainCache(DefaultDomainCacheTTL)
+			})
+
+			It("returns the override without looking up the organization's domains", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+				Expect(defaultDomain).To(Equal(v2action.Domain{
+					Name: "override-domain.com",
+					GUID: "some-override-domain-guid",
+				}))
+
+				Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(0))
+			})
+
+			It("does not store the override in the DomainCache", func() {
+				_, cached := actor.DomainCache.LookupDefault(orgGUID)
+				Expect(cached).To(BeFalse())
+			})
+		})
+
+		Context("when the first domains are internal", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{
+					{
+						Name:     "apps.internal",
+						GUID:     "some-internal-domain-guid",
+						Internal: true,
+					},
+					{
+						Name: "shared-domain.com",
+						GUID: "some-shared-domain-guid",
+					},
+				},
+					v2action.Warnings{"private-domain-warnings", "shared-domain-warnings"},
+					nil,
+				)
+			})
+
+			It("returns the first external domain and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings"))
+				Expect(defaultDomain).To(Equal(v2action.Domain{
+					Name: "shared-domain.com",
+					GUID: "some-shared-domain-guid",
+				}))
+			})
+		})
+
+		Context("when the first domain has the internal domain name but is not reported as internal", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{
+					{
+						Name: "apps.internal",
+						GUID: "some-internal-domain-guid",
+					},
+					{
+						Name: "shared-domain.com",
+						GUID: "some-shared-domain-guid",
+					},
+				},
+					v2action.Warnings{"private-domain-warnings"},
+					nil,
+				)
+			})
+
+			It("skips it and returns the first external domain", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(defaultDomain).To(Equal(v2action.Domain{
+					Name: "shared-domain.com",
+					GUID: "some-shared-domain-guid",
+				}))
+			})
+
+			Context("when the internal domain name is not configured", func() {
+				BeforeEach(func() {
+					actor.InternalDomainName = ""
+				})
+
+				It("returns the first domain", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(defaultDomain.Name).To(Equal("apps.internal"))
+				})
+			})
+		})
+
+		Context("when only internal domains exist", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{
+					{
+						Name:     "apps.internal",
+						GUID:     "some-internal-domain-guid",
+						Internal: true,
+					},
+				},
+					v2action.Warnings{"private-domain-warnings"},
+					nil,
+				)
+			})
+
+			It("returns a NoDomainsFoundError and warnings", func() {
+				Expect(executeErr).To(MatchError(actionerror.NoDomainsFoundError{OrganizationGUID: orgGUID}))
+				Expect(warnings).To(ConsistOf("private-domain-warnings"))
+			})
 		})
 
 		Context("no domains exist", func() {
@@ -1,5 +1,7 @@
 package pushaction
 
+import "code.cloudfoundry.org/cli/actor/v2action"
+
 type Event string
 
 const (
@@ -20,3 +22,32 @@ const (
 	RetryUpload                     Event = "retry upload"
 	Complete                        Event = "complete"
 )
+
+// RouteAction is what happened to a route during CreateRoutes or MapRoutes.
+type RouteAction string
+
+const (
+	RouteCreated RouteAction = "created"
+	RouteMapped  RouteAction = "mapped"
+	RouteSkipped RouteAction = "skipped"
+)
+
+// RouteEvent reports the progress of a single route during CreateRoutes or
+// MapRoutes.
+type RouteEvent struct {
+	// Route is the route in a human readable format.
+	Route string
+	// Action is what happened to the route.
+	Action RouteAction
+}
+
+// RouteProgressFunc is called with each RouteEvent as routes are created or
+// mapped.
+type RouteProgressFunc func(event RouteEvent)
+
+// report calls f with an event for the route, unless f is nil.
+func (f RouteProgressFunc) report(route v2action.Route, action RouteAction) {
+	if f != nil {
+		f(RouteEvent{Route: route.String(), Action: action})
+	}
+}
@@ -0,0 +1,89 @@
+package pushaction
+
+import (
+	"strings"
+	"unicode"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	log "github.com/sirupsen/logrus"
+)
+
+// HostnameOptions are the settings GenerateHostname uses to turn an
+// application's name into the host of its generated route.
+type HostnameOptions struct {
+	// Hostname is used instead of the application's name when it is set.
+	Hostname string
+
+	// NoHostname generates an empty host.
+	NoHostname bool
+
+	// MaxLength is the length the host is truncated to. Zero disables
+	// truncation.
+	MaxLength int
+
+	// AllowedCharacters are characters, such as the underscore, that are kept
+	// in the host instead of being dropped.
+	AllowedCharacters string
+}
+
+// GenerateHostname returns the host push gives the generated route of the
+// application with the provided name. The name, or opts.Hostname when it is
+// set, is lowercased, spaces are replaced with hyphens and any other invalid
+// characters that are not in opts.AllowedCharacters are dropped. An
+// InvalidHostnameError is returned when nothing usable is left of a name that
+// is not blank.
+func GenerateHostname(name string, opts HostnameOptions) (string, error) {
+	if opts.NoHostname {
+		return "", nil
+	}
+
+	hostname := opts.Hostname
+	if hostname == "" {
+		hostname = name
+	}
+
+	sanitizedHostname := sanitizeHostname(hostname, opts.MaxLength, opts.AllowedCharacters)
+	if sanitizedHostname == "" && strings.TrimSpace(hostname) != "" {
+		log.Errorln("hostname sanitized to an empty string:", hostname)
+		return "", actionerror.InvalidHostnameError{Original: hostname}
+	}
+	return sanitizedHostname, nil
+}
+
+// sanitizeHostname converts name into a hostname by lowercasing it, replacing
+// spaces with hyphens and dropping any other invalid characters that are not
+// in allowedCharacters. The hostname is truncated to maxLength when it is
+// positive. It returns an empty string when name has no letters or numbers.
+func sanitizeHostname(name string, maxLength int, allowedCharacters string) string {
+	sanitizedName := []rune{}
+	validCount := 0
+
+	for _, runeChar := range strings.TrimSpace(name) {
+		switch {
+		case 'a' <= runeChar && runeChar <= 'z':
+			sanitizedName = append(sanitizedName, runeChar)
+			validCount++
+		case 'A' <= runeChar && runeChar <= 'Z':
+			sanitizedName = append(sanitizedName, unicode.ToLower(runeChar))
+			validCount++
+		case ' ' == runeChar || '-' == runeChar:
+			sanitizedName = append(sanitizedName, '-')
+		case '0' <= runeChar && runeChar <= '9':
+			sanitizedName = append(sanitizedName, runeChar)
+			validCount++
+		case strings.ContainsRune(allowedCharacters, runeChar):
+			sanitizedName = append(sanitizedName, runeChar)
+		}
+	}
+
+	if validCount == 0 {
+		return ""
+	}
+
+	hostname := strings.Trim(string(sanitizedName), "-")
+	if maxLength > 0 && len(hostname) > maxLength {
+		hostname = strings.TrimRight(hostname[:maxLength], "-")
+	}
+
+	return hostname
+}
@@ -0,0 +1,52 @@
+package pushaction_test
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GenerateHostname", func() {
+	DescribeTable("generating hosts",
+		func(name string, opts HostnameOptions, expectedHostname string, expectedErr error) {
+			hostname, err := GenerateHostname(name, opts)
+			if expectedErr != nil {
+				Expect(err).To(MatchError(expectedErr))
+				return
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hostname).To(Equal(expectedHostname))
+		},
+
+		Entry("simple name", "some-app", HostnameOptions{}, "some-app", nil),
+		Entry("upper case letters", "Some-App", HostnameOptions{}, "some-app", nil),
+		Entry("spaces and symbols", "$Some App 1234567890", HostnameOptions{}, "some-app-1234567890", nil),
+		Entry("leading and trailing invalid characters", " %^ @# app **(& ", HostnameOptions{}, "app", nil),
+		Entry("leading and trailing hyphens", "--app--", HostnameOptions{}, "app", nil),
+		Entry("blank name", "  ", HostnameOptions{}, "", nil),
+		Entry("no usable characters", " %^ @# **(& ", HostnameOptions{}, "",
+			actionerror.InvalidHostnameError{Original: " %^ @# **(& "}),
+		Entry("only emoji", "🚀🚀", HostnameOptions{}, "",
+			actionerror.InvalidHostnameError{Original: "🚀🚀"}),
+		Entry("hostname instead of the name", "some-app", HostnameOptions{Hostname: "Some Host"}, "some-host", nil),
+		Entry("unusable hostname", "some-app", HostnameOptions{Hostname: "🚀🚀"}, "",
+			actionerror.InvalidHostnameError{Original: "🚀🚀"}),
+		Entry("no hostname", "some-app", HostnameOptions{Hostname: "some-host", NoHostname: true}, "", nil),
+		Entry("no hostname with an unusable name", "🚀🚀", HostnameOptions{NoHostname: true}, "", nil),
+		Entry("longer than the maximum length", strings.Repeat("a", 60)+" b-c", HostnameOptions{MaxLength: 63},
+			strings.Repeat("a", 60)+"-b", nil),
+		Entry("truncated to trailing hyphens", strings.Repeat("a", 61)+"  b", HostnameOptions{MaxLength: 63},
+			strings.Repeat("a", 61), nil),
+		Entry("no maximum length", strings.Repeat("a", 70), HostnameOptions{}, strings.Repeat("a", 70), nil),
+		Entry("underscores not allowed", "some_app", HostnameOptions{}, "someapp", nil),
+		Entry("underscores allowed", "Some_App", HostnameOptions{AllowedCharacters: "_"}, "some_app", nil),
+		Entry("only allowed characters", "__", HostnameOptions{AllowedCharacters: "_"}, "",
+			actionerror.InvalidHostnameError{Original: "__"}),
+	)
+})
@@ -86,6 +86,10 @@ func (Actor) validatePremergedSettings(settings CommandLineSettings, apps []mani
 		if app.NoRoute && len(app.Routes) > 0 {
 			return actionerror.PropertyCombinationError{AppName: app.Name, Properties: []string{"no-route", "routes"}}
 		}
+		if app.Hostname != "" && len(app.Routes) > 0 {
+			log.WithField("app", app.Name).Error("hostname and routes are both set")
+			return actionerror.PropertyCombinationError{AppName: app.Name, Properties: []string{"hostname", "routes"}}
+		}
 	}
 
 	return nil
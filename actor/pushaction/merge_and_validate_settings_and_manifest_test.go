@@ -22,7 +22,7 @@ var _ = Describe("MergeAndValidateSettingsAndManifest", func() {
 	)
 
 	BeforeEach(func() {
-		actor = NewActor(nil, nil)
+		actor = NewActor(nil, nil, nil)
 		currentDirectory = getCurrentDir()
 	})
 
@@ -183,6 +183,61 @@ var _ = Describe("MergeAndValidateSettingsAndManifest", func() {
 		})
 	})
 
+	Describe("hostname and routes", func() {
+		var (
+			apps       []manifest.Application
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			cmdSettings = CommandLineSettings{
+				CurrentDirectory: currentDirectory,
+			}
+
+			apps = []manifest.Application{
+				{Name: "app-1", Path: currentDirectory},
+			}
+		})
+
+		JustBeforeEach(func() {
+			_, executeErr = actor.MergeAndValidateSettingsAndManifests(cmdSettings, apps)
+		})
+
+		Context("when the manifest only specifies a hostname", func() {
+			BeforeEach(func() {
+				apps[0].Hostname = "some-hostname"
+			})
+
+			It("does not return an error", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the manifest only specifies routes", func() {
+			BeforeEach(func() {
+				apps[0].Routes = []string{"some-route.example.com"}
+			})
+
+			It("does not return an error", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the manifest specifies both a hostname and routes", func() {
+			BeforeEach(func() {
+				apps[0].Hostname = "some-hostname"
+				apps[0].Routes = []string{"some-route.example.com"}
+			})
+
+			It("returns a PropertyCombinationError", func() {
+				Expect(executeErr).To(MatchError(actionerror.PropertyCombinationError{
+					AppName:    "app-1",
+					Properties: []string{"hostname", "routes"},
+				}))
+			})
+		})
+	})
+
 	const RealPath = "some-real-path"
 
 	manifestWithMultipleApps := []manifest.Application{
@@ -309,6 +364,18 @@ var _ = Describe("MergeAndValidateSettingsAndManifest", func() {
 				AppName:    "some-name-1",
 				Properties: []string{"no-route", "routes"},
 			}),
+		Entry("PropertyCombinationError",
+			CommandLineSettings{},
+			[]manifest.Application{{
+				Name:     "some-name-1",
+				Hostname: "some-hostname",
+				Routes:   []string{"some-route"},
+				Path:     RealPath,
+			}},
+			actionerror.PropertyCombinationError{
+				AppName:    "some-name-1",
+				Properties: []string{"hostname", "routes"},
+			}),
 
 		// The following are postmerge PropertyCombinationErrors
 		Entry("PropertyCombinationError",
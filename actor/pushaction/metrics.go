@@ -0,0 +1,64 @@
+package pushaction
+
+import "time"
+
+//go:generate counterfeiter . Metrics
+
+// Metrics records how long the route requests made by the Actor take.
+type Metrics interface {
+	ObserveDuration(op string, d time.Duration)
+}
+
+//go:generate counterfeiter . CounterMetrics
+
+// CounterMetrics counts events such as DomainCache hits and misses. They are
+// only counted when the Actor's Metrics is also a CounterMetrics.
+type CounterMetrics interface {
+	IncrementCounter(op string)
+}
+
+// The operations whose durations are recorded by Metrics.
+const (
+	CreateRouteOperation                     = "CreateRoute"
+	GetDomainsByNameAndOrganizationOperation = "GetDomainsByNameAndOrganization"
+	MapRouteToApplicationOperation           = "MapRouteToApplication"
+	MapRouteToApplicationWithPortOperation   = "MapRouteToApplicationWithPort"
+	MapRouteToApplicationDeploymentOperation = "MapRouteToApplicationDeployment"
+)
+
+// The counters incremented in Metrics for each name looked up in the
+// DomainCache, depending on whether it was cached.
+const (
+	DomainCacheHitOperation  = "domain_cache_hit"
+	DomainCacheMissOperation = "domain_cache_miss"
+)
+
+func observeNothing() {}
+
+// observe starts timing op and returns a function that records its duration
+// in Metrics. When Metrics is not set, nothing is timed.
+func (actor Actor) observe(op string) func() {
+	if actor.Metrics == nil {
+		return observeNothing
+	}
+
+	start := time.Now()
+	return func() {
+		actor.Metrics.ObserveDuration(op, time.Since(start))
+	}
+}
+
+// countDomainCacheLookup increments the DomainCacheHitOperation or
+// DomainCacheMissOperation counter in Metrics, when it is a CounterMetrics.
+func (actor Actor) countDomainCacheLookup(cached bool) {
+	counter, ok := actor.Metrics.(CounterMetrics)
+	if !ok {
+		return
+	}
+
+	if cached {
+		counter.IncrementCounter(DomainCacheHitOperation)
+	} else {
+		counter.IncrementCounter(DomainCacheMissOperation)
+	}
+}
@@ -0,0 +1,205 @@
+package pushaction_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// countingMetrics is a Metrics that is also a CounterMetrics.
+type countingMetrics struct {
+	*pushactionfakes.FakeMetrics
+	*pushactionfakes.FakeCounterMetrics
+}
+
+var _ = Describe("Metrics", func() {
+	var (
+		actor       *Actor
+		fakeV2Actor *pushactionfakes.FakeV2Actor
+		fakeMetrics *pushactionfakes.FakeMetrics
+
+		config ApplicationConfig
+	)
+
+	BeforeEach(func() {
+		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
+		fakeMetrics = new(pushactionfakes.FakeMetrics)
+		actor = NewActor(fakeV2Actor, nil, nil)
+		actor.Metrics = fakeMetrics
+
+		fakeV2Actor.GetDomainsByNameAndOrganizationStub = func([]string, string) ([]v2action.Domain, v2action.Warnings, error) {
+			time.Sleep(time.Millisecond)
+			return []v2action.Domain{{GUID: "some-domain-guid", Name: "example.com"}}, nil, nil
+		}
+		fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		fakeV2Actor.CreateRouteStub = func(route v2action.Route, _ bool) (v2action.Route, v2action.Warnings, error) {
+			time.Sleep(time.Millisecond)
+			route.GUID = route.Host + "-guid"
+			return route, nil, nil
+		}
+
+		config = ApplicationConfig{
+			DesiredApplication: Application{
+				Application: v2action.Application{GUID: "some-app-guid"},
+			},
+		}
+	})
+
+	observedOperations := func() []string {
+		var ops []string
+		for i := 0; i < fakeMetrics.ObserveDurationCallCount(); i++ {
+			op, d := fakeMetrics.ObserveDurationArgsForCall(i)
+			Expect(d).To(BeNumerically(">", 0))
+			ops = append(ops, op)
+		}
+		return ops
+	}
+
+	It("records the duration of domain lookups made by CalculateRoutes", func() {
+		_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(observedOperations()).To(Equal([]string{GetDomainsByNameAndOrganizationOperation}))
+		_, d := fakeMetrics.ObserveDurationArgsForCall(0)
+		Expect(d).To(BeNumerically(">=", time.Millisecond))
+	})
+
+	It("records the duration of each route created by CreateRoutes", func() {
+		config.DesiredRoutes = []v2action.Route{{Host: "some-route-1"}, {Host: "some-route-2"}}
+
+		_, _, _, err := actor.CreateRoutes(context.Background(), config, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(observedOperations()).To(Equal([]string{CreateRouteOperation, CreateRouteOperation}))
+	})
+
+	It("records the duration of each route mapped by MapRoutes", func() {
+		config.DesiredRoutes = []v2action.Route{
+			{GUID: "some-route-guid-1"},
+			{GUID: "some-route-guid-2", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+		}
+
+		_, _, _, _, err := actor.MapRoutes(context.Background(), config, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(observedOperations()).To(ConsistOf(MapRouteToApplicationOperation, MapRouteToApplicationWithPortOperation))
+	})
+
+	It("records the duration of the requests made by CreateAndMapDefaultApplicationRoute", func() {
+		fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{{GUID: "some-domain-guid", Name: "example.com"}}, nil, nil)
+
+		_, err := actor.CreateAndMapDefaultApplicationRoute("some-org-guid", "some-space-guid", v2action.Application{Name: "some-app", GUID: "some-app-guid"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(observedOperations()).To(Equal([]string{CreateRouteOperation, MapRouteToApplicationOperation}))
+	})
+
+	Context("when a DomainCache is set", func() {
+		var fakeCounterMetrics *pushactionfakes.FakeCounterMetrics
+
+		countedOperations := func() []string {
+			var ops []string
+			for i := 0; i < fakeCounterMetrics.IncrementCounterCallCount(); i++ {
+				ops = append(ops, fakeCounterMetrics.IncrementCounterArgsForCall(i))
+			}
+			return ops
+		}
+
+		BeforeEach(func() {
+			fakeCounterMetrics = new(pushactionfakes.FakeCounterMetrics)
+			actor.Metrics = countingMetrics{FakeMetrics: fakeMetrics, FakeCounterMetrics: fakeCounterMetrics}
+			actor.DomainCache = NewDomainCache(DefaultDomainCacheTTL)
+		})
+
+		It("counts a miss for each name that was not cached and a hit for each that was", func() {
+			_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countedOperations()).To(Equal([]string{DomainCacheMissOperation, DomainCacheMissOperation}))
+
+			_, _, err = actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countedOperations()).To(Equal([]string{
+				DomainCacheMissOperation, DomainCacheMissOperation,
+				DomainCacheHitOperation, DomainCacheHitOperation,
+			}))
+			Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+		})
+
+		It("counts the lookups of the default domain", func() {
+			fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{{GUID: "some-domain-guid", Name: "example.com"}}, nil, nil)
+
+			_, _, err := actor.DefaultDomain("some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+			_, _, err = actor.DefaultDomain("some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(countedOperations()).To(Equal([]string{DomainCacheMissOperation, DomainCacheHitOperation}))
+		})
+
+		Context("when Metrics is not set", func() {
+			BeforeEach(func() {
+				actor.Metrics = nil
+			})
+
+			It("still caches the domains", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				_, _, err = actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeCounterMetrics.IncrementCounterCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when Metrics does not count events", func() {
+			BeforeEach(func() {
+				actor.Metrics = fakeMetrics
+			})
+
+			It("still caches the domains and records durations", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				_, _, err = actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeCounterMetrics.IncrementCounterCallCount()).To(Equal(0))
+				Expect(observedOperations()).To(Equal([]string{GetDomainsByNameAndOrganizationOperation}))
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when no DomainCache is set", func() {
+		It("does not count any cache lookups", func() {
+			fakeCounterMetrics := new(pushactionfakes.FakeCounterMetrics)
+			actor.Metrics = countingMetrics{FakeMetrics: fakeMetrics, FakeCounterMetrics: fakeCounterMetrics}
+
+			_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeCounterMetrics.IncrementCounterCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when Metrics is not set", func() {
+		BeforeEach(func() {
+			actor.Metrics = nil
+		})
+
+		It("records nothing", func() {
+			config.DesiredRoutes = []v2action.Route{{Host: "some-route-1"}}
+
+			_, _, _, err := actor.CreateRoutes(context.Background(), config, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeMetrics.ObserveDurationCallCount()).To(Equal(0))
+		})
+	})
+})
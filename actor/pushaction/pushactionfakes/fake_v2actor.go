@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/cli/actor/pushaction"
 	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/types"
 )
 
 type FakeV2Actor struct {
@@ -284,6 +285,141 @@ type FakeV2Actor struct {
 		result2 v2action.Warnings
 		result3 error
 	}
+	DeleteRouteStub        func(routeGUID string) (v2action.Warnings, error)
+	deleteRouteMutex       sync.RWMutex
+	deleteRouteArgsForCall []struct {
+		routeGUID string
+	}
+	deleteRouteReturns struct {
+		result1 v2action.Warnings
+		result2 error
+	}
+	deleteRouteReturnsOnCall map[int]struct {
+		result1 v2action.Warnings
+		result2 error
+	}
+	GetRouteApplicationsStub        func(routeGUID string) ([]v2action.Application, v2action.Warnings, error)
+	getRouteApplicationsMutex       sync.RWMutex
+	getRouteApplicationsArgsForCall []struct {
+		routeGUID string
+	}
+	getRouteApplicationsReturns struct {
+		result1 []v2action.Application
+		result2 v2action.Warnings
+		result3 error
+	}
+	getRouteApplicationsReturnsOnCall map[int]struct {
+		result1 []v2action.Application
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetOrganizationStub        func(guid string) (v2action.Organization, v2action.Warnings, error)
+	getOrganizationMutex       sync.RWMutex
+	getOrganizationArgsForCall []struct {
+		guid string
+	}
+	getOrganizationReturns struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}
+	getOrganizationReturnsOnCall map[int]struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}
+	MapRouteToApplicationWithPortStub        func(routeGUID string, appGUID string, appPort types.NullInt) (v2action.Warnings, error)
+	mapRouteToApplicationWithPortMutex       sync.RWMutex
+	mapRouteToApplicationWithPortArgsForCall []struct {
+		routeGUID string
+		appGUID   string
+		appPort   types.NullInt
+	}
+	mapRouteToApplicationWithPortReturns struct {
+		result1 v2action.Warnings
+		result2 error
+	}
+	mapRouteToApplicationWithPortReturnsOnCall map[int]struct {
+		result1 v2action.Warnings
+		result2 error
+	}
+	MapRouteToApplicationDeploymentStub        func(routeGUID string, appGUID string, appPort types.NullInt, deploymentGUID string) (v2action.Warnings, error)
+	mapRouteToApplicationDeploymentMutex       sync.RWMutex
+	mapRouteToApplicationDeploymentArgsForCall []struct {
+		routeGUID      string
+		appGUID        string
+		appPort        types.NullInt
+		deploymentGUID string
+	}
+	mapRouteToApplicationDeploymentReturns struct {
+		result1 v2action.Warnings
+		result2 error
+	}
+	mapRouteToApplicationDeploymentReturnsOnCall map[int]struct {
+		result1 v2action.Warnings
+		result2 error
+	}
+	GetApplicationRouteMappingsStub        func(appGUID string) ([]v2action.RouteMapping, v2action.Warnings, error)
+	getApplicationRouteMappingsMutex       sync.RWMutex
+	getApplicationRouteMappingsArgsForCall []struct {
+		appGUID string
+	}
+	getApplicationRouteMappingsReturns struct {
+		result1 []v2action.RouteMapping
+		result2 v2action.Warnings
+		result3 error
+	}
+	getApplicationRouteMappingsReturnsOnCall map[int]struct {
+		result1 []v2action.RouteMapping
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetOrganizationRoutesWithHostAndDomainStub        func(route v2action.Route, orgGUID string) ([]v2action.Route, v2action.Warnings, error)
+	getOrganizationRoutesWithHostAndDomainMutex       sync.RWMutex
+	getOrganizationRoutesWithHostAndDomainArgsForCall []struct {
+		route   v2action.Route
+		orgGUID string
+	}
+	getOrganizationRoutesWithHostAndDomainReturns struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}
+	getOrganizationRoutesWithHostAndDomainReturnsOnCall map[int]struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetDomainStub        func(domainGUID string) (v2action.Domain, v2action.Warnings, error)
+	getDomainMutex       sync.RWMutex
+	getDomainArgsForCall []struct {
+		domainGUID string
+	}
+	getDomainReturns struct {
+		result1 v2action.Domain
+		result2 v2action.Warnings
+		result3 error
+	}
+	getDomainReturnsOnCall map[int]struct {
+		result1 v2action.Domain
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetSpaceRoutesStub        func(spaceGUID string) ([]v2action.Route, v2action.Warnings, error)
+	getSpaceRoutesMutex       sync.RWMutex
+	getSpaceRoutesArgsForCall []struct {
+		spaceGUID string
+	}
+	getSpaceRoutesReturns struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}
+	getSpaceRoutesReturnsOnCall map[int]struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1276,6 +1412,489 @@ func (fake *FakeV2Actor) UploadApplicationPackageReturnsOnCall(i int, result1 v2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeV2Actor) DeleteRoute(routeGUID string) (v2action.Warnings, error) {
+	fake.deleteRouteMutex.Lock()
+	ret, specificReturn := fake.deleteRouteReturnsOnCall[len(fake.deleteRouteArgsForCall)]
+	fake.deleteRouteArgsForCall = append(fake.deleteRouteArgsForCall, struct {
+		routeGUID string
+	}{routeGUID})
+	fake.recordInvocation("DeleteRoute", []interface{}{routeGUID})
+	fake.deleteRouteMutex.Unlock()
+	if fake.DeleteRouteStub != nil {
+		return fake.DeleteRouteStub(routeGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deleteRouteReturns.result1, fake.deleteRouteReturns.result2
+}
+
+func (fake *FakeV2Actor) DeleteRouteCallCount() int {
+	fake.deleteRouteMutex.RLock()
+	defer fake.deleteRouteMutex.RUnlock()
+	return len(fake.deleteRouteArgsForCall)
+}
+
+func (fake *FakeV2Actor) DeleteRouteArgsForCall(i int) string {
+	fake.deleteRouteMutex.RLock()
+	defer fake.deleteRouteMutex.RUnlock()
+	return fake.deleteRouteArgsForCall[i].routeGUID
+}
+
+func (fake *FakeV2Actor) DeleteRouteReturns(result1 v2action.Warnings, result2 error) {
+	fake.DeleteRouteStub = nil
+	fake.deleteRouteReturns = struct {
+		result1 v2action.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeV2Actor) DeleteRouteReturnsOnCall(i int, result1 v2action.Warnings, result2 error) {
+	fake.DeleteRouteStub = nil
+	if fake.deleteRouteReturnsOnCall == nil {
+		fake.deleteRouteReturnsOnCall = make(map[int]struct {
+			result1 v2action.Warnings
+			result2 error
+		})
+	}
+	fake.deleteRouteReturnsOnCall[i] = struct {
+		result1 v2action.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeV2Actor) GetRouteApplications(routeGUID string) ([]v2action.Application, v2action.Warnings, error) {
+	fake.getRouteApplicationsMutex.Lock()
+	ret, specificReturn := fake.getRouteApplicationsReturnsOnCall[len(fake.getRouteApplicationsArgsForCall)]
+	fake.getRouteApplicationsArgsForCall = append(fake.getRouteApplicationsArgsForCall, struct {
+		routeGUID string
+	}{routeGUID})
+	fake.recordInvocation("GetRouteApplications", []interface{}{routeGUID})
+	fake.getRouteApplicationsMutex.Unlock()
+	if fake.GetRouteApplicationsStub != nil {
+		return fake.GetRouteApplicationsStub(routeGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getRouteApplicationsReturns.result1, fake.getRouteApplicationsReturns.result2, fake.getRouteApplicationsReturns.result3
+}
+
+func (fake *FakeV2Actor) GetRouteApplicationsCallCount() int {
+	fake.getRouteApplicationsMutex.RLock()
+	defer fake.getRouteApplicationsMutex.RUnlock()
+	return len(fake.getRouteApplicationsArgsForCall)
+}
+
+func (fake *FakeV2Actor) GetRouteApplicationsArgsForCall(i int) string {
+	fake.getRouteApplicationsMutex.RLock()
+	defer fake.getRouteApplicationsMutex.RUnlock()
+	return fake.getRouteApplicationsArgsForCall[i].routeGUID
+}
+
+func (fake *FakeV2Actor) GetRouteApplicationsReturns(result1 []v2action.Application, result2 v2action.Warnings, result3 error) {
+	fake.GetRouteApplicationsStub = nil
+	fake.getRouteApplicationsReturns = struct {
+		result1 []v2action.Application
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetRouteApplicationsReturnsOnCall(i int, result1 []v2action.Application, result2 v2action.Warnings, result3 error) {
+	fake.GetRouteApplicationsStub = nil
+	if fake.getRouteApplicationsReturnsOnCall == nil {
+		fake.getRouteApplicationsReturnsOnCall = make(map[int]struct {
+			result1 []v2action.Application
+			result2 v2action.Warnings
+			result3 error
+		})
+	}
+	fake.getRouteApplicationsReturnsOnCall[i] = struct {
+		result1 []v2action.Application
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetOrganization(guid string) (v2action.Organization, v2action.Warnings, error) {
+	fake.getOrganizationMutex.Lock()
+	ret, specificReturn := fake.getOrganizationReturnsOnCall[len(fake.getOrganizationArgsForCall)]
+	fake.getOrganizationArgsForCall = append(fake.getOrganizationArgsForCall, struct {
+		guid string
+	}{guid})
+	fake.recordInvocation("GetOrganization", []interface{}{guid})
+	fake.getOrganizationMutex.Unlock()
+	if fake.GetOrganizationStub != nil {
+		return fake.GetOrganizationStub(guid)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getOrganizationReturns.result1, fake.getOrganizationReturns.result2, fake.getOrganizationReturns.result3
+}
+
+func (fake *FakeV2Actor) GetOrganizationCallCount() int {
+	fake.getOrganizationMutex.RLock()
+	defer fake.getOrganizationMutex.RUnlock()
+	return len(fake.getOrganizationArgsForCall)
+}
+
+func (fake *FakeV2Actor) GetOrganizationArgsForCall(i int) string {
+	fake.getOrganizationMutex.RLock()
+	defer fake.getOrganizationMutex.RUnlock()
+	return fake.getOrganizationArgsForCall[i].guid
+}
+
+func (fake *FakeV2Actor) GetOrganizationReturns(result1 v2action.Organization, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationStub = nil
+	fake.getOrganizationReturns = struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetOrganizationReturnsOnCall(i int, result1 v2action.Organization, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationStub = nil
+	if fake.getOrganizationReturnsOnCall == nil {
+		fake.getOrganizationReturnsOnCall = make(map[int]struct {
+			result1 v2action.Organization
+			result2 v2action.Warnings
+			result3 error
+		})
+	}
+	fake.getOrganizationReturnsOnCall[i] = struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationWithPort(routeGUID string, appGUID string, appPort types.NullInt) (v2action.Warnings, error) {
+	fake.mapRouteToApplicationWithPortMutex.Lock()
+	ret, specificReturn := fake.mapRouteToApplicationWithPortReturnsOnCall[len(fake.mapRouteToApplicationWithPortArgsForCall)]
+	fake.mapRouteToApplicationWithPortArgsForCall = append(fake.mapRouteToApplicationWithPortArgsForCall, struct {
+		routeGUID string
+		appGUID   string
+		appPort   types.NullInt
+	}{routeGUID, appGUID, appPort})
+	fake.recordInvocation("MapRouteToApplicationWithPort", []interface{}{routeGUID, appGUID, appPort})
+	fake.mapRouteToApplicationWithPortMutex.Unlock()
+	if fake.MapRouteToApplicationWithPortStub != nil {
+		return fake.MapRouteToApplicationWithPortStub(routeGUID, appGUID, appPort)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.mapRouteToApplicationWithPortReturns.result1, fake.mapRouteToApplicationWithPortReturns.result2
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationWithPortCallCount() int {
+	fake.mapRouteToApplicationWithPortMutex.RLock()
+	defer fake.mapRouteToApplicationWithPortMutex.RUnlock()
+	return len(fake.mapRouteToApplicationWithPortArgsForCall)
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationWithPortArgsForCall(i int) (string, string, types.NullInt) {
+	fake.mapRouteToApplicationWithPortMutex.RLock()
+	defer fake.mapRouteToApplicationWithPortMutex.RUnlock()
+	return fake.mapRouteToApplicationWithPortArgsForCall[i].routeGUID, fake.mapRouteToApplicationWithPortArgsForCall[i].appGUID, fake.mapRouteToApplicationWithPortArgsForCall[i].appPort
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationWithPortReturns(result1 v2action.Warnings, result2 error) {
+	fake.MapRouteToApplicationWithPortStub = nil
+	fake.mapRouteToApplicationWithPortReturns = struct {
+		result1 v2action.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationWithPortReturnsOnCall(i int, result1 v2action.Warnings, result2 error) {
+	fake.MapRouteToApplicationWithPortStub = nil
+	if fake.mapRouteToApplicationWithPortReturnsOnCall == nil {
+		fake.mapRouteToApplicationWithPortReturnsOnCall = make(map[int]struct {
+			result1 v2action.Warnings
+			result2 error
+		})
+	}
+	fake.mapRouteToApplicationWithPortReturnsOnCall[i] = struct {
+		result1 v2action.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationDeployment(routeGUID string, appGUID string, appPort types.NullInt, deploymentGUID string) (v2action.Warnings, error) {
+	fake.mapRouteToApplicationDeploymentMutex.Lock()
+	ret, specificReturn := fake.mapRouteToApplicationDeploymentReturnsOnCall[len(fake.mapRouteToApplicationDeploymentArgsForCall)]
+	fake.mapRouteToApplicationDeploymentArgsForCall = append(fake.mapRouteToApplicationDeploymentArgsForCall, struct {
+		routeGUID      string
+		appGUID        string
+		appPort        types.NullInt
+		deploymentGUID string
+	}{routeGUID, appGUID, appPort, deploymentGUID})
+	fake.recordInvocation("MapRouteToApplicationDeployment", []interface{}{routeGUID, appGUID, appPort, deploymentGUID})
+	fake.mapRouteToApplicationDeploymentMutex.Unlock()
+	if fake.MapRouteToApplicationDeploymentStub != nil {
+		return fake.MapRouteToApplicationDeploymentStub(routeGUID, appGUID, appPort, deploymentGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.mapRouteToApplicationDeploymentReturns.result1, fake.mapRouteToApplicationDeploymentReturns.result2
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationDeploymentCallCount() int {
+	fake.mapRouteToApplicationDeploymentMutex.RLock()
+	defer fake.mapRouteToApplicationDeploymentMutex.RUnlock()
+	return len(fake.mapRouteToApplicationDeploymentArgsForCall)
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationDeploymentArgsForCall(i int) (string, string, types.NullInt, string) {
+	fake.mapRouteToApplicationDeploymentMutex.RLock()
+	defer fake.mapRouteToApplicationDeploymentMutex.RUnlock()
+	return fake.mapRouteToApplicationDeploymentArgsForCall[i].routeGUID, fake.mapRouteToApplicationDeploymentArgsForCall[i].appGUID, fake.mapRouteToApplicationDeploymentArgsForCall[i].appPort, fake.mapRouteToApplicationDeploymentArgsForCall[i].deploymentGUID
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationDeploymentReturns(result1 v2action.Warnings, result2 error) {
+	fake.MapRouteToApplicationDeploymentStub = nil
+	fake.mapRouteToApplicationDeploymentReturns = struct {
+		result1 v2action.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeV2Actor) MapRouteToApplicationDeploymentReturnsOnCall(i int, result1 v2action.Warnings, result2 error) {
+	fake.MapRouteToApplicationDeploymentStub = nil
+	if fake.mapRouteToApplicationDeploymentReturnsOnCall == nil {
+		fake.mapRouteToApplicationDeploymentReturnsOnCall = make(map[int]struct {
+			result1 v2action.Warnings
+			result2 error
+		})
+	}
+	fake.mapRouteToApplicationDeploymentReturnsOnCall[i] = struct {
+		result1 v2action.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeV2Actor) GetApplicationRouteMappings(appGUID string) ([]v2action.RouteMapping, v2action.Warnings, error) {
+	fake.getApplicationRouteMappingsMutex.Lock()
+	ret, specificReturn := fake.getApplicationRouteMappingsReturnsOnCall[len(fake.getApplicationRouteMappingsArgsForCall)]
+	fake.getApplicationRouteMappingsArgsForCall = append(fake.getApplicationRouteMappingsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("GetApplicationRouteMappings", []interface{}{appGUID})
+	fake.getApplicationRouteMappingsMutex.Unlock()
+	if fake.GetApplicationRouteMappingsStub != nil {
+		return fake.GetApplicationRouteMappingsStub(appGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getApplicationRouteMappingsReturns.result1, fake.getApplicationRouteMappingsReturns.result2, fake.getApplicationRouteMappingsReturns.result3
+}
+
+func (fake *FakeV2Actor) GetApplicationRouteMappingsCallCount() int {
+	fake.getApplicationRouteMappingsMutex.RLock()
+	defer fake.getApplicationRouteMappingsMutex.RUnlock()
+	return len(fake.getApplicationRouteMappingsArgsForCall)
+}
+
+func (fake *FakeV2Actor) GetApplicationRouteMappingsArgsForCall(i int) string {
+	fake.getApplicationRouteMappingsMutex.RLock()
+	defer fake.getApplicationRouteMappingsMutex.RUnlock()
+	return fake.getApplicationRouteMappingsArgsForCall[i].appGUID
+}
+
+func (fake *FakeV2Actor) GetApplicationRouteMappingsReturns(result1 []v2action.RouteMapping, result2 v2action.Warnings, result3 error) {
+	fake.GetApplicationRouteMappingsStub = nil
+	fake.getApplicationRouteMappingsReturns = struct {
+		result1 []v2action.RouteMapping
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetApplicationRouteMappingsReturnsOnCall(i int, result1 []v2action.RouteMapping, result2 v2action.Warnings, result3 error) {
+	fake.GetApplicationRouteMappingsStub = nil
+	if fake.getApplicationRouteMappingsReturnsOnCall == nil {
+		fake.getApplicationRouteMappingsReturnsOnCall = make(map[int]struct {
+			result1 []v2action.RouteMapping
+			result2 v2action.Warnings
+			result3 error
+		})
+	}
+	fake.getApplicationRouteMappingsReturnsOnCall[i] = struct {
+		result1 []v2action.RouteMapping
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetOrganizationRoutesWithHostAndDomain(route v2action.Route, orgGUID string) ([]v2action.Route, v2action.Warnings, error) {
+	fake.getOrganizationRoutesWithHostAndDomainMutex.Lock()
+	ret, specificReturn := fake.getOrganizationRoutesWithHostAndDomainReturnsOnCall[len(fake.getOrganizationRoutesWithHostAndDomainArgsForCall)]
+	fake.getOrganizationRoutesWithHostAndDomainArgsForCall = append(fake.getOrganizationRoutesWithHostAndDomainArgsForCall, struct {
+		route   v2action.Route
+		orgGUID string
+	}{route, orgGUID})
+	fake.recordInvocation("GetOrganizationRoutesWithHostAndDomain", []interface{}{route, orgGUID})
+	fake.getOrganizationRoutesWithHostAndDomainMutex.Unlock()
+	if fake.GetOrganizationRoutesWithHostAndDomainStub != nil {
+		return fake.GetOrganizationRoutesWithHostAndDomainStub(route, orgGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getOrganizationRoutesWithHostAndDomainReturns.result1, fake.getOrganizationRoutesWithHostAndDomainReturns.result2, fake.getOrganizationRoutesWithHostAndDomainReturns.result3
+}
+
+func (fake *FakeV2Actor) GetOrganizationRoutesWithHostAndDomainCallCount() int {
+	fake.getOrganizationRoutesWithHostAndDomainMutex.RLock()
+	defer fake.getOrganizationRoutesWithHostAndDomainMutex.RUnlock()
+	return len(fake.getOrganizationRoutesWithHostAndDomainArgsForCall)
+}
+
+func (fake *FakeV2Actor) GetOrganizationRoutesWithHostAndDomainArgsForCall(i int) (v2action.Route, string) {
+	fake.getOrganizationRoutesWithHostAndDomainMutex.RLock()
+	defer fake.getOrganizationRoutesWithHostAndDomainMutex.RUnlock()
+	return fake.getOrganizationRoutesWithHostAndDomainArgsForCall[i].route, fake.getOrganizationRoutesWithHostAndDomainArgsForCall[i].orgGUID
+}
+
+func (fake *FakeV2Actor) GetOrganizationRoutesWithHostAndDomainReturns(result1 []v2action.Route, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationRoutesWithHostAndDomainStub = nil
+	fake.getOrganizationRoutesWithHostAndDomainReturns = struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetOrganizationRoutesWithHostAndDomainReturnsOnCall(i int, result1 []v2action.Route, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationRoutesWithHostAndDomainStub = nil
+	if fake.getOrganizationRoutesWithHostAndDomainReturnsOnCall == nil {
+		fake.getOrganizationRoutesWithHostAndDomainReturnsOnCall = make(map[int]struct {
+			result1 []v2action.Route
+			result2 v2action.Warnings
+			result3 error
+		})
+	}
+	fake.getOrganizationRoutesWithHostAndDomainReturnsOnCall[i] = struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetDomain(domainGUID string) (v2action.Domain, v2action.Warnings, error) {
+	fake.getDomainMutex.Lock()
+	ret, specificReturn := fake.getDomainReturnsOnCall[len(fake.getDomainArgsForCall)]
+	fake.getDomainArgsForCall = append(fake.getDomainArgsForCall, struct {
+		domainGUID string
+	}{domainGUID})
+	fake.recordInvocation("GetDomain", []interface{}{domainGUID})
+	fake.getDomainMutex.Unlock()
+	if fake.GetDomainStub != nil {
+		return fake.GetDomainStub(domainGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getDomainReturns.result1, fake.getDomainReturns.result2, fake.getDomainReturns.result3
+}
+
+func (fake *FakeV2Actor) GetDomainCallCount() int {
+	fake.getDomainMutex.RLock()
+	defer fake.getDomainMutex.RUnlock()
+	return len(fake.getDomainArgsForCall)
+}
+
+func (fake *FakeV2Actor) GetDomainArgsForCall(i int) string {
+	fake.getDomainMutex.RLock()
+	defer fake.getDomainMutex.RUnlock()
+	return fake.getDomainArgsForCall[i].domainGUID
+}
+
+func (fake *FakeV2Actor) GetDomainReturns(result1 v2action.Domain, result2 v2action.Warnings, result3 error) {
+	fake.GetDomainStub = nil
+	fake.getDomainReturns = struct {
+		result1 v2action.Domain
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetDomainReturnsOnCall(i int, result1 v2action.Domain, result2 v2action.Warnings, result3 error) {
+	fake.GetDomainStub = nil
+	if fake.getDomainReturnsOnCall == nil {
+		fake.getDomainReturnsOnCall = make(map[int]struct {
+			result1 v2action.Domain
+			result2 v2action.Warnings
+			result3 error
+		})
+	}
+	fake.getDomainReturnsOnCall[i] = struct {
+		result1 v2action.Domain
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetSpaceRoutes(spaceGUID string) ([]v2action.Route, v2action.Warnings, error) {
+	fake.getSpaceRoutesMutex.Lock()
+	ret, specificReturn := fake.getSpaceRoutesReturnsOnCall[len(fake.getSpaceRoutesArgsForCall)]
+	fake.getSpaceRoutesArgsForCall = append(fake.getSpaceRoutesArgsForCall, struct {
+		spaceGUID string
+	}{spaceGUID})
+	fake.recordInvocation("GetSpaceRoutes", []interface{}{spaceGUID})
+	fake.getSpaceRoutesMutex.Unlock()
+	if fake.GetSpaceRoutesStub != nil {
+		return fake.GetSpaceRoutesStub(spaceGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getSpaceRoutesReturns.result1, fake.getSpaceRoutesReturns.result2, fake.getSpaceRoutesReturns.result3
+}
+
+func (fake *FakeV2Actor) GetSpaceRoutesCallCount() int {
+	fake.getSpaceRoutesMutex.RLock()
+	defer fake.getSpaceRoutesMutex.RUnlock()
+	return len(fake.getSpaceRoutesArgsForCall)
+}
+
+func (fake *FakeV2Actor) GetSpaceRoutesArgsForCall(i int) string {
+	fake.getSpaceRoutesMutex.RLock()
+	defer fake.getSpaceRoutesMutex.RUnlock()
+	return fake.getSpaceRoutesArgsForCall[i].spaceGUID
+}
+
+func (fake *FakeV2Actor) GetSpaceRoutesReturns(result1 []v2action.Route, result2 v2action.Warnings, result3 error) {
+	fake.GetSpaceRoutesStub = nil
+	fake.getSpaceRoutesReturns = struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeV2Actor) GetSpaceRoutesReturnsOnCall(i int, result1 []v2action.Route, result2 v2action.Warnings, result3 error) {
+	fake.GetSpaceRoutesStub = nil
+	if fake.getSpaceRoutesReturnsOnCall == nil {
+		fake.getSpaceRoutesReturnsOnCall = make(map[int]struct {
+			result1 []v2action.Route
+			result2 v2action.Warnings
+			result3 error
+		})
+	}
+	fake.getSpaceRoutesReturnsOnCall[i] = struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeV2Actor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1315,6 +1934,24 @@ func (fake *FakeV2Actor) Invocations() map[string][][]interface{} {
 	defer fake.updateApplicationMutex.RUnlock()
 	fake.uploadApplicationPackageMutex.RLock()
 	defer fake.uploadApplicationPackageMutex.RUnlock()
+	fake.deleteRouteMutex.RLock()
+	defer fake.deleteRouteMutex.RUnlock()
+	fake.getRouteApplicationsMutex.RLock()
+	defer fake.getRouteApplicationsMutex.RUnlock()
+	fake.getOrganizationMutex.RLock()
+	defer fake.getOrganizationMutex.RUnlock()
+	fake.mapRouteToApplicationWithPortMutex.RLock()
+	defer fake.mapRouteToApplicationWithPortMutex.RUnlock()
+	fake.mapRouteToApplicationDeploymentMutex.RLock()
+	defer fake.mapRouteToApplicationDeploymentMutex.RUnlock()
+	fake.getApplicationRouteMappingsMutex.RLock()
+	defer fake.getApplicationRouteMappingsMutex.RUnlock()
+	fake.getOrganizationRoutesWithHostAndDomainMutex.RLock()
+	defer fake.getOrganizationRoutesWithHostAndDomainMutex.RUnlock()
+	fake.getDomainMutex.RLock()
+	defer fake.getDomainMutex.RUnlock()
+	fake.getSpaceRoutesMutex.RLock()
+	defer fake.getSpaceRoutesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,56 @@
+package pushaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate counterfeiter . Resolver
+
+// Resolver looks up hostnames in DNS. A *net.Resolver satisfies it.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// resolveRouteDomains checks that the private HTTP domains of the routes
+// resolve in DNS, using the hostname of the first route on each domain so
+// that wildcard records are honoured. A warning is returned for each domain
+// that does not resolve. When Resolver is not set, nothing is checked.
+func (actor Actor) resolveRouteDomains(ctx context.Context, routes []v2action.Route) RouteWarnings {
+	if actor.Resolver == nil {
+		return nil
+	}
+
+	var warnings RouteWarnings
+	checked := map[string]bool{}
+	for _, route := range routes {
+		if !route.Domain.IsPrivate() || !route.Domain.IsHTTP() || route.Domain.IsInternal() {
+			continue
+		}
+
+		domainName := strings.ToLower(route.Domain.Name)
+		if checked[domainName] {
+			continue
+		}
+		checked[domainName] = true
+
+		hostname := route.Domain.Name
+		if route.Host != "" && route.Host != "*" {
+			hostname = route.Host + "." + hostname
+		}
+
+		log.WithField("hostname", hostname).Debug("resolving route hostname")
+		if _, err := actor.Resolver.LookupHost(ctx, hostname); err != nil {
+			log.WithField("hostname", hostname).Warnln("resolving route hostname:", err)
+			warnings = append(warnings, RouteWarning{
+				Message:  fmt.Sprintf("Domain %s does not resolve: looking up %s failed: %s", route.Domain.Name, hostname, err),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,111 @@
+package pushaction_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolver", func() {
+	var (
+		actor        *Actor
+		fakeV2Actor  *pushactionfakes.FakeV2Actor
+		fakeResolver *pushactionfakes.FakeResolver
+
+		routes     []string
+		warnings   RouteWarnings
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
+		fakeResolver = new(pushactionfakes.FakeResolver)
+		actor = NewActor(fakeV2Actor, nil, nil)
+		actor.Resolver = fakeResolver
+
+		fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+			{GUID: "private-domain-guid", Name: "private.com", Type: constant.PrivateDomain},
+			{GUID: "shared-domain-guid", Name: "shared.com", Type: constant.SharedDomain},
+		}, nil, nil)
+		fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+
+		routes = []string{"app.private.com", "other.private.com", "app.shared.com"}
+	})
+
+	JustBeforeEach(func() {
+		_, warnings, executeErr = actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", nil, false)
+	})
+
+	Context("when the private domains resolve", func() {
+		BeforeEach(func() {
+			fakeResolver.LookupHostReturns([]string{"10.0.0.1"}, nil)
+		})
+
+		It("checks each private domain once without returning a warning", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+
+			Expect(fakeResolver.LookupHostCallCount()).To(Equal(1))
+			_, host := fakeResolver.LookupHostArgsForCall(0)
+			Expect(host).To(Equal("app.private.com"))
+		})
+	})
+
+	Context("when a private domain does not resolve", func() {
+		BeforeEach(func() {
+			fakeResolver.LookupHostReturns(nil, errors.New("no such host"))
+		})
+
+		It("returns a warning instead of an error", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(RouteWarning{
+				Message:  "Domain private.com does not resolve: looking up app.private.com failed: no such host",
+				Severity: SeverityWarning,
+			}))
+		})
+	})
+
+	Context("when the routes have a wildcard host", func() {
+		BeforeEach(func() {
+			routes = []string{"*.private.com"}
+		})
+
+		It("looks up the domain itself", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeResolver.LookupHostCallCount()).To(Equal(1))
+			_, host := fakeResolver.LookupHostArgsForCall(0)
+			Expect(host).To(Equal("private.com"))
+		})
+	})
+
+	Context("when the routes are only on shared domains", func() {
+		BeforeEach(func() {
+			routes = []string{"app.shared.com"}
+		})
+
+		It("does not look anything up", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeResolver.LookupHostCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when Resolver is not set", func() {
+		BeforeEach(func() {
+			actor.Resolver = nil
+		})
+
+		It("skips the check", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			Expect(fakeResolver.LookupHostCallCount()).To(Equal(0))
+		})
+	})
+})
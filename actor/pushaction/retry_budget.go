@@ -0,0 +1,41 @@
+package pushaction
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/types"
+)
+
+// RetryBudget bounds the route mapping retries made by
+// MapRoutesWithRetryBudget across all of the routes it maps. Each route is
+// still retried at most MapRouteRetries times.
+type RetryBudget struct {
+	// Retries is the total number of retries. When it is not set, the number
+	// of retries is not limited.
+	Retries types.NullInt
+
+	// Deadline is the time after which no more retries are made. When it is
+	// zero, there is no deadline.
+	Deadline time.Time
+}
+
+// spend uses up one retry that will be made after waiting backoff. It returns
+// false, without using anything up, when no retries are left or the retry
+// would be made after the deadline. A nil budget is never exhausted.
+func (budget *RetryBudget) spend(backoff time.Duration) bool {
+	if budget == nil {
+		return true
+	}
+
+	if budget.Retries.IsSet && budget.Retries.Value <= 0 {
+		return false
+	}
+	if !budget.Deadline.IsZero() && time.Now().Add(backoff).After(budget.Deadline) {
+		return false
+	}
+
+	if budget.Retries.IsSet {
+		budget.Retries.Value--
+	}
+	return true
+}
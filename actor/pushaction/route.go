@@ -1,346 +1,2258 @@
 package pushaction
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"code.cloudfoundry.org/cli/actor/actionerror"
 	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v3action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 	"code.cloudfoundry.org/cli/types"
 	"code.cloudfoundry.org/cli/util/manifest"
+	"code.cloudfoundry.org/cli/util/words/generator"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/idna"
 )
 
-func (actor Actor) MapRoutes(config ApplicationConfig) (ApplicationConfig, bool, Warnings, error) {
+// hostnameProfile maps internationalized hostnames (case, width and
+// normalization) for lookup. STD3 rules are not enforced so that hostname
+// validation is left to Route.Validate and the Cloud Controller.
+var hostnameProfile = idna.New(idna.MapForLookup(), idna.Transitional(true), idna.StrictDomainName(false))
+
+// routeSchemeRegexp matches the scheme at the start of a route, such as
+// http:// in http://app.example.com. The submatch is the scheme's name.
+var routeSchemeRegexp = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*)://`)
+
+// portRangeRegexp matches a route with a port range, such as
+// tcp.example.com:6000-6005. The submatches are everything before the port,
+// the first port, the last port and the path.
+var portRangeRegexp = regexp.MustCompile(`^((?:[a-zA-Z][a-zA-Z0-9+.-]*://)?[^/:]+):(\d+)-(\d+)(/.*)?$`)
+
+// hostPortRegexp matches a route that is only a hostname and a port, such as
+// the TCP route tcp.example.com:1234. The submatches are the hostname and the
+// port.
+var hostPortRegexp = regexp.MustCompile(`^([^/:]+):(\d+)$`)
+
+// MaxRoutePortRangeSize is the maximum number of ports a single route's port
+// range can contain.
+const MaxRoutePortRangeSize = 100
+
+// RouteChanges describes how an application's routes change when its
+// CurrentRoutes are replaced with its DesiredRoutes.
+type RouteChanges struct {
+	// Added are the DesiredRoutes that are not in CurrentRoutes.
+	Added []v2action.Route
+	// Removed are the CurrentRoutes that are not in DesiredRoutes.
+	Removed []v2action.Route
+	// Unchanged are the DesiredRoutes that are already in CurrentRoutes.
+	Unchanged []v2action.Route
+}
+
+// RouteDiff compares the config's CurrentRoutes and DesiredRoutes by GUID.
+// Added and Unchanged are in DesiredRoutes order and Removed is in
+// CurrentRoutes order. Routes without a GUID never match one another, so a
+// desired route that has not been created yet is always Added. A desired
+// route whose app port differs from the current route's is also Added, so
+// that it is mapped again with the new port; the current routes' app ports
+// are those of the application's route mappings.
+func (actor Actor) RouteDiff(config ApplicationConfig) RouteChanges {
+	var changes RouteChanges
+	for _, route := range config.DesiredRoutes {
+		if route.GUID != "" && actor.routeInListByGUIDAndMapping(route, config.CurrentRoutes) {
+			changes.Unchanged = append(changes.Unchanged, route)
+		} else {
+			changes.Added = append(changes.Added, route)
+		}
+	}
+
+	for _, route := range config.CurrentRoutes {
+		if route.GUID == "" || !actor.routeInListByGUID(route, config.DesiredRoutes) {
+			changes.Removed = append(changes.Removed, route)
+		}
+	}
+
+	return changes
+}
+
+// MapRoutes maps any DesiredRoutes that are not in CurrentRoutes to the
+// desired application, mapping the Primary route first, and returns the routes
+// it mapped along with whether there were any. It never unmaps routes; see
+// ReconcileRoutes. A route mapped to a different app port is remapped to the
+// desired one. When mapping fails, the routes mapped before the failure are
+// returned with the error. progress, when not nil, is called as each route is
+// mapped or skipped. DryRun, AtomicMapRoutes and WarningsAsErrors change how
+// routes are mapped and errors are returned.
+func (actor Actor) MapRoutes(ctx context.Context, config ApplicationConfig, progress RouteProgressFunc) (ApplicationConfig, bool, []v2action.Route, RouteWarnings, error) {
+	config, mapped, mappedRoutes, warnings, err := actor.mapRoutes(ctx, config, progress, nil)
+	return config, mapped, mappedRoutes, warnings, actor.warningsAsError(warnings, err)
+}
+
+// MapRoutesWithRetryBudget maps routes the same way as MapRoutes, except that
+// the retries of transient mapping failures are shared between all of the
+// routes and bounded by budget. Once the budget is exhausted, the next failure
+// is returned without being retried.
+func (actor Actor) MapRoutesWithRetryBudget(ctx context.Context, config ApplicationConfig, budget RetryBudget, progress RouteProgressFunc) (ApplicationConfig, bool, []v2action.Route, RouteWarnings, error) {
+	config, mapped, mappedRoutes, warnings, err := actor.mapRoutes(ctx, config, progress, &budget)
+	return config, mapped, mappedRoutes, warnings, actor.warningsAsError(warnings, err)
+}
+
+func (actor Actor) mapRoutes(ctx context.Context, config ApplicationConfig, progress RouteProgressFunc, budget *RetryBudget) (ApplicationConfig, bool, []v2action.Route, RouteWarnings, error) {
+	if config.NoRoute {
+		log.Info("no-route set, skipping route mapping")
+		return config, false, nil, nil, nil
+	}
+	if len(config.DesiredRoutes) == 0 {
+		log.Info("no desired routes, skipping route mapping")
+		return config, false, nil, nil, nil
+	}
+
+	if err := actor.checkPrimaryRoutes(config); err != nil {
+		log.Errorln("mapping routes:", err)
+		return ApplicationConfig{}, false, nil, nil, err
+	}
+
+	if actor.DryRun {
+		config, routesToMap := actor.MapRoutesDryRun(config)
+		return config, len(routesToMap) > 0, routesToMap, nil, nil
+	}
+
 	log.Info("mapping routes")
+	config.DesiredRoutes = actor.primaryRoutesFirst(config.DesiredRoutes)
+
+	var mappedRoutes []v2action.Route
+	var allWarnings RouteWarnings
+
+	changes := actor.RouteDiff(config)
+	for _, route := range changes.Unchanged {
+		log.Debugf("route %s already bound to app", route)
+		allWarnings = append(allWarnings, RouteWarning{
+			Message:  fmt.Sprintf("Route %s is already mapped to the application.", route),
+			Severity: SeverityInfo,
+		})
+		progress.report(route, RouteSkipped)
+	}
 
-	var boundRoutes bool
-	var allWarnings Warnings
+	// unmappedRoutes are the CurrentRoutes that were unmapped so that they
+	// could be mapped again with a different app port.
+	var unmappedRoutes []v2action.Route
+	fail := func(err error) (ApplicationConfig, bool, []v2action.Route, RouteWarnings, error) {
+		if !actor.AtomicMapRoutes {
+			return ApplicationConfig{}, false, mappedRoutes, allWarnings, err
+		}
+		allWarnings = append(allWarnings, actor.rollBackRouteMappings(config.DesiredApplication.GUID, config.DesiredApplication.SpaceGUID, mappedRoutes, unmappedRoutes)...)
+		return ApplicationConfig{}, false, nil, allWarnings, err
+	}
 
-	for _, route := range config.DesiredRoutes {
-		if !actor.routeInListByGUID(route, config.CurrentRoutes) {
-			log.Debugf("mapping route: %#v", route)
-			warnings, err := actor.mapRouteToApp(route, config.DesiredApplication.GUID)
-			allWarnings = append(allWarnings, warnings...)
+	for _, route := range changes.Added {
+		if err := ctx.Err(); err != nil {
+			log.Errorln("mapping route:", err)
+			return fail(err)
+		}
+
+		if currentRoute, found := actor.routeByGUID(route, config.CurrentRoutes); found {
+			log.WithField("route", route).Debug("route is mapped to a different app port, unmapping it first")
+			warnings, err := actor.V2Actor.UnmapRouteFromApplication(route.GUID, config.DesiredApplication.GUID)
+			allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, warnings...)...)
 			if err != nil {
-				log.Errorln("mapping route:", err)
-				return ApplicationConfig{}, false, allWarnings, err
+				log.Errorln("unmapping route:", err)
+				return fail(err)
 			}
-			boundRoutes = true
-		} else {
-			log.Debugf("route %s already bound to app", route)
+			unmappedRoutes = append(unmappedRoutes, currentRoute)
+		}
+
+		log.Debugf("mapping route: %#v", route)
+		warnings, err := actor.mapRouteToApp(ctx, route, config.DesiredApplication.GUID, config.DesiredApplication.SpaceGUID, config.DeploymentGUID, budget)
+		allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, warnings...)...)
+		if err != nil {
+			log.Errorln("mapping route:", err)
+			return fail(err)
 		}
+		progress.report(route, RouteMapped)
+		mappedRoutes = append(mappedRoutes, route)
+		allWarnings = append(allWarnings, protocolDowngradeWarnings(route)...)
 	}
 	log.Debug("mapping routes complete")
 	config.CurrentRoutes = config.DesiredRoutes
 
-	return config, boundRoutes, allWarnings, nil
+	return config, len(mappedRoutes) > 0, mappedRoutes, allWarnings, nil
 }
 
-func (actor Actor) UnmapRoutes(config ApplicationConfig) (ApplicationConfig, Warnings, error) {
-	var warnings Warnings
+// protocolDowngradeWarnings returns a warning when the route has a different
+// protocol from the one it was asked to have. Routes without a protocol, such
+// as those from a Cloud Controller that does not support route protocols, do
+// not result in a warning.
+func protocolDowngradeWarnings(route v2action.Route) RouteWarnings {
+	if route.RequestedProtocol == "" || route.Protocol == "" || route.Protocol == route.RequestedProtocol {
+		return nil
+	}
+
+	log.WithField("route", route).Warnf("route uses protocol %s instead of %s", route.Protocol, route.RequestedProtocol)
+	return RouteWarnings{{
+		Message:  fmt.Sprintf("Route %s uses protocol %s instead of the requested %s.", route, route.Protocol, route.RequestedProtocol),
+		Severity: SeverityWarning,
+	}}
+}
+
+// MapRoutesDryRun returns the configuration MapRoutes would return and the
+// routes it would map, without mapping any of them. As in MapRoutes, a config
+// with NoRoute set or without DesiredRoutes is returned unchanged.
+func (actor Actor) MapRoutesDryRun(config ApplicationConfig) (ApplicationConfig, []v2action.Route) {
+	if config.NoRoute || len(config.DesiredRoutes) == 0 {
+		return config, nil
+	}
+
+	log.Info("planning route mappings")
+	config.DesiredRoutes = actor.primaryRoutesFirst(config.DesiredRoutes)
+
+	routesToMap := actor.RouteDiff(config).Added
+	for _, route := range routesToMap {
+		log.WithField("route", route).Debug("would map route")
+	}
+	config.CurrentRoutes = config.DesiredRoutes
+
+	return config, routesToMap
+}
+
+// UnmapRoutes unmaps all of the CurrentRoutes from the desired application.
+// Up to RouteConcurrency routes are unmapped in parallel. When an unmap fails,
+// no further unmaps are started and the first error (in CurrentRoutes order)
+// is returned; the returned CurrentRoutes contains the routes that are still
+// mapped to the application. If ctx is cancelled, no further unmaps are
+// started and the context's error is returned.
+func (actor Actor) UnmapRoutes(ctx context.Context, config ApplicationConfig) (ApplicationConfig, Warnings, error) {
+	type unmapRouteResult struct {
+		unmapped bool
+		err      error
+	}
 
 	appGUID := config.DesiredApplication.GUID
+	results := make([]unmapRouteResult, len(config.CurrentRoutes))
+	var warnings warningsCollector
+
+	var routesToUnmap []int
+	for index := range config.CurrentRoutes {
+		routesToUnmap = append(routesToUnmap, index)
+	}
+
+	ctxErr := actor.inParallel(ctx, routesToUnmap, func(index int) error {
+		unmapWarnings, err := actor.V2Actor.UnmapRouteFromApplication(config.CurrentRoutes[index].GUID, appGUID)
+		warnings.add(index, NewRouteWarnings(SeverityWarning, unmapWarnings...))
+		results[index] = unmapRouteResult{
+			unmapped: err == nil,
+			err:      err,
+		}
+		return err
+	})
+
+	var (
+		remainingRoutes []v2action.Route
+		firstErr        error
+	)
+	allWarnings := warnings.drain().Strings()
+	for index, result := range results {
+		if result.err != nil && firstErr == nil {
+			firstErr = result.err
+		}
+		if !result.unmapped {
+			remainingRoutes = append(remainingRoutes, config.CurrentRoutes[index])
+		}
+	}
+	config.CurrentRoutes = remainingRoutes
+
+	if firstErr == nil {
+		firstErr = ctxErr
+	}
+	if firstErr != nil {
+		log.Errorln("unmapping route:", firstErr)
+		return config, allWarnings, firstErr
+	}
+
+	return config, allWarnings, nil
+}
+
+// UnmapSpecificRoutes unmaps only the CurrentRoutes that are in routes,
+// compared by GUID, from the desired application. Routes that are not in
+// CurrentRoutes are ignored. The returned CurrentRoutes contains every route
+// that is still mapped to the application, in the original order; errors are
+// handled as in UnmapRoutes.
+func (actor Actor) UnmapSpecificRoutes(ctx context.Context, config ApplicationConfig, routes []v2action.Route) (ApplicationConfig, Warnings, error) {
+	var targeted, untouched []v2action.Route
 	for _, route := range config.CurrentRoutes {
-		routeWarnings, err := actor.V2Actor.UnmapRouteFromApplication(route.GUID, appGUID)
-		warnings = append(warnings, routeWarnings...)
-		if err != nil {
-			return config, warnings, err
+		if actor.routeInListByGUID(route, routes) {
+			targeted = append(targeted, route)
+		} else {
+			untouched = append(untouched, route)
+		}
+	}
+
+	unmapConfig := config
+	unmapConfig.CurrentRoutes = targeted
+	unmapConfig, warnings, err := actor.UnmapRoutes(ctx, unmapConfig)
+
+	var remainingRoutes []v2action.Route
+	for _, route := range config.CurrentRoutes {
+		if actor.routeInListByGUID(route, untouched) || actor.routeInListByGUID(route, unmapConfig.CurrentRoutes) {
+			remainingRoutes = append(remainingRoutes, route)
 		}
 	}
-	config.CurrentRoutes = nil
+	config.CurrentRoutes = remainingRoutes
 
-	return config, warnings, nil
+	return config, warnings, err
 }
 
-func (actor Actor) CalculateRoutes(routes []string, orgGUID string, spaceGUID string, existingRoutes []v2action.Route) ([]v2action.Route, Warnings, error) {
-	calculatedRoutes, unknownRoutes := actor.splitExistingRoutes(routes, existingRoutes)
-	possibleDomains, err := actor.generatePossibleDomains(unknownRoutes)
+// RemapRoute moves the route from the application with fromAppGUID to the
+// application with toAppGUID. The route is mapped to the new application
+// before it is unmapped from the old one, so that it keeps serving traffic
+// throughout; mapping is retried as in MapRoutes. A route that is not in
+// toAppSpaceGUID, the space of the new application, is returned as a
+// RouteInDifferentSpaceError naming the route. When mapping fails, the route
+// is left mapped to the old application only. When unmapping fails, the route
+// is mapped to both applications and a RouteMappedToBothApplicationsError is
+// returned.
+func (actor Actor) RemapRoute(ctx context.Context, route v2action.Route, fromAppGUID string, toAppGUID string, toAppSpaceGUID string) (Warnings, error) {
+	log.WithField("route", route).Debug("remapping route")
+
+	var allWarnings Warnings
+	mapWarnings, err := actor.mapRouteToApp(ctx, route, toAppGUID, toAppSpaceGUID, "", nil)
+	allWarnings = append(allWarnings, mapWarnings...)
 	if err != nil {
-		log.Errorln("domain breakdown:", err)
-		return nil, nil, err
+		log.Errorln("mapping route:", err)
+		return allWarnings, err
 	}
 
-	var allWarnings Warnings
-	foundDomains, warnings, err := actor.V2Actor.GetDomainsByNameAndOrganization(possibleDomains, orgGUID)
+	unmapWarnings, err := actor.V2Actor.UnmapRouteFromApplication(route.GUID, fromAppGUID)
+	allWarnings = append(allWarnings, unmapWarnings...)
+	if err != nil {
+		log.Errorln("unmapping route:", err)
+		return allWarnings, actionerror.RouteMappedToBothApplicationsError{
+			Route:       route.String(),
+			FromAppGUID: fromAppGUID,
+			ToAppGUID:   toAppGUID,
+			Err:         err,
+		}
+	}
+
+	return allWarnings, nil
+}
+
+// checkRouteLimit returns a TooManyRoutesError when there are more routes
+// than MaxRoutesPerApp allows.
+func (actor Actor) checkRouteLimit(routes []v2action.Route) error {
+	if actor.MaxRoutesPerApp > 0 && len(routes) > actor.MaxRoutesPerApp {
+		log.Errorf("%d routes exceeds the limit of %d", len(routes), actor.MaxRoutesPerApp)
+		return actionerror.TooManyRoutesError{Routes: len(routes), MaxRoutes: actor.MaxRoutesPerApp}
+	}
+	return nil
+}
+
+// checkRoutesSessionAffinity returns a RouteSessionAffinityNotSupportedError
+// naming the first route that sets session affinity, since the Cloud
+// Controller API has no way to set it.
+func (actor Actor) checkRoutesSessionAffinity(routes []v2action.Route) error {
+	for _, route := range routes {
+		if route.SessionAffinity.IsSet {
+			log.WithField("route", route).Error("session affinity is not supported")
+			return actionerror.RouteSessionAffinityNotSupportedError{Route: route.String()}
+		}
+	}
+	return nil
+}
+
+// uniqueRoutes returns the routes without those that have the same settings
+// as an earlier route; see routeInListBySettings. The order of the routes is
+// kept.
+func (actor Actor) uniqueRoutes(routes []v2action.Route) []v2action.Route {
+	var unique []v2action.Route
+	for _, route := range routes {
+		if _, found := actor.routeInListBySettings(route, unique); found {
+			log.WithField("route", route).Debug("skipping duplicate route")
+			continue
+		}
+		unique = append(unique, route)
+	}
+	return unique
+}
+
+// RouteSummary describes the changes EnsureRoutesMapped or ReconcileRoutes
+// made to an application's routes.
+type RouteSummary struct {
+	// Created are the routes that were created.
+	Created []v2action.Route
+	// Mapped are the routes that were mapped, or mapped again with new
+	// settings, to the application.
+	Mapped []v2action.Route
+	// Unmapped are the routes that were unmapped from the application.
+	Unmapped []v2action.Route
+}
+
+// EnsureRoutesMapped brings the desired application's routes in line with the
+// config: missing DesiredRoutes are created, and the routes are then
+// reconciled as in ReconcileRoutes. When config has NoRoute set, nothing is
+// created and all of the CurrentRoutes are unmapped. Running it again with the
+// returned config does nothing. When the actor is in DryRun mode no routes are
+// changed and the summary describes what would have been done. Only warnings
+// of SeverityWarning from the route actions are returned.
+func (actor Actor) EnsureRoutesMapped(ctx context.Context, config ApplicationConfig) (ApplicationConfig, RouteSummary, Warnings, error) {
+	var (
+		summary     RouteSummary
+		allWarnings Warnings
+	)
+
+	if !config.NoRoute {
+		var (
+			warnings RouteWarnings
+			err      error
+		)
+		config, summary.Created, warnings, err = actor.CreateRoutes(ctx, config, nil)
+		allWarnings = append(allWarnings, warnings.AtLeast(SeverityWarning).Strings()...)
+		if err != nil {
+			return ApplicationConfig{}, summary, allWarnings, err
+		}
+	}
+
+	config, reconciled, warnings, err := actor.ReconcileRoutes(ctx, config)
 	allWarnings = append(allWarnings, warnings...)
+	summary.Mapped = reconciled.Mapped
+	summary.Unmapped = reconciled.Unmapped
+	if err != nil {
+		return ApplicationConfig{}, summary, allWarnings, err
+	}
+
+	return config, summary, allWarnings, nil
+}
+
+// ReconcileRoutes makes the config's DesiredRoutes the desired application's
+// only routes: the DesiredRoutes that RouteDiff reports as Added are mapped as
+// in MapRoutes, and the CurrentRoutes it reports as Removed are unmapped. When
+// config has NoRoute set, nothing is mapped and all of the CurrentRoutes are
+// unmapped. Unlike EnsureRoutesMapped, no routes are created, so every desired
+// route must already exist.
+//
+// Routes are mapped before any are unmapped, so the application is not left
+// without routes while they change. When mapping fails, nothing is unmapped;
+// the returned summary lists the routes mapped before the failure. When the
+// actor is in DryRun mode no routes are changed and the summary describes what
+// would have been done. Only warnings of SeverityWarning from the route
+// actions are returned.
+func (actor Actor) ReconcileRoutes(ctx context.Context, config ApplicationConfig) (ApplicationConfig, RouteSummary, Warnings, error) {
+	var (
+		summary     RouteSummary
+		allWarnings Warnings
+	)
+
+	changes := actor.RouteDiff(config)
+	if config.NoRoute {
+		changes.Removed = config.CurrentRoutes
+	} else if len(changes.Added) > 0 {
+		var (
+			warnings RouteWarnings
+			err      error
+		)
+		config, _, summary.Mapped, warnings, err = actor.MapRoutes(ctx, config, nil)
+		allWarnings = append(allWarnings, warnings.AtLeast(SeverityWarning).Strings()...)
+		if err != nil {
+			return ApplicationConfig{}, summary, allWarnings, err
+		}
+	}
+
+	if len(changes.Removed) > 0 {
+		if !actor.DryRun {
+			unmapConfig := config
+			unmapConfig.CurrentRoutes = changes.Removed
+			_, warnings, err := actor.UnmapRoutes(ctx, unmapConfig)
+			allWarnings = append(allWarnings, warnings...)
+			if err != nil {
+				return ApplicationConfig{}, summary, allWarnings, err
+			}
+		}
+		summary.Unmapped = changes.Removed
+		config.CurrentRoutes = actor.withoutRoutes(config.CurrentRoutes, changes.Removed)
+	}
+
+	return config, summary, allWarnings, nil
+}
+
+// withoutRoutes returns the routes that are not in excluded, compared by GUID.
+func (actor Actor) withoutRoutes(routes []v2action.Route, excluded []v2action.Route) []v2action.Route {
+	var remaining []v2action.Route
+	for _, route := range routes {
+		if !actor.routeInListByGUID(route, excluded) {
+			remaining = append(remaining, route)
+		}
+	}
+	return remaining
+}
+
+// CalculateRoutes returns a route for each of the provided routes, looking up
+// any that are not in existingRoutes. Every route is validated before any is
+// looked up, and duplicate routes are only returned once. A route with a port
+// range results in one route per port. When a lookup fails or ctx is
+// cancelled, the routes calculated so far are returned with the error.
+// MaxRouteLabels, MaxRoutesPerApp, FailOnRouteInOtherSpace,
+// WarnOnHostInMultipleDomains, Resolver and WarningsAsErrors add checks and
+// warnings.
+func (actor Actor) CalculateRoutes(ctx context.Context, routes []string, orgGUID string, spaceGUID string, existingRoutes []v2action.Route, randomTCPPorts bool) ([]v2action.Route, RouteWarnings, error) {
+	calculatedRoutes, potentialRoutes, warnings, err := actor.validateRoutes(ctx, routes, orgGUID, spaceGUID, existingRoutes, randomTCPPorts)
+	allWarnings := NewRouteWarnings(SeverityWarning, warnings...)
 	if err != nil {
-		log.Errorln("domain lookup:", err)
 		return nil, allWarnings, err
 	}
-	nameToFoundDomain := map[string]v2action.Domain{}
-	for _, foundDomain := range foundDomains {
-		log.WithField("domain", foundDomain.Name).Debug("found domain")
-		nameToFoundDomain[foundDomain.Name] = foundDomain
+
+	calculatedRoutes, allWarnings, err = actor.lookUpRoutes(ctx, calculatedRoutes, potentialRoutes, allWarnings, orgGUID)
+	return calculatedRoutes, allWarnings, actor.warningsAsError(allWarnings, err)
+}
+
+// lookUpRoutes looks up each of the validated potentialRoutes, as
+// CalculateRoutes describes, and returns them after calculatedRoutes.
+func (actor Actor) lookUpRoutes(ctx context.Context, calculatedRoutes []v2action.Route, potentialRoutes []v2action.Route, allWarnings RouteWarnings, orgGUID string) ([]v2action.Route, RouteWarnings, error) {
+	allRoutes := append(append([]v2action.Route(nil), calculatedRoutes...), potentialRoutes...)
+	if err := actor.checkRouteLimit(actor.uniqueRoutes(allRoutes)); err != nil {
+		return nil, allWarnings, err
 	}
 
-	for _, route := range unknownRoutes {
-		log.WithField("route", route).Debug("generating route")
+	if actor.FailOnRouteInOtherSpace {
+		preflightWarnings, preflightErr := actor.checkRoutesInOtherSpaces(actor.uniqueRoutes(potentialRoutes), orgGUID)
+		allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, preflightWarnings...)...)
+		if preflightErr != nil {
+			log.Errorln("route preflight:", preflightErr)
+			return nil, allWarnings, preflightErr
+		}
+	}
 
-		root, port, path, parseErr := actor.parseURL(route)
-		if parseErr != nil {
-			log.Errorln("parse route:", parseErr)
-			return nil, allWarnings, parseErr
+	for _, potentialRoute := range actor.uniqueRoutes(potentialRoutes) {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			log.Errorln("route lookup:", ctxErr)
+			return actor.uniqueRoutes(calculatedRoutes), allWarnings, ctxErr
 		}
 
-		host, domain, domainErr := actor.calculateRoute(root, nameToFoundDomain)
-		if _, ok := domainErr.(actionerror.DomainNotFoundError); ok {
-			log.Error("no matching domains")
-			return nil, allWarnings, actionerror.NoMatchingDomainError{Route: route}
-		} else if domainErr != nil {
-			log.Errorln("matching domains:", domainErr)
-			return nil, allWarnings, domainErr
+		calculatedRoute, routeWarnings, routeErr := actor.findOrReturnPartialRouteWithSettings(potentialRoute, orgGUID)
+		allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, routeWarnings...)...)
+		if routeErr != nil {
+			log.Errorln("route lookup:", routeErr)
+			return actor.uniqueRoutes(calculatedRoutes), allWarnings, routeErr
+		}
+		if calculatedRoute.ExistsInOtherSpace {
+			allWarnings = append(allWarnings, RouteWarning{
+				Message:  fmt.Sprintf("Route %s does not exist in this space, but a route with the same host and domain exists in another space of the organization.", calculatedRoute),
+				Severity: SeverityWarning,
+			})
 		}
 
-		potentialRoute := v2action.Route{
-			Host:      strings.Join(host, "."),
-			Domain:    domain,
-			Path:      path,
-			Port:      port,
-			SpaceGUID: spaceGUID,
+		calculatedRoutes = append(calculatedRoutes, calculatedRoute)
+	}
+
+	calculatedRoutes = actor.uniqueRoutes(calculatedRoutes)
+	if actor.WarnOnHostInMultipleDomains {
+		allWarnings = append(allWarnings, actor.hostsInMultipleDomains(calculatedRoutes)...)
+	}
+	allWarnings = append(allWarnings, actor.resolveRouteDomains(ctx, calculatedRoutes)...)
+	return calculatedRoutes, allWarnings, nil
+}
+
+// hostsInMultipleDomains returns a warning for each host that the routes use
+// with more than one domain, listing the domains in the order they are first
+// used. Hosts are compared case insensitively and routes without a host are
+// ignored.
+func (Actor) hostsInMultipleDomains(routes []v2action.Route) RouteWarnings {
+	var hosts []string
+	hostDomains := map[string][]string{}
+	for _, route := range routes {
+		if route.Host == "" {
+			continue
+		}
+
+		host := strings.ToLower(route.Host)
+		domains, seen := hostDomains[host]
+		if !seen {
+			hosts = append(hosts, host)
+		}
+
+		knownDomain := false
+		for _, domain := range domains {
+			if strings.EqualFold(domain, route.Domain.Name) {
+				knownDomain = true
+				break
+			}
+		}
+		if !knownDomain {
+			hostDomains[host] = append(domains, route.Domain.Name)
+		}
+	}
+
+	var warnings RouteWarnings
+	for _, host := range hosts {
+		if domains := hostDomains[host]; len(domains) > 1 {
+			log.WithField("host", host).Warn("host is used with more than one domain")
+			warnings = append(warnings, RouteWarning{
+				Message:  fmt.Sprintf("Host %s is used with more than one domain: %s.", host, strings.Join(domains, ", ")),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return warnings
+}
+
+// CreateAndMapDefaultApplicationRoute creates the default route for the
+// application, if it does not already exist, and maps it to the application.
+// When SkipDefaultRoute is set, it does nothing.
+func (actor Actor) CreateAndMapDefaultApplicationRoute(orgGUID string, spaceGUID string, app v2action.Application) (Warnings, error) {
+	if actor.SkipDefaultRoute {
+		log.WithField("app", app.Name).Debug("skipping default route")
+		return nil, nil
+	}
+
+	var warnings Warnings
+	defaultRoute, domainWarnings, err := actor.getDefaultRoute(orgGUID, spaceGUID, app.Name)
+	warnings = append(warnings, domainWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	boundRoutes, appRouteWarnings, err := actor.V2Actor.GetApplicationRoutes(app.GUID)
+	warnings = append(warnings, appRouteWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	_, routeAlreadyBound := actor.routeInListBySettings(defaultRoute, boundRoutes)
+	if routeAlreadyBound {
+		return warnings, err
+	}
+
+	spaceRoutes, spaceRoutesWarnings, err := actor.V2Actor.GetSpaceRoutes(spaceGUID)
+	warnings = append(warnings, spaceRoutesWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	// An equivalent route in the space is reused, so that pushing again does
+	// not create a duplicate of the default route.
+	spaceRoute, routeAlreadyExists := actor.routeInListBySettings(defaultRoute, spaceRoutes)
+	if !routeAlreadyExists {
+		var spaceRouteWarnings v2action.Warnings
+		spaceRoute, spaceRouteWarnings, err = actor.V2Actor.FindRouteBoundToSpaceWithSettings(defaultRoute)
+		warnings = append(warnings, spaceRouteWarnings...)
+		routeAlreadyExists = true
+		if _, ok := err.(actionerror.RouteNotFoundError); ok {
+			routeAlreadyExists = false
+		} else if err != nil {
+			return warnings, err
+		}
+	}
+
+	if !routeAlreadyExists {
+		var createRouteWarning v2action.Warnings
+		observed := actor.observe(CreateRouteOperation)
+		spaceRoute, createRouteWarning, err = actor.V2Actor.CreateRoute(defaultRoute, false)
+		observed()
+		warnings = append(warnings, createRouteWarning...)
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	observed := actor.observe(MapRouteToApplicationOperation)
+	mapWarnings, err := actor.V2Actor.MapRouteToApplication(spaceRoute.GUID, app.GUID)
+	observed()
+	warnings = append(warnings, mapWarnings...)
+	return warnings, err
+}
+
+// CreateRoutes creates any desired routes that do not have a GUID, up to
+// RouteConcurrency at a time, and returns the routes it created in
+// DesiredRoutes order. The metadata and options of each desired route are
+// reconciled once it exists; a route that sets session affinity fails the
+// push before anything is created, since the Cloud Controller cannot set it.
+// A route created by another process in the meantime is looked up and used
+// instead. When a creation fails, the routes
+// created before it are returned with the error. progress, when not nil, is
+// called as each route is created or skipped. DryRun, MaxRoutesPerApp and
+// WarningsAsErrors change how routes are created and errors are returned.
+func (actor Actor) CreateRoutes(ctx context.Context, config ApplicationConfig, progress RouteProgressFunc) (ApplicationConfig, []v2action.Route, RouteWarnings, error) {
+	config, createdRoutes, warnings, err := actor.createRoutes(ctx, config, progress)
+	return config, createdRoutes, warnings, actor.warningsAsError(warnings, err)
+}
+
+func (actor Actor) createRoutes(ctx context.Context, config ApplicationConfig, progress RouteProgressFunc) (ApplicationConfig, []v2action.Route, RouteWarnings, error) {
+	if config.NoRoute {
+		log.Info("no-route set, skipping route creation")
+		return config, nil, nil, nil
+	}
+	if len(config.DesiredRoutes) == 0 {
+		log.Info("no desired routes, skipping route creation")
+		return config, nil, nil, nil
+	}
+
+	if actor.DryRun {
+		config, routesToCreate, err := actor.CreateRoutesDryRun(config)
+		return config, routesToCreate, nil, err
+	}
+
+	if err := actor.checkRouteLimit(config.DesiredRoutes); err != nil {
+		return ApplicationConfig{}, nil, nil, err
+	}
+	if err := actor.checkRoutesSessionAffinity(config.DesiredRoutes); err != nil {
+		return ApplicationConfig{}, nil, nil, err
+	}
+
+	log.Info("creating routes")
+
+	type createRouteResult struct {
+		route   v2action.Route
+		existed bool
+		created bool
+		err     error
+	}
+
+	results := make([]createRouteResult, len(config.DesiredRoutes))
+	var warnings warningsCollector
+
+	var routesToCreate []int
+	for index, route := range config.DesiredRoutes {
+		if route.GUID == "" {
+			routesToCreate = append(routesToCreate, index)
+		} else {
+			log.WithField("route", route).Debug("already exists, skipping")
+			results[index] = createRouteResult{route: route, existed: true}
+			warnings.add(index, RouteWarnings{{
+				Message:  fmt.Sprintf("Route %s already exists.", route),
+				Severity: SeverityInfo,
+			}})
+		}
+	}
+
+	ctxErr := actor.inParallel(ctx, routesToCreate, func(index int) error {
+		createdRoute, existed, createWarnings, err := actor.createRoute(config.DesiredRoutes[index])
+		warnings.add(index, createWarnings)
+		results[index] = createRouteResult{
+			route:   createdRoute,
+			existed: existed,
+			created: err == nil && !existed,
+			err:     err,
+		}
+		return err
+	})
+
+	var (
+		routes        []v2action.Route
+		createdRoutes []v2action.Route
+		firstErr      error
+	)
+	allWarnings := warnings.drain()
+	for _, result := range results {
+		if result.err != nil && firstErr == nil {
+			firstErr = result.err
+		}
+		switch {
+		case result.existed:
+			progress.report(result.route, RouteSkipped)
+		case result.created:
+			createdRoutes = append(createdRoutes, result.route)
+			progress.report(result.route, RouteCreated)
 		}
+		routes = append(routes, result.route)
+	}
+
+	if firstErr == nil {
+		firstErr = ctxErr
+	}
+	if firstErr != nil {
+		log.Errorln("creating route:", firstErr)
+		return ApplicationConfig{}, createdRoutes, allWarnings, firstErr
+	}
+	config.DesiredRoutes = routes
+
+	metadataWarnings, err := actor.setRoutesMetadata(routes)
+	allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, metadataWarnings...)...)
+	if err != nil {
+		log.Errorln("setting route metadata:", err)
+		return ApplicationConfig{}, createdRoutes, allWarnings, err
+	}
+
+	optionsWarnings, err := actor.setRoutesOptions(routes)
+	allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, optionsWarnings...)...)
+	if err != nil {
+		log.Errorln("setting route options:", err)
+		return ApplicationConfig{}, createdRoutes, allWarnings, err
+	}
 
-		validationErr := potentialRoute.Validate()
-		if validationErr != nil {
-			return nil, allWarnings, validationErr
+	return config, createdRoutes, allWarnings, nil
+}
+
+// createRoute creates the route, as described in CreateRoutes, returning the
+// created route, or the existing route along with true when another process
+// created it first.
+func (actor Actor) createRoute(route v2action.Route) (v2action.Route, bool, RouteWarnings, error) {
+	log.WithField("route", route).Debug("creating route")
+
+	var allWarnings RouteWarnings
+	observed := actor.observe(CreateRouteOperation)
+	createdRoute, createWarnings, err := actor.V2Actor.CreateRoute(route, route.RandomTCPPort())
+	observed()
+	if quotaErr, ok := err.(ccerror.RouteQuotaExceededError); ok {
+		err = actionerror.RouteQuotaExceededError{Route: route.String(), Message: quotaErr.Message}
+	}
+	allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, createWarnings...)...)
+	existed := false
+	if _, ok := err.(actionerror.RouteAlreadyExistsError); ok {
+		foundRoute, findWarnings, findErr := actor.V2Actor.FindRouteBoundToSpaceWithSettings(route)
+		allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, findWarnings...)...)
+		if findErr == nil {
+			log.WithField("route", route).Debug("route was created concurrently, using the existing route")
+			createdRoute, existed, err = foundRoute, true, nil
+			allWarnings = append(allWarnings, RouteWarning{
+				Message:  fmt.Sprintf("Route %s was created by another process; using the existing route.", route),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return withPushSettings(createdRoute, route), existed, allWarnings, err
+}
+
+// ReserveRoutes creates the provided routes in the space without mapping them
+// to an application, so that they can be mapped later, for example once the
+// application has been pushed. The routes are calculated as in CalculateRoutes
+// and created as in CreateRoutes; routes that already exist are left alone.
+// The routes that were created are returned.
+func (actor Actor) ReserveRoutes(ctx context.Context, routes []string, orgGUID string, spaceGUID string) ([]v2action.Route, RouteWarnings, error) {
+	log.Info("reserving routes")
+	calculatedRoutes, warnings, err := actor.CalculateRoutes(ctx, routes, orgGUID, spaceGUID, nil, false)
+	if err != nil {
+		log.Errorln("calculating routes to reserve:", err)
+		return nil, warnings, err
+	}
+
+	config := ApplicationConfig{DesiredRoutes: calculatedRoutes}
+	_, createdRoutes, createWarnings, err := actor.CreateRoutes(ctx, config, nil)
+	warnings = append(warnings, createWarnings...)
+	return createdRoutes, warnings, err
+}
+
+// setRoutesMetadata sets the labels and annotations of each route that has
+// metadata, stopping at the first error. See routeMetadata. Labels the route
+// has that its metadata no longer declares are removed, so that re-pushing
+// reconciles the route's labels with the manifest.
+func (actor Actor) setRoutesMetadata(routes []v2action.Route) (Warnings, error) {
+	var allWarnings Warnings
+	for _, route := range routes {
+		metadata := routeMetadata(route)
+		if metadata.IsEmpty() {
+			continue
+		}
+		if actor.V3Actor == nil {
+			return allWarnings, actionerror.RouteRequiresV3APIError{Route: route.String()}
+		}
+
+		currentMetadata, warnings, err := actor.V3Actor.GetRouteMetadata(route.GUID)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			return allWarnings, err
+		}
+
+		log.WithField("route", route).Debug("setting route metadata")
+		warnings, err = actor.V3Actor.UpdateRouteMetadata(route.GUID, metadataUpdate(metadata, currentMetadata))
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			return allWarnings, err
+		}
+	}
+
+	return allWarnings, nil
+}
+
+// metadataUpdate returns the update that sets metadata on a route that
+// currently has currentMetadata: every label and annotation of metadata is
+// set, and every current label that metadata does not declare is removed.
+func metadataUpdate(metadata v2action.Metadata, currentMetadata v3action.Metadata) v3action.Metadata {
+	update := v3action.Metadata{
+		Labels:      map[string]types.FilteredString{},
+		Annotations: map[string]types.FilteredString{},
+	}
+	for name := range currentMetadata.Labels {
+		if _, ok := metadata.Labels[name]; !ok {
+			update.Labels[name] = types.FilteredString{}
+		}
+	}
+	for name, value := range metadata.Labels {
+		update.Labels[name] = types.FilteredString{IsSet: true, Value: value}
+	}
+	for name, value := range metadata.Annotations {
+		update.Annotations[name] = types.FilteredString{IsSet: true, Value: value}
+	}
+	return update
+}
+
+// routeMetadata returns the labels and annotations to set on the route: its
+// metadata, with the EphemeralRouteAnnotation added when the route is marked
+// as Ephemeral. The route's own annotations are not modified.
+func routeMetadata(route v2action.Route) v2action.Metadata {
+	metadata := route.Metadata
+	if !route.Ephemeral || metadata.IsEphemeral() {
+		return metadata
+	}
+
+	annotations := map[string]string{v2action.EphemeralRouteAnnotation: "true"}
+	for name, value := range metadata.Annotations {
+		if name != v2action.EphemeralRouteAnnotation {
+			annotations[name] = value
+		}
+	}
+	metadata.Annotations = annotations
+	return metadata
+}
+
+// setRoutesOptions sets the options of each route that has them, stopping at
+// the first error. When the Cloud Controller does not support route options,
+// the returned error names the route.
+func (actor Actor) setRoutesOptions(routes []v2action.Route) (Warnings, error) {
+	var allWarnings Warnings
+	for _, route := range routes {
+		if len(route.Options) == 0 {
+			continue
+		}
+		if actor.V3Actor == nil {
+			return allWarnings, actionerror.RouteRequiresV3APIError{Route: route.String()}
+		}
+
+		log.WithField("route", route).Debug("setting route options")
+		warnings, err := actor.V3Actor.UpdateRouteOptions(route.GUID, route.Options)
+		allWarnings = append(allWarnings, warnings...)
+		if e, ok := err.(actionerror.RouteOptionsNotSupportedError); ok {
+			e.Route = route.String()
+			return allWarnings, e
+		} else if err != nil {
+			return allWarnings, err
+		}
+	}
+
+	return allWarnings, nil
+}
+
+// CreateRoutesDryRun returns the configuration CreateRoutes would return and
+// the routes it would create, without creating any of them. The routes that
+// would be created are validated and the first validation error is returned,
+// as is the TooManyRoutesError CreateRoutes would return. Since nothing is
+// created, the returned DesiredRoutes do not gain GUIDs.
+func (actor Actor) CreateRoutesDryRun(config ApplicationConfig) (ApplicationConfig, []v2action.Route, error) {
+	if config.NoRoute {
+		return config, nil, nil
+	}
+
+	if err := actor.checkRouteLimit(config.DesiredRoutes); err != nil {
+		return ApplicationConfig{}, nil, err
+	}
+	if err := actor.checkRoutesSessionAffinity(config.DesiredRoutes); err != nil {
+		return ApplicationConfig{}, nil, err
+	}
+
+	log.Info("planning route creation")
+
+	var routesToCreate []v2action.Route
+	for _, route := range config.DesiredRoutes {
+		if route.GUID != "" {
+			log.WithField("route", route).Debug("already exists, skipping")
+			continue
+		}
+
+		if err := route.Validate(); err != nil {
+			log.Errorln("validating route:", err)
+			return ApplicationConfig{}, nil, err
+		}
+		log.WithField("route", route).Debug("would create route")
+		routesToCreate = append(routesToCreate, route)
+	}
+
+	return config, routesToCreate, nil
+}
+
+// RollbackCreatedRoutes deletes routes that were created during a push that
+// later failed. A route is only deleted when no application other than appGUID
+// is mapped to it. Deletion continues past failures; the routes that were not
+// deleted, either because they were skipped or because deleting them failed,
+// are returned along with the first error. If ctx is cancelled, the remaining
+// routes are not deleted and the context's error is returned.
+func (actor Actor) RollbackCreatedRoutes(ctx context.Context, appGUID string, routes []v2action.Route) ([]v2action.Route, Warnings, error) {
+	log.Info("rolling back created routes")
+
+	var (
+		undeletedRoutes []v2action.Route
+		allWarnings     Warnings
+		firstErr        error
+	)
+	for index, route := range routes {
+		if err := ctx.Err(); err != nil {
+			undeletedRoutes = append(undeletedRoutes, routes[index:]...)
+			if firstErr == nil {
+				firstErr = err
+			}
+			break
+		}
+
+		apps, warnings, err := actor.V2Actor.GetRouteApplications(route.GUID)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			log.WithField("route", route).Errorln("getting route applications:", err)
+			undeletedRoutes = append(undeletedRoutes, route)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if actor.mappedToOtherApplication(apps, appGUID) {
+			log.WithField("route", route).Debug("mapped to another application, skipping")
+			undeletedRoutes = append(undeletedRoutes, route)
+			continue
+		}
+
+		log.WithField("route", route).Debug("deleting route")
+		warnings, err = actor.V2Actor.DeleteRoute(route.GUID)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			log.WithField("route", route).Errorln("deleting route:", err)
+			undeletedRoutes = append(undeletedRoutes, route)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return undeletedRoutes, allWarnings, firstErr
+}
+
+// DeleteUnmappedRoutes unmaps all of the CurrentRoutes from the desired
+// application, as UnmapRoutes does, and then deletes each unmapped route that
+// is no longer mapped to any application. Routes that are still mapped to
+// other applications are retained. When unmapping fails, nothing is deleted
+// and the error is returned. Deletion continues past failures; a route that
+// could not be checked or deleted is retained and the first error is
+// returned. If ctx is cancelled, the remaining routes are retained and the
+// context's error is returned.
+func (actor Actor) DeleteUnmappedRoutes(ctx context.Context, config ApplicationConfig) (ApplicationConfig, []v2action.Route, []v2action.Route, Warnings, error) {
+	routes := config.CurrentRoutes
+	config, allWarnings, err := actor.UnmapRoutes(ctx, config)
+	if err != nil {
+		return config, nil, nil, allWarnings, err
+	}
+
+	log.Info("deleting unmapped routes")
+
+	var (
+		deletedRoutes  []v2action.Route
+		retainedRoutes []v2action.Route
+		firstErr       error
+	)
+	for index, route := range routes {
+		if err := ctx.Err(); err != nil {
+			retainedRoutes = append(retainedRoutes, routes[index:]...)
+			firstErr = err
+			break
+		}
+
+		apps, warnings, err := actor.V2Actor.GetRouteApplications(route.GUID)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			log.WithField("route", route).Errorln("getting route applications:", err)
+			retainedRoutes = append(retainedRoutes, route)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if len(apps) > 0 {
+			log.WithField("route", route).Debug("mapped to another application, retaining")
+			retainedRoutes = append(retainedRoutes, route)
+			continue
+		}
+
+		log.WithField("route", route).Debug("deleting route")
+		warnings, err = actor.V2Actor.DeleteRoute(route.GUID)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			log.WithField("route", route).Errorln("deleting route:", err)
+			retainedRoutes = append(retainedRoutes, route)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		deletedRoutes = append(deletedRoutes, route)
+	}
+
+	return config, deletedRoutes, retainedRoutes, allWarnings, firstErr
+}
+
+func (Actor) mappedToOtherApplication(apps []v2action.Application, appGUID string) bool {
+	for _, app := range apps {
+		if app.GUID != appGUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetOrphanedRoutes returns the routes in the space that are not mapped to any
+// application, in the order the space's routes are listed. The applications
+// of up to RouteConcurrency routes are looked up at the same time. When a
+// lookup fails, the first error is returned. If ctx is cancelled, no further
+// lookups are started and the context's error is returned.
+func (actor Actor) GetOrphanedRoutes(ctx context.Context, spaceGUID string) ([]v2action.Route, Warnings, error) {
+	spaceRoutes, spaceWarnings, err := actor.V2Actor.GetSpaceRoutes(spaceGUID)
+	allWarnings := Warnings(spaceWarnings)
+	if err != nil {
+		log.Errorln("getting space routes:", err)
+		return nil, allWarnings, err
+	}
+
+	type routeApplicationsResult struct {
+		orphaned bool
+		err      error
+	}
+
+	results := make([]routeApplicationsResult, len(spaceRoutes))
+	var warnings warningsCollector
+
+	var routesToCheck []int
+	for index := range spaceRoutes {
+		routesToCheck = append(routesToCheck, index)
+	}
+
+	ctxErr := actor.inParallel(ctx, routesToCheck, func(index int) error {
+		apps, appWarnings, err := actor.V2Actor.GetRouteApplications(spaceRoutes[index].GUID)
+		warnings.add(index, NewRouteWarnings(SeverityWarning, appWarnings...))
+		results[index] = routeApplicationsResult{orphaned: err == nil && len(apps) == 0, err: err}
+		return err
+	})
+
+	allWarnings = append(allWarnings, warnings.drain().Strings()...)
+
+	var orphanedRoutes []v2action.Route
+	for index, result := range results {
+		if result.err != nil {
+			log.WithField("route", spaceRoutes[index]).Errorln("getting route applications:", result.err)
+			return nil, allWarnings, result.err
+		}
+		if result.orphaned {
+			orphanedRoutes = append(orphanedRoutes, spaceRoutes[index])
+		}
+	}
+	if ctxErr != nil {
+		log.Errorln("getting route applications:", ctxErr)
+		return nil, allWarnings, ctxErr
+	}
+
+	return orphanedRoutes, allWarnings, nil
+}
+
+// DeleteEphemeralRoutes deletes the routes in the space that are marked as
+// ephemeral with the EphemeralRouteAnnotation and were created more than
+// olderThan ago. Routes that are newer, or whose creation time is unknown, are
+// not looked at. Deletion continues past failures; the deleted routes are
+// returned along with the first error. Without a V3Actor no route can have
+// the annotation, so nothing is deleted.
+func (actor Actor) DeleteEphemeralRoutes(spaceGUID string, olderThan time.Duration) ([]v2action.Route, Warnings, error) {
+	if actor.V3Actor == nil {
+		log.Info("no V3 API, so no routes are ephemeral")
+		return nil, nil, nil
+	}
+
+	spaceRoutes, spaceWarnings, err := actor.V2Actor.GetSpaceRoutes(spaceGUID)
+	allWarnings := Warnings(spaceWarnings)
+	if err != nil {
+		log.Errorln("getting space routes:", err)
+		return nil, allWarnings, err
+	}
+
+	log.Info("deleting ephemeral routes")
+	cutoff := time.Now().Add(-olderThan)
+
+	var (
+		deletedRoutes []v2action.Route
+		firstErr      error
+	)
+	for _, route := range spaceRoutes {
+		if route.CreatedAt.IsZero() || !route.CreatedAt.Before(cutoff) {
+			continue
+		}
+
+		v3Metadata, metadataWarnings, err := actor.V3Actor.GetRouteMetadata(route.GUID)
+		allWarnings = append(allWarnings, metadataWarnings...)
+		if err != nil {
+			log.WithField("route", route).Errorln("getting route metadata:", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		metadata := routeMetadataFromV3(v3Metadata)
+		if !metadata.IsEphemeral() {
+			continue
+		}
+
+		log.WithField("route", route).Debug("deleting ephemeral route")
+		warnings, err := actor.V2Actor.DeleteRoute(route.GUID)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil {
+			log.WithField("route", route).Errorln("deleting route:", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		route.Metadata = metadata
+		deletedRoutes = append(deletedRoutes, route)
+	}
+
+	return deletedRoutes, allWarnings, firstErr
+}
+
+// routeMetadataFromV3 returns the labels and annotations of metadata that are
+// set.
+func routeMetadataFromV3(metadata v3action.Metadata) v2action.Metadata {
+	var routeMetadata v2action.Metadata
+	for name, value := range metadata.Labels {
+		if !value.IsSet {
+			continue
+		}
+		if routeMetadata.Labels == nil {
+			routeMetadata.Labels = map[string]string{}
+		}
+		routeMetadata.Labels[name] = value.Value
+	}
+	for name, value := range metadata.Annotations {
+		if !value.IsSet {
+			continue
+		}
+		if routeMetadata.Annotations == nil {
+			routeMetadata.Annotations = map[string]string{}
+		}
+		routeMetadata.Annotations[name] = value.Value
+	}
+	return routeMetadata
+}
+
+// GeneratedRouteStatus is whether the route returned by GetGeneratedRoute
+// already exists.
+type GeneratedRouteStatus string
+
+const (
+	// GeneratedRouteNew is a route that does not exist yet.
+	GeneratedRouteNew GeneratedRouteStatus = "new"
+	// GeneratedRouteExistsInSpace is a route that exists in the space.
+	GeneratedRouteExistsInSpace GeneratedRouteStatus = "exists in space"
+	// GeneratedRouteExistsElsewhere is a route that exists, or has the host
+	// and domain of a route that exists, in another space.
+	GeneratedRouteExistsElsewhere GeneratedRouteStatus = "exists elsewhere"
+)
+
+// GetGeneratedRoute returns a route with the host and the default org domain.
+// This may be a partial route (ie no GUID) if the route does not exist. When
+// the manifest asks for a random route on an HTTP domain, a random suffix is
+// added to the host and a route that does not exist yet is returned.
+// When the manifest asks for an internal route, the domain must be an internal
+// domain; routes on internal domains have no path or port.
+//
+// The returned status tells whether the route already exists. A route in
+// another space is returned with a RouteInDifferentSpaceError and
+// GeneratedRouteExistsElsewhere. A route that does not exist yet, but whose
+// host and domain are used by a route in another space of the organization,
+// is flagged with ExistsInOtherSpace and also has
+// GeneratedRouteExistsElsewhere. The status is empty when any other error is
+// returned.
+func (actor Actor) GetGeneratedRoute(manifestApp manifest.Application, orgGUID string, spaceGUID string, knownRoutes []v2action.Route) (v2action.Route, GeneratedRouteStatus, Warnings, error) {
+	desiredDomain, warnings, err := actor.calculateDomain(manifestApp, orgGUID)
+	if err != nil {
+		return v2action.Route{}, "", warnings, err
+	}
+
+	if manifestApp.InternalRoute && !desiredDomain.IsInternal() {
+		return v2action.Route{}, "", warnings, actionerror.InternalRouteOnExternalDomainError{Domain: desiredDomain.Name}
+	}
+
+	desiredHostname, err := actor.calculateHostname(manifestApp, desiredDomain)
+	if err != nil {
+		return v2action.Route{}, "", warnings, err
+	}
+
+	desiredPath, err := actor.calculatePath(manifestApp, desiredDomain)
+	if err != nil {
+		return v2action.Route{}, "", warnings, err
+	}
+
+	desiredProtocol, err := actor.calculateProtocol(manifestApp, desiredDomain)
+	if err != nil {
+		return v2action.Route{}, "", warnings, err
+	}
+
+	defaultRoute := v2action.Route{
+		Domain:    desiredDomain,
+		Host:      desiredHostname,
+		SpaceGUID: spaceGUID,
+		Path:      desiredPath,
+		Protocol:  desiredProtocol,
+	}
+
+	// when the default desired domain is a TCP domain, always return a
+	// new/random route
+	if desiredDomain.IsTCP() {
+		return defaultRoute, GeneratedRouteNew, warnings, nil
+	}
+
+	if manifestApp.RandomRoute && manifestApp.Hostname == "" && !manifestApp.NoHostname {
+		randomRoute, randomWarnings, err := actor.generateRandomRoute(defaultRoute, knownRoutes)
+		warnings = append(warnings, randomWarnings...)
+		if err != nil {
+			return v2action.Route{}, "", warnings, err
+		}
+		return randomRoute, GeneratedRouteNew, warnings, nil
+	}
+
+	cachedRoute, found := actor.routeInListBySettings(defaultRoute, knownRoutes)
+	if found {
+		return cachedRoute, GeneratedRouteExistsInSpace, warnings, nil
+	}
+
+	route, routeWarnings, err := actor.V2Actor.FindRouteBoundToSpaceWithSettings(defaultRoute)
+	warnings = append(warnings, routeWarnings...)
+	switch err.(type) {
+	case nil:
+		return route, GeneratedRouteExistsInSpace, warnings, nil
+	case actionerror.RouteInDifferentSpaceError:
+		return route, GeneratedRouteExistsElsewhere, warnings, err
+	case actionerror.RouteNotFoundError:
+		partialRoute, orgWarnings := actor.flagRouteInOtherSpace(defaultRoute, orgGUID)
+		warnings = append(warnings, orgWarnings...)
+		if partialRoute.ExistsInOtherSpace {
+			return partialRoute, GeneratedRouteExistsElsewhere, warnings, nil
+		}
+		return partialRoute, GeneratedRouteNew, warnings, nil
+	default:
+		return route, "", warnings, err
+	}
+}
+
+// generateRandomRoute adds a random suffix to the route's host until it finds
+// a route that is neither known nor taken. Up to MaxRandomRouteAttempts hosts
+// are tried.
+func (actor Actor) generateRandomRoute(route v2action.Route, knownRoutes []v2action.Route) (v2action.Route, Warnings, error) {
+	var allWarnings Warnings
+	baseHost := route.Host
+
+	for attempt := 0; attempt < MaxRandomRouteAttempts; attempt++ {
+		route.Host = actor.randomHostname(baseHost)
+		if _, found := actor.routeInListBySettings(route, knownRoutes); found {
+			log.WithField("host", route.Host).Debug("random route is already known, retrying")
+			continue
+		}
+
+		_, warnings, err := actor.V2Actor.FindRouteBoundToSpaceWithSettings(route)
+		allWarnings = append(allWarnings, warnings...)
+		switch err.(type) {
+		case actionerror.RouteNotFoundError:
+			return route, allWarnings, nil
+		case nil, actionerror.RouteInDifferentSpaceError:
+			log.WithField("host", route.Host).Debug("random route is taken, retrying")
+		default:
+			return v2action.Route{}, allWarnings, err
+		}
+	}
+
+	log.Errorln("no random route available after attempts:", MaxRandomRouteAttempts)
+	return v2action.Route{}, allWarnings, actionerror.RandomRouteUnavailableError{Attempts: MaxRandomRouteAttempts}
+}
+
+var (
+	defaultWordGeneratorOnce  sync.Once
+	defaultWordGeneratorValue generator.WordGenerator
+)
+
+// defaultWordGenerator returns the WordGenerator used when the Actor does not
+// have one. Loading the word lists is slow, so it is only done once.
+func defaultWordGenerator() generator.WordGenerator {
+	defaultWordGeneratorOnce.Do(func() {
+		defaultWordGeneratorValue = generator.NewWordGenerator()
+	})
+	return defaultWordGeneratorValue
+}
+
+// randomHostname joins baseHost and a random suffix with a hyphen. baseHost is
+// shortened so that the result fits in MaxHostnameLength.
+func (actor Actor) randomHostname(baseHost string) string {
+	wordGenerator := actor.WordGenerator
+	if wordGenerator == nil {
+		wordGenerator = defaultWordGenerator()
+	}
+	suffix := actor.sanitize(wordGenerator.Babble())
+
+	if actor.MaxHostnameLength > 0 {
+		maxBaseLength := actor.MaxHostnameLength - len(suffix) - 1
+		if maxBaseLength < 0 {
+			maxBaseLength = 0
+		}
+		if len(baseHost) > maxBaseLength {
+			baseHost = strings.TrimRight(baseHost[:maxBaseLength], "-")
+		}
+	}
+
+	if baseHost == "" {
+		return suffix
+	}
+	return baseHost + "-" + suffix
+}
+
+// mapRouteToApp maps route to the application, using the route's app port when
+// it has one. When deploymentGUID is set, the route is only mapped to that
+// deployment of the application. Transient Cloud Controller failures are
+// retried up to MapRouteRetries times, waiting MapRouteRetryBackoff before the
+// first retry and twice as long before each retry after that. Each retry is
+// also spent from budget; once it is exhausted, the failure is returned. If ctx
+// is cancelled while waiting to retry, the context's error is returned. The
+// warnings from every attempt are returned.
+//
+// When a route with a path is already mapped to another application, a
+// RoutePathConflictError naming the path and, if it can be found, that
+// application is returned.
+//
+// Older Cloud Controllers can map a route to an application in another space.
+// When both the route's space and appSpaceGUID are known and differ, a
+// RouteInDifferentSpaceError is returned without asking the Cloud Controller.
+func (actor Actor) mapRouteToApp(ctx context.Context, route v2action.Route, appGUID string, appSpaceGUID string, deploymentGUID string, budget *RetryBudget) (v2action.Warnings, error) {
+	if route.SpaceGUID != "" && appSpaceGUID != "" && route.SpaceGUID != appSpaceGUID {
+		log.WithField("route", route.String()).Errorf("route is in space %s, application is in space %s", route.SpaceGUID, appSpaceGUID)
+		return nil, actionerror.RouteInDifferentSpaceError{Route: route.String()}
+	}
+
+	var allWarnings v2action.Warnings
+	backoff := actor.MapRouteRetryBackoff
+	for attempt := 0; ; attempt++ {
+		var (
+			warnings v2action.Warnings
+			err      error
+		)
+		switch {
+		case deploymentGUID != "":
+			observed := actor.observe(MapRouteToApplicationDeploymentOperation)
+			warnings, err = actor.V2Actor.MapRouteToApplicationDeployment(route.GUID, appGUID, route.AppPort, deploymentGUID)
+			observed()
+		case route.AppPort.IsSet:
+			observed := actor.observe(MapRouteToApplicationWithPortOperation)
+			warnings, err = actor.V2Actor.MapRouteToApplicationWithPort(route.GUID, appGUID, route.AppPort)
+			observed()
+		default:
+			observed := actor.observe(MapRouteToApplicationOperation)
+			warnings, err = actor.V2Actor.MapRouteToApplication(route.GUID, appGUID)
+			observed()
+		}
+		allWarnings = append(allWarnings, warnings...)
+		if _, ok := err.(actionerror.RouteInDifferentSpaceError); ok {
+			return allWarnings, actionerror.RouteInDifferentSpaceError{Route: route.String()}
+		}
+		if _, ok := err.(ccerror.RouteMappingTakenError); ok && route.Path != "" {
+			log.WithField("route", route.String()).Errorln("path is mapped to another application:", err)
+			appName, ownerWarnings := actor.routeOwnerName(route, appGUID)
+			allWarnings = append(allWarnings, ownerWarnings...)
+			return allWarnings, actionerror.RoutePathConflictError{Route: route.String(), Path: route.Path, App: appName}
+		}
+		if attempt >= actor.MapRouteRetries || !isTransientError(err) {
+			return allWarnings, err
+		}
+		if !budget.spend(backoff) {
+			log.WithField("route", route.String()).Errorln("retry budget exhausted:", err)
+			return allWarnings, err
+		}
+
+		log.WithField("route", route.String()).Warnf("retrying route mapping in %s: %s", backoff, err)
+		if err := waitForRetry(ctx, backoff); err != nil {
+			log.WithField("route", route.String()).Errorln("waiting to retry route mapping:", err)
+			return allWarnings, err
+		}
+		backoff *= 2
+	}
+}
+
+// routeOwnerName returns the name of an application, other than the one with
+// appGUID, that the route is mapped to. When none can be found, the name is
+// empty; failing to look the applications up is only logged.
+func (actor Actor) routeOwnerName(route v2action.Route, appGUID string) (string, v2action.Warnings) {
+	apps, warnings, err := actor.V2Actor.GetRouteApplications(route.GUID)
+	if err != nil {
+		log.WithField("route", route.String()).Errorln("looking up route applications:", err)
+		return "", warnings
+	}
+
+	for _, app := range apps {
+		if app.GUID != appGUID {
+			return app.Name, warnings
+		}
+	}
+	return "", warnings
+}
+
+// rollBackRouteMappings unmaps mappedRoutes from the application, most
+// recently mapped first, and then maps unmappedRoutes to it again, checking
+// that they are in appSpaceGUID as MapRoutes does. Rolling back continues past
+// failures; each failure results in a warning, since the error that caused the
+// rollback is the one returned to the caller.
+func (actor Actor) rollBackRouteMappings(appGUID string, appSpaceGUID string, mappedRoutes []v2action.Route, unmappedRoutes []v2action.Route) RouteWarnings {
+	log.Info("rolling back route mappings")
+
+	var allWarnings RouteWarnings
+	for i := len(mappedRoutes) - 1; i >= 0; i-- {
+		route := mappedRoutes[i]
+		warnings, err := actor.V2Actor.UnmapRouteFromApplication(route.GUID, appGUID)
+		allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, warnings...)...)
+		if err != nil {
+			log.WithField("route", route.String()).Errorln("rolling back route mapping:", err)
+			allWarnings = append(allWarnings, RouteWarning{
+				Message:  fmt.Sprintf("Unable to unmap route %s while rolling back: %s", route, err),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+
+	for _, route := range unmappedRoutes {
+		warnings, err := actor.mapRouteToApp(context.Background(), route, appGUID, appSpaceGUID, "", nil)
+		allWarnings = append(allWarnings, NewRouteWarnings(SeverityWarning, warnings...)...)
+		if err != nil {
+			log.WithField("route", route.String()).Errorln("rolling back route mapping:", err)
+			allWarnings = append(allWarnings, RouteWarning{
+				Message:  fmt.Sprintf("Unable to map route %s again while rolling back: %s", route, err),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return allWarnings
+}
+
+// waitForRetry waits for backoff to pass, returning early with the context's
+// error if ctx is cancelled first.
+func waitForRetry(ctx context.Context, backoff time.Duration) error {
+	if backoff <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransientError returns true if err is a Cloud Controller server error
+// that may succeed when the request is retried.
+func isTransientError(err error) bool {
+	switch e := err.(type) {
+	case ccerror.ServiceUnavailableError:
+		return true
+	case ccerror.V2UnexpectedResponseError:
+		return e.ResponseCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// calculateDomain returns the domain named by the manifest, or the
+// organization's default domain when the manifest does not name one. A
+// NoDomainsFoundError is returned when the organization has no domains at all
+// and a DomainNotFoundError when the named domain does not exist; the error
+// suggests a close match when there is one, see withDomainSuggestion. When more
+// than one domain has the named domain's name, the one preferred by
+// DomainPreference is returned. When the manifest gives the domain's GUID, the
+// domain is not looked up by name; see domainWithGUID. An application without
+// a domain of its own uses the manifest's default domain, when there is one,
+// instead of the organization's; see manifestDefaultDomain. A domain named
+// InternalDomainName is always returned as an internal domain.
+func (actor Actor) calculateDomain(manifestApp manifest.Application, orgGUID string) (v2action.Domain, Warnings, error) {
+	if manifestApp.DomainGUID != "" {
+		return actor.domainWithGUID(manifestApp)
+	}
+
+	switch {
+	case manifestApp.Domain != "":
+		domain, warnings, err := actor.domainByName(manifestApp.Domain, orgGUID)
+		return domain, warnings, actor.withDomainSuggestion(err, orgGUID)
+	case manifestApp.DefaultDomain != "":
+		domain, warnings, err := actor.manifestDefaultDomain(manifestApp.DefaultDomain, orgGUID)
+		return domain, warnings, actor.withDomainSuggestion(err, orgGUID)
+	}
+
+	desiredDomain, warnings, err := actor.DefaultDomain(orgGUID)
+	if err != nil {
+		log.Errorln("could not find default domains:", err.Error())
+		return v2action.Domain{}, warnings, err
+	}
+	return desiredDomain, warnings, nil
+}
+
+// domainByName looks up the domain with the provided name in the
+// organization, returning the one preferred by DomainPreference when several
+// share the name. A name that was not found earlier in the same push is not
+// looked up again; see ForPush.
+func (actor Actor) domainByName(name string, orgGUID string) (v2action.Domain, Warnings, error) {
+	if actor.missingDomains.contains(orgGUID, name) {
+		log.Errorf("provided domain '%s' is known not to exist", name)
+		return v2action.Domain{}, nil, actionerror.DomainNotFoundError{Name: name}
+	}
+
+	observed := actor.observe(GetDomainsByNameAndOrganizationOperation)
+	desiredDomains, warnings, err := actor.V2Actor.GetDomainsByNameAndOrganization([]string{name}, orgGUID)
+	observed()
+	if err != nil {
+		log.Errorf("could not find provided domain '%s': %s", name, err)
+		return v2action.Domain{}, Warnings(warnings), err
+	}
+	if len(desiredDomains) == 0 {
+		log.Errorf("provided domain '%s' does not exist", name)
+		actor.missingDomains.add(orgGUID, name)
+		return v2action.Domain{}, Warnings(warnings), actionerror.DomainNotFoundError{Name: name}
+	}
+	return actor.markInternalDomain(actor.preferredDomain(desiredDomains)), Warnings(warnings), nil
+}
+
+// manifestDefaultDomain returns the manifest's default domain. The domain is
+// stored in the DomainCache, so that when several applications in the
+// manifest use it, it is only looked up, and its warnings returned, once.
+func (actor Actor) manifestDefaultDomain(name string, orgGUID string) (v2action.Domain, Warnings, error) {
+	if actor.DomainCache != nil {
+		domain, exists, cached := actor.DomainCache.Lookup(orgGUID, name)
+		actor.countDomainCacheLookup(cached)
+		switch {
+		case cached && exists:
+			log.WithField("domain", domain.Name).Debug("using manifest default domain from cache")
+			return domain, nil, nil
+		case cached:
+			return v2action.Domain{}, nil, actionerror.DomainNotFoundError{Name: name}
+		}
+	}
+
+	domain, warnings, err := actor.domainByName(name, orgGUID)
+	if actor.DomainCache != nil {
+		switch err.(type) {
+		case nil:
+			actor.DomainCache.Store(orgGUID, []string{name}, []v2action.Domain{domain})
+		case actionerror.DomainNotFoundError:
+			actor.DomainCache.Store(orgGUID, []string{name}, nil)
+		}
+	}
+	return domain, warnings, err
+}
+
+// domainWithGUID returns the domain with the manifest's domain GUID. When the
+// manifest also names the domain and none of its route settings depend on the
+// domain's type, the domain is built without a request, as an HTTP domain, and
+// the GUID is only validated once the route is looked up or created. Otherwise
+// the domain is fetched by its GUID, returning a DomainNotFoundError when it
+// does not exist.
+func (actor Actor) domainWithGUID(manifestApp manifest.Application) (v2action.Domain, Warnings, error) {
+	if manifestApp.Domain != "" && !domainTypeMatters(manifestApp) {
+		log.WithField("GUID", manifestApp.DomainGUID).Debug("using domain GUID from manifest")
+		return actor.markInternalDomain(v2action.Domain{GUID: manifestApp.DomainGUID, Name: manifestApp.Domain}), nil, nil
+	}
+
+	domain, warnings, err := actor.V2Actor.GetDomain(manifestApp.DomainGUID)
+	if err != nil {
+		log.Errorf("could not find provided domain GUID '%s': %s", manifestApp.DomainGUID, err)
+		return v2action.Domain{}, Warnings(warnings), err
+	}
+	return actor.markInternalDomain(domain), Warnings(warnings), nil
+}
+
+// domainTypeMatters returns true when the manifest's route settings are only
+// valid for some types of domains; see calculateHostname, calculatePath and
+// calculateProtocol.
+func domainTypeMatters(manifestApp manifest.Application) bool {
+	return manifestApp.Hostname != "" ||
+		manifestApp.NoHostname ||
+		manifestApp.RoutePath != "" ||
+		manifestApp.RouteProtocol != ""
+}
+
+// wildcardHost is the host of a route that matches every host on its domain.
+const wildcardHost = "*"
+
+// calculateHostname returns the host of the application's generated route. The
+// manifest's hostname, or the application's name when there is none, is
+// sanitized by GenerateHostname unless it is the wildcard host, which is only
+// allowed on domains that allow wildcard hosts.
+func (actor Actor) calculateHostname(manifestApp manifest.Application, domain v2action.Domain) (string, error) {
+	switch {
+	case manifestApp.Hostname == wildcardHost && !domain.AllowsWildcardHosts():
+		return "", actionerror.WildcardHostNotAllowedError{Domain: domain.Name}
+	case manifestApp.Hostname == wildcardHost:
+		return wildcardHost, nil
+	case manifestApp.Hostname != "" && domain.IsTCP():
+		return "", actionerror.HostnameWithTCPDomainError{}
+	case manifestApp.NoHostname && domain.IsShared() && domain.IsHTTP():
+		return "", actionerror.NoHostnameAndSharedDomainError{}
+	case manifestApp.NoHostname:
+		return "", nil
+	case domain.IsHTTP():
+		return GenerateHostname(manifestApp.Name, HostnameOptions{
+			Hostname:          manifestApp.Hostname,
+			MaxLength:         actor.MaxHostnameLength,
+			AllowedCharacters: actor.AllowedHostnameCharacters,
+		})
+	default:
+		return "", nil
+	}
+}
+
+// calculateProtocol returns the manifest's route protocol when the domain
+// supports it. HTTP domains support http1 and http2 and TCP domains support
+// tcp.
+func (Actor) calculateProtocol(manifestApp manifest.Application, domain v2action.Domain) (constant.RouteProtocol, error) {
+	protocol := constant.RouteProtocol(strings.ToLower(manifestApp.RouteProtocol))
+
+	switch {
+	case protocol == "":
+		return "", nil
+	case domain.IsHTTP() && (protocol == constant.HTTP1RouteProtocol || protocol == constant.HTTP2RouteProtocol),
+		domain.IsTCP() && protocol == constant.TCPRouteProtocol:
+		return protocol, nil
+	default:
+		return "", actionerror.RouteProtocolNotSupportedByDomainError{Protocol: manifestApp.RouteProtocol, Domain: domain.Name}
+	}
+}
+
+// hostAndDomain returns the parsed route's host labels and domain. When the
+// route names its domain, that domain is used; otherwise the domain is found
+// by calculateRoute.
+func (actor Actor) hostAndDomain(parsed parsedRoute, domainCache map[string]v2action.Domain) ([]string, v2action.Domain, error) {
+	if parsed.domain == "" {
+		return actor.calculateRoute(parsed.hostname, domainCache)
+	}
+
+	domain, ok := domainCache[strings.ToLower(parsed.domain)]
+	if !ok {
+		return nil, v2action.Domain{}, actionerror.DomainNotFoundError{Name: parsed.domain}
+	}
+	if parsed.host == "" {
+		return nil, domain, nil
+	}
+	return []string{parsed.host}, domain, nil
+}
+
+// calculateRoute splits the route into its host labels and the domain it is
+// on. Labels are moved from the left of the route to the host one at a time,
+// so when the route matches more than one domain, such as example.com and
+// sub.example.com for host.sub.example.com, the longest, most specific, domain
+// is used. The host of a route on a parent domain keeps the labels of any
+// delegated subdomain that is not itself a domain, such as host.sub on
+// example.com.
+func (actor Actor) calculateRoute(route string, domainCache map[string]v2action.Domain) ([]string, v2action.Domain, error) {
+	var hosts []string
+	for {
+		if domain, ok := domainCache[strings.ToLower(route)]; ok {
+			return hosts, domain, nil
+		}
+
+		host, domain := actor.splitHost(route)
+		if host == "" {
+			return nil, v2action.Domain{}, actionerror.DomainNotFoundError{Name: route}
+		}
+
+		hosts = append(hosts, host)
+		route = domain
+	}
+}
+
+func (actor Actor) calculatePath(manifestApp manifest.Application, domain v2action.Domain) (string, error) {
+	switch {
+	case manifestApp.RoutePath != "" && domain.IsTCP():
+		return "", actionerror.RoutePathWithTCPDomainError{}
+	case manifestApp.RoutePath != "" && domain.IsInternal():
+		return "", actionerror.InvalidInternalRouteSettings{Domain: domain.Name}
+	default:
+		return manifestApp.RoutePath, nil
+	}
+}
+
+// applyRouteAppPorts sets the app port of each route that has one in
+// appPorts, which is keyed by the routes as they are written in the manifest.
+// When more than one key names a route, the first key in sorted order is used.
+func (actor Actor) applyRouteAppPorts(routes []v2action.Route, appPorts map[string]int) []v2action.Route {
+	return actor.forNamedRoutes(routes, sortedManifestRoutes(appPorts), func(route *v2action.Route, manifestRoute string) {
+		route.AppPort = types.NullInt{IsSet: true, Value: appPorts[manifestRoute]}
+	})
+}
+
+// applyPrimaryRoutes marks each route in primaryRoutes, which are the routes
+// as they are written in the manifest, as Primary.
+func (actor Actor) applyPrimaryRoutes(routes []v2action.Route, primaryRoutes []string) []v2action.Route {
+	return actor.forNamedRoutes(routes, primaryRoutes, func(route *v2action.Route, _ string) {
+		route.Primary = true
+	})
+}
+
+// applyEphemeralRoutes marks each route in ephemeralRoutes, which are the
+// routes as they are written in the manifest, as Ephemeral.
+func (actor Actor) applyEphemeralRoutes(routes []v2action.Route, ephemeralRoutes []string) []v2action.Route {
+	return actor.forNamedRoutes(routes, ephemeralRoutes, func(route *v2action.Route, _ string) {
+		route.Ephemeral = true
+	})
+}
+
+// checkPrimaryRoutes returns a MultiplePrimaryRoutesError when more than one
+// of the DesiredRoutes is marked as Primary.
+func (actor Actor) checkPrimaryRoutes(config ApplicationConfig) error {
+	var primaryRoutes []string
+	for _, route := range config.DesiredRoutes {
+		if route.Primary {
+			primaryRoutes = append(primaryRoutes, route.String())
+		}
+	}
+
+	if len(primaryRoutes) > 1 {
+		return actionerror.MultiplePrimaryRoutesError{
+			AppName: config.DesiredApplication.Name,
+			Routes:  primaryRoutes,
+		}
+	}
+	return nil
+}
+
+// primaryRoutesFirst returns routes with the Primary routes moved to the
+// front. The order of the routes is otherwise unchanged.
+func (Actor) primaryRoutesFirst(routes []v2action.Route) []v2action.Route {
+	if len(routes) == 0 {
+		return routes
+	}
+
+	sortedRoutes := make([]v2action.Route, len(routes))
+	copy(sortedRoutes, routes)
+	sort.SliceStable(sortedRoutes, func(i, j int) bool {
+		return sortedRoutes[i].Primary && !sortedRoutes[j].Primary
+	})
+	return sortedRoutes
+}
+
+// applyRouteSessionAffinity sets the session affinity of each route that has
+// one in affinities, which is keyed by the routes as they are written in the
+// manifest. When more than one key names a route, the first key in sorted
+// order is used.
+func (actor Actor) applyRouteSessionAffinity(routes []v2action.Route, affinities map[string]bool) []v2action.Route {
+	return actor.forNamedRoutes(routes, sortedManifestRoutes(affinities), func(route *v2action.Route, manifestRoute string) {
+		route.SessionAffinity = types.NullBool{IsSet: true, Value: affinities[manifestRoute]}
+	})
+}
+
+// applyRouteOptions sets the options of each route that has them in options,
+// which is keyed by the routes as they are written in the manifest. When more
+// than one key names a route, the first key in sorted order is used. It
+// returns an UnknownRouteOptionError or InvalidRouteOptionValueError for the
+// first route whose options are not valid.
+func (actor Actor) applyRouteOptions(routes []v2action.Route, options map[string]map[string]string) ([]v2action.Route, error) {
+	if len(options) == 0 {
+		return routes, nil
+	}
+
+	optionRoutes := actor.forNamedRoutes(routes, sortedManifestRoutes(options), func(route *v2action.Route, manifestRoute string) {
+		route.Options = options[manifestRoute]
+	})
+	for _, route := range optionRoutes {
+		if err := route.ValidateOptions(); err != nil {
+			return nil, err
+		}
+	}
+
+	return optionRoutes, nil
+}
+
+// applyRouteMetadata sets the labels and annotations of each route that has
+// them in metadata, which is keyed by the routes as they are written in the
+// manifest. When more than one key names a route, the first key in sorted
+// order is used.
+func (actor Actor) applyRouteMetadata(routes []v2action.Route, metadata map[string]manifest.Metadata) []v2action.Route {
+	return actor.forNamedRoutes(routes, sortedManifestRoutes(metadata), func(route *v2action.Route, manifestRoute string) {
+		route.Metadata = v2action.Metadata(metadata[manifestRoute])
+	})
+}
+
+// forNamedRoutes returns a copy of routes in which set has been called on
+// each route that one of manifestRoutes, the routes as they are written in the
+// manifest, names. set is passed the first of manifestRoutes that names the
+// route. When there are no manifestRoutes, routes is returned as it is.
+func (actor Actor) forNamedRoutes(routes []v2action.Route, manifestRoutes []string, set func(*v2action.Route, string)) []v2action.Route {
+	if len(manifestRoutes) == 0 {
+		return routes
+	}
+
+	names := actor.routeNames(manifestRoutes)
+	namedRoutes := make([]v2action.Route, 0, len(routes))
+	for _, route := range routes {
+		for i, manifestRoute := range manifestRoutes {
+			if routeHasName(route, names[i]) {
+				set(&route, manifestRoute)
+				break
+			}
+		}
+		namedRoutes = append(namedRoutes, route)
+	}
+
+	return namedRoutes
+}
+
+// sortedManifestRoutes returns the keys of manifestRouteSettings, a map keyed
+// by the routes as they are written in the manifest, in sorted order.
+func sortedManifestRoutes(manifestRouteSettings interface{}) []string {
+	keys := reflect.ValueOf(manifestRouteSettings).MapKeys()
+	manifestRoutes := make([]string, 0, len(keys))
+	for _, key := range keys {
+		manifestRoutes = append(manifestRoutes, key.String())
+	}
+	sort.Strings(manifestRoutes)
+	return manifestRoutes
+}
+
+// expandPortRanges replaces each route that has a port range with one route
+// for every port in the range. All other routes are returned unchanged. Routes
+// with an invalid port range are returned separately.
+func (Actor) expandPortRanges(routes []string) ([]string, []actionerror.InvalidRoute) {
+	var (
+		expandedRoutes []string
+		invalidRoutes  []actionerror.InvalidRoute
+	)
+	for _, route := range routes {
+		matches := portRangeRegexp.FindStringSubmatch(route)
+		if matches == nil {
+			expandedRoutes = append(expandedRoutes, route)
+			continue
+		}
+
+		root, path := matches[1], matches[4]
+		firstPort, firstErr := strconv.Atoi(matches[2])
+		lastPort, lastErr := strconv.Atoi(matches[3])
+
+		var err error
+		switch {
+		case firstErr != nil || lastErr != nil:
+			err = actionerror.RoutePortRangeTooLargeError{Route: route, MaxPorts: MaxRoutePortRangeSize}
+		case firstPort > lastPort:
+			err = actionerror.InvertedRoutePortRangeError{Route: route}
+		case lastPort-firstPort+1 > MaxRoutePortRangeSize:
+			err = actionerror.RoutePortRangeTooLargeError{Route: route, MaxPorts: MaxRoutePortRangeSize}
+		}
+		if err != nil {
+			log.Errorln("port range:", err)
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{Route: route, Err: err})
+			continue
 		}
 
-		calculatedRoute, routeWarnings, routeErr := actor.findOrReturnPartialRouteWithSettings(potentialRoute)
-		allWarnings = append(allWarnings, routeWarnings...)
-		if routeErr != nil {
-			log.Errorln("route lookup:", routeErr)
-			return nil, allWarnings, routeErr
+		log.WithField("route", route).Debugf("expanding %d ports", lastPort-firstPort+1)
+		for port := firstPort; port <= lastPort; port++ {
+			expandedRoutes = append(expandedRoutes, fmt.Sprintf("%s:%d%s", root, port, path))
 		}
-
-		calculatedRoutes = append(calculatedRoutes, calculatedRoute)
 	}
 
-	return calculatedRoutes, allWarnings, nil
+	return expandedRoutes, invalidRoutes
 }
 
-func (actor Actor) CreateAndMapDefaultApplicationRoute(orgGUID string, spaceGUID string, app v2action.Application) (Warnings, error) {
-	var warnings Warnings
-	defaultRoute, domainWarnings, err := actor.getDefaultRoute(orgGUID, spaceGUID, app.Name)
-	warnings = append(warnings, domainWarnings...)
+// validateRoutes splits the provided routes into those in existingRoutes and
+// those that are not. The latter are parsed and validated, and the (partial)
+// route each one describes is returned. All of the routes are checked before
+// returning, so that every invalid route is reported at once. The wildcard
+// host is only allowed on domains that allow it. A route on an HTTP domain
+// must not specify a port and, when randomTCPPorts is not set, a TCP route
+// must specify one. The domains of routes that match no domain are looked up
+// a second time, bypassing the DomainCache, before the routes are rejected.
+func (actor Actor) validateRoutes(ctx context.Context, routes []string, orgGUID string, spaceGUID string, existingRoutes []v2action.Route, randomTCPPorts bool) ([]v2action.Route, []v2action.Route, Warnings, error) {
+	routes, invalidRoutes := actor.expandPortRanges(routes)
+	knownRoutes, unknownRoutes := actor.splitExistingRoutes(actor.parseRoutes(routes), existingRoutes)
+
+	potentialRoutes, warnings, err := actor.validateParsedRoutes(ctx, unknownRoutes, invalidRoutes, orgGUID, spaceGUID, randomTCPPorts)
 	if err != nil {
-		return warnings, err
+		return nil, nil, warnings, err
 	}
+	return knownRoutes, potentialRoutes, warnings, nil
+}
 
-	boundRoutes, appRouteWarnings, err := actor.V2Actor.GetApplicationRoutes(app.GUID)
-	warnings = append(warnings, appRouteWarnings...)
-	if err != nil {
-		return warnings, err
+// validateParsedRoutes validates the parsed routes as validateRoutes
+// describes and returns the (partial) route each one describes. The routes in
+// invalidRoutes, which were rejected before they were parsed, are reported
+// along with any of the parsed routes that are invalid.
+func (actor Actor) validateParsedRoutes(ctx context.Context, unparsedRoutes []parsedRoute, invalidRoutes []actionerror.InvalidRoute, orgGUID string, spaceGUID string, randomTCPPorts bool) ([]v2action.Route, Warnings, error) {
+	var parsedRoutes []parsedRoute
+	for _, parsed := range unparsedRoutes {
+		if parsed.err != nil {
+			log.Errorln("parse route:", parsed.err)
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{Route: parsed.route, Err: parsed.err})
+			continue
+		}
+		if actor.MaxRouteLabels > 0 && strings.Count(parsed.hostname, ".") >= actor.MaxRouteLabels {
+			log.Errorln("parse route: too many labels in", parsed.route)
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{
+				Route: parsed.route,
+				Err:   actionerror.RouteTooManyLabelsError{Route: parsed.route, MaxLabels: actor.MaxRouteLabels},
+			})
+			continue
+		}
+		parsedRoutes = append(parsedRoutes, parsed)
 	}
 
-	_, routeAlreadyBound := actor.routeInListBySettings(defaultRoute, boundRoutes)
-	if routeAlreadyBound {
-		return warnings, err
+	if err := ctx.Err(); err != nil {
+		log.Errorln("domain lookup:", err)
+		return nil, nil, err
 	}
 
-	spaceRoute, spaceRouteWarnings, err := actor.V2Actor.FindRouteBoundToSpaceWithSettings(defaultRoute)
-	warnings = append(warnings, spaceRouteWarnings...)
-	routeAlreadyExists := true
-	if _, ok := err.(actionerror.RouteNotFoundError); ok {
-		routeAlreadyExists = false
-	} else if err != nil {
-		return warnings, err
+	nameToFoundDomain, warnings, err := actor.getDomainsByName(actor.generatePossibleDomains(parsedRoutes), orgGUID)
+	if err != nil {
+		log.Errorln("domain lookup:", err)
+		return nil, warnings, err
 	}
 
-	if !routeAlreadyExists {
-		var createRouteWarning v2action.Warnings
-		spaceRoute, createRouteWarning, err = actor.V2Actor.CreateRoute(defaultRoute, false)
-		warnings = append(warnings, createRouteWarning...)
-		if err != nil {
-			return warnings, err
+	// A domain can be shared into the organization after it was looked up,
+	// or cached as missing, so the domains of routes without one are looked
+	// up once more before the routes are reported as invalid.
+	if unmatchedRoutes := actor.routesWithoutDomain(parsedRoutes, nameToFoundDomain); len(unmatchedRoutes) > 0 {
+		log.Debug("retrying domain lookup for routes without a matching domain")
+		retriedDomains, retryWarnings, retryErr := actor.lookupDomainsByName(actor.generatePossibleDomains(unmatchedRoutes), orgGUID)
+		warnings = append(warnings, retryWarnings...)
+		if retryErr != nil {
+			log.Errorln("domain lookup:", retryErr)
+			return nil, warnings, retryErr
+		}
+		for name, domain := range retriedDomains {
+			nameToFoundDomain[name] = domain
 		}
 	}
 
-	mapWarnings, err := actor.V2Actor.MapRouteToApplication(spaceRoute.GUID, app.GUID)
-	warnings = append(warnings, mapWarnings...)
-	return warnings, err
-}
-
-func (actor Actor) CreateRoutes(config ApplicationConfig) (ApplicationConfig, bool, Warnings, error) {
-	log.Info("creating routes")
+	var potentialRoutes []v2action.Route
+	for _, parsed := range parsedRoutes {
+		log.WithField("route", parsed.route).Debug("generating route")
 
-	var routes []v2action.Route
-	var createdRoutes bool
-	var allWarnings Warnings
+		host, domain, domainErr := actor.hostAndDomain(parsed, nameToFoundDomain)
+		if _, ok := domainErr.(actionerror.DomainNotFoundError); ok {
+			log.Error("no matching domains")
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{
+				Route: parsed.route,
+				Err:   actionerror.NoMatchingDomainError{Route: parsed.route},
+			})
+			continue
+		} else if domainErr != nil {
+			log.Errorln("matching domains:", domainErr)
+			return nil, warnings, domainErr
+		}
 
-	for _, route := range config.DesiredRoutes {
-		if route.GUID == "" {
-			log.WithField("route", route).Debug("creating route")
+		if strings.Join(host, ".") == wildcardHost && !domain.AllowsWildcardHosts() {
+			log.Errorln("validate route: wildcard host not allowed", parsed.route)
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{
+				Route: parsed.route,
+				Err:   actionerror.WildcardHostNotAllowedError{Domain: domain.Name},
+			})
+			continue
+		}
 
-			createdRoute, warnings, err := actor.V2Actor.CreateRoute(route, route.RandomTCPPort())
-			allWarnings = append(allWarnings, warnings...)
-			if err != nil {
-				log.Errorln("creating route:", err)
-				return ApplicationConfig{}, true, allWarnings, err
-			}
-			routes = append(routes, createdRoute)
+		if len(host) == 0 && domain.IsShared() && domain.IsHTTP() {
+			log.Errorln("validate route: no hostname on shared domain", parsed.route)
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{
+				Route: parsed.route,
+				Err:   actionerror.NoHostnameAndSharedDomainError{},
+			})
+			continue
+		}
 
-			createdRoutes = true
-		} else {
-			log.WithField("route", route).Debug("already exists, skipping")
-			routes = append(routes, route)
+		if domain.IsHTTP() && !domain.IsInternal() && parsed.port.IsSet {
+			log.Errorln("validate route: port for HTTP route", parsed.route)
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{
+				Route: parsed.route,
+				Err:   actionerror.PortWithHTTPDomainError{Route: parsed.route, Domain: domain.Name},
+			})
+			continue
 		}
-	}
-	config.DesiredRoutes = routes
 
-	return config, createdRoutes, allWarnings, nil
-}
+		potentialRoute := v2action.Route{
+			Host:      strings.Join(host, "."),
+			Domain:    domain,
+			Path:      parsed.path,
+			Port:      parsed.port,
+			Protocol:  parsed.protocol,
+			SpaceGUID: spaceGUID,
+		}
 
-// GetGeneratedRoute returns a route with the host and the default org domain.
-// This may be a partial route (ie no GUID) if the route does not exist.
-func (actor Actor) GetGeneratedRoute(manifestApp manifest.Application, orgGUID string, spaceGUID string, knownRoutes []v2action.Route) (v2action.Route, Warnings, error) {
-	desiredDomain, warnings, err := actor.calculateDomain(manifestApp, orgGUID)
-	if err != nil {
-		return v2action.Route{}, warnings, err
-	}
+		if !actor.SkipRouteValidation {
+			if validationErr := potentialRoute.Validate(); validationErr != nil {
+				log.Errorln("validate route:", validationErr)
+				invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{Route: parsed.route, Err: validationErr})
+				continue
+			}
+		}
 
-	desiredHostname, err := actor.calculateHostname(manifestApp, desiredDomain)
-	if err != nil {
-		return v2action.Route{}, warnings, err
-	}
+		if domain.IsTCP() && !potentialRoute.Port.IsSet && !randomTCPPorts {
+			log.Errorln("validate route: no port for TCP route", parsed.route)
+			invalidRoutes = append(invalidRoutes, actionerror.InvalidRoute{
+				Route: parsed.route,
+				Err:   actionerror.TCPRouteMissingPortError{Route: parsed.route},
+			})
+			continue
+		}
 
-	desiredPath, err := actor.calculatePath(manifestApp, desiredDomain)
-	if err != nil {
-		return v2action.Route{}, warnings, err
+		potentialRoutes = append(potentialRoutes, potentialRoute)
 	}
 
-	defaultRoute := v2action.Route{
-		Domain:    desiredDomain,
-		Host:      desiredHostname,
-		SpaceGUID: spaceGUID,
-		Path:      desiredPath,
+	switch len(invalidRoutes) {
+	case 0:
+		return potentialRoutes, warnings, nil
+	case 1:
+		return nil, warnings, invalidRoutes[0].Err
+	default:
+		return nil, warnings, actionerror.InvalidRoutesError{Routes: invalidRoutes}
 	}
+}
 
-	// when the default desired domain is a TCP domain, always return a
-	// new/random route
-	if desiredDomain.IsTCP() {
-		return defaultRoute, warnings, nil
+// findOrReturnPartialRouteWithSettings returns the existing route with the
+// route's settings, or the route itself when it does not exist yet; see
+// flagRouteInOtherSpace. An existing route is returned as the Cloud Controller
+// describes it, GUID and timestamps included, along with the route's push
+// settings; see withPushSettings. When the route is in use outside of the
+// route's space, a RouteOwnedByOtherOrganizationError naming the owning
+// organization, if it can be found, is returned.
+func (actor Actor) findOrReturnPartialRouteWithSettings(route v2action.Route, orgGUID string) (v2action.Route, Warnings, error) {
+	cachedRoute, warnings, err := actor.V2Actor.FindRouteBoundToSpaceWithSettings(route)
+	if err == nil {
+		cachedRoute = withPushSettings(cachedRoute, route)
 	}
-
-	cachedRoute, found := actor.routeInListBySettings(defaultRoute, knownRoutes)
-	if !found {
-		route, routeWarnings, err := actor.V2Actor.FindRouteBoundToSpaceWithSettings(defaultRoute)
-		if _, ok := err.(actionerror.RouteNotFoundError); ok {
-			return defaultRoute, append(warnings, routeWarnings...), nil
+	switch err.(type) {
+	case actionerror.RouteNotFoundError:
+		partialRoute, orgWarnings := actor.flagRouteInOtherSpace(route, orgGUID)
+		return partialRoute, append(Warnings(warnings), orgWarnings...), nil
+	case actionerror.RouteInDifferentSpaceError:
+		orgName, orgWarnings := actor.owningOrganizationName(route.Domain, orgGUID)
+		return v2action.Route{}, append(Warnings(warnings), orgWarnings...), actionerror.RouteOwnedByOtherOrganizationError{
+			Route:        route.String(),
+			Organization: orgName,
 		}
-		return route, append(warnings, routeWarnings...), err
 	}
-	return cachedRoute, warnings, nil
+	return cachedRoute, Warnings(warnings), err
 }
 
-func (actor Actor) mapRouteToApp(route v2action.Route, appGUID string) (v2action.Warnings, error) {
-	warnings, err := actor.V2Actor.MapRouteToApplication(route.GUID, appGUID)
-	if _, ok := err.(actionerror.RouteInDifferentSpaceError); ok {
-		return warnings, actionerror.RouteInDifferentSpaceError{Route: route.String()}
+// withPushSettings returns the existing route with the settings of the desired
+// route that the Cloud Controller does not return for a route: its app port,
+// metadata, session affinity, options, whether it is primary or ephemeral and
+// the protocol it was asked to have. Everything else, such as the GUID,
+// timestamps and the protocol it has, comes from the existing route.
+func withPushSettings(existingRoute v2action.Route, desiredRoute v2action.Route) v2action.Route {
+	existingRoute.RequestedProtocol = desiredRoute.RequestedProtocol
+	if existingRoute.RequestedProtocol == "" {
+		existingRoute.RequestedProtocol = desiredRoute.Protocol
 	}
-	return warnings, err
+	existingRoute.AppPort = desiredRoute.AppPort
+	existingRoute.Metadata = desiredRoute.Metadata
+	existingRoute.SessionAffinity = desiredRoute.SessionAffinity
+	existingRoute.Options = desiredRoute.Options
+	existingRoute.Primary = desiredRoute.Primary
+	existingRoute.Ephemeral = desiredRoute.Ephemeral
+	return existingRoute
 }
 
-func (actor Actor) calculateDomain(manifestApp manifest.Application, orgGUID string) (v2action.Domain, Warnings, error) {
-	var (
-		desiredDomain v2action.Domain
-		warnings      Warnings
-		err           error
-	)
-
-	if manifestApp.Domain == "" {
-		desiredDomain, warnings, err = actor.DefaultDomain(orgGUID)
+// checkRoutesInOtherSpaces returns a RouteInDifferentSpaceError for the first
+// route that has the same host, domain, path and port as a route in another
+// space of the organization. Unlike flagRouteInOtherSpace, failing to look the
+// routes up is an error.
+func (actor Actor) checkRoutesInOtherSpaces(routes []v2action.Route, orgGUID string) (Warnings, error) {
+	var allWarnings Warnings
+	for _, route := range routes {
+		orgRoutes, warnings, err := actor.V2Actor.GetOrganizationRoutesWithHostAndDomain(route, orgGUID)
+		allWarnings = append(allWarnings, warnings...)
 		if err != nil {
-			log.Errorln("could not find default domains:", err.Error())
-			return v2action.Domain{}, warnings, err
-		}
-	} else {
-		desiredDomains, getDomainWarnings, getDomainsErr := actor.V2Actor.GetDomainsByNameAndOrganization([]string{manifestApp.Domain}, orgGUID)
-		warnings = append(warnings, getDomainWarnings...)
-		if getDomainsErr != nil {
-			log.Errorln("could not find provided domains '%s':", manifestApp.Domain, getDomainsErr.Error())
-			return v2action.Domain{}, warnings, getDomainsErr
+			return allWarnings, err
 		}
-		if len(desiredDomains) == 0 {
-			log.Errorln("could not find provided domains '%s':", manifestApp.Domain)
-			return v2action.Domain{}, warnings, actionerror.DomainNotFoundError{Name: manifestApp.Domain}
+
+		path := strings.TrimSuffix(route.Path, "/")
+		for _, orgRoute := range orgRoutes {
+			if orgRoute.SpaceGUID != route.SpaceGUID && sameRoutePort(route.Domain, orgRoute.Port, route.Port) &&
+				strings.EqualFold(strings.TrimSuffix(orgRoute.Path, "/"), path) {
+				return allWarnings, actionerror.RouteInDifferentSpaceError{Route: route.String()}
+			}
 		}
-		// CC does not allow one to have shared/owned domains with the same domain name. so it's ok to take the first one
-		desiredDomain = desiredDomains[0]
 	}
 
-	return desiredDomain, warnings, nil
+	return allWarnings, nil
 }
 
-func (actor Actor) calculateHostname(manifestApp manifest.Application, domain v2action.Domain) (string, error) {
-	hostname := manifestApp.Hostname
-	if hostname == "" {
-		hostname = manifestApp.Name
+// flagRouteInOtherSpace sets ExistsInOtherSpace on a route that does not
+// exist yet when a route with the same host and domain exists in another space
+// of the organization. Failing to look the routes up is not an error; the
+// route is returned unflagged instead.
+func (actor Actor) flagRouteInOtherSpace(route v2action.Route, orgGUID string) (v2action.Route, Warnings) {
+	orgRoutes, warnings, err := actor.V2Actor.GetOrganizationRoutesWithHostAndDomain(route, orgGUID)
+	if err != nil {
+		log.Errorln("getting organization routes:", err)
+		return route, Warnings(warnings)
 	}
 
-	sanitizedHostname := actor.sanitize(hostname)
-
-	switch {
-	case manifestApp.Hostname != "" && domain.IsTCP():
-		return "", actionerror.HostnameWithTCPDomainError{}
-	case manifestApp.NoHostname && domain.IsShared() && domain.IsHTTP():
-		return "", actionerror.NoHostnameAndSharedDomainError{}
-	case manifestApp.NoHostname:
-		return "", nil
-	case domain.IsHTTP():
-		return sanitizedHostname, nil
-	default:
-		return "", nil
+	for _, orgRoute := range orgRoutes {
+		if orgRoute.SpaceGUID != route.SpaceGUID {
+			log.WithField("route", route.String()).Warn("route with the same host and domain exists in another space")
+			route.ExistsInOtherSpace = true
+			break
+		}
 	}
+	return route, Warnings(warnings)
 }
 
-func (actor Actor) calculateRoute(route string, domainCache map[string]v2action.Domain) ([]string, v2action.Domain, error) {
-	host, domain := actor.splitHost(route)
-	if domain, ok := domainCache[route]; ok {
-		return nil, domain, nil
-	}
-
-	if host == "" {
-		return nil, v2action.Domain{}, actionerror.DomainNotFoundError{Name: route}
+// owningOrganizationName returns the name of the organization that owns the
+// domain when it is a private domain owned by an organization other than
+// orgGUID. Failing to look the organization up is not an error; an empty name
+// is returned instead.
+func (actor Actor) owningOrganizationName(domain v2action.Domain, orgGUID string) (string, Warnings) {
+	if !domain.IsPrivate() || domain.OwningOrganizationGUID == "" || domain.OwningOrganizationGUID == orgGUID {
+		return "", nil
 	}
 
-	hosts, foundDomain, err := actor.calculateRoute(domain, domainCache)
-	hosts = append([]string{host}, hosts...)
-
-	return hosts, foundDomain, err
-}
-
-func (actor Actor) calculatePath(manifestApp manifest.Application, domain v2action.Domain) (string, error) {
-	if manifestApp.RoutePath != "" && domain.IsTCP() {
-		return "", actionerror.RoutePathWithTCPDomainError{}
-	} else {
-		return manifestApp.RoutePath, nil
+	org, warnings, err := actor.V2Actor.GetOrganization(domain.OwningOrganizationGUID)
+	if err != nil {
+		log.Errorln("getting owning organization:", err)
+		return "", Warnings(warnings)
 	}
+	return org.Name, Warnings(warnings)
 }
 
-func (actor Actor) findOrReturnPartialRouteWithSettings(route v2action.Route) (v2action.Route, Warnings, error) {
-	cachedRoute, warnings, err := actor.V2Actor.FindRouteBoundToSpaceWithSettings(route)
-	if _, ok := err.(actionerror.RouteNotFoundError); ok {
-		return route, Warnings(warnings), nil
+// routesWithoutDomain returns the parsed routes whose hostnames do not belong
+// to any of the found domains.
+func (actor Actor) routesWithoutDomain(parsedRoutes []parsedRoute, nameToFoundDomain map[string]v2action.Domain) []parsedRoute {
+	var unmatchedRoutes []parsedRoute
+	for _, parsed := range parsedRoutes {
+		if _, _, err := actor.hostAndDomain(parsed, nameToFoundDomain); err != nil {
+			unmatchedRoutes = append(unmatchedRoutes, parsed)
+		}
 	}
-	return cachedRoute, Warnings(warnings), err
+	return unmatchedRoutes
 }
 
-func (actor Actor) generatePossibleDomains(routes []string) ([]string, error) {
-	var hostnames []string
-	for _, route := range routes {
-		host, _, _, err := actor.parseURL(route)
-		if err != nil {
-			return nil, err
+// generatePossibleDomains returns every domain the parsed routes' hostnames
+// could belong to. Domain names are case insensitive, so the domains are
+// lowercased and each one is only returned once, in sorted order. A single
+// trailing dot, as in the fully qualified app.example.com., is ignored.
+func (Actor) generatePossibleDomains(parsedRoutes []parsedRoute) []string {
+	possibleDomains := map[string]interface{}{}
+	for _, parsed := range parsedRoutes {
+		if parsed.domain != "" {
+			possibleDomains[strings.ToLower(parsed.domain)] = nil
+			continue
 		}
-		hostnames = append(hostnames, host)
-	}
 
-	possibleDomains := map[string]interface{}{}
-	for _, route := range hostnames {
-		count := strings.Count(route, ".")
-		domains := strings.SplitN(route, ".", count)
+		hostname := strings.TrimSuffix(strings.ToLower(parsed.hostname), ".")
+		count := strings.Count(hostname, ".")
+		domains := strings.SplitN(hostname, ".", count)
 
 		for i := range domains {
 			domain := strings.Join(domains[i:], ".")
@@ -352,9 +2264,10 @@ func (actor Actor) generatePossibleDomains(routes []string) ([]string, error) {
 	for domain := range possibleDomains {
 		domains = append(domains, domain)
 	}
+	sort.Strings(domains)
 
 	log.Debugln("domain brakedown:", strings.Join(domains, ","))
-	return domains, nil
+	return domains
 }
 
 func (actor Actor) getDefaultRoute(orgGUID string, spaceGUID string, appName string) (v2action.Route, Warnings, error) {
@@ -370,23 +2283,183 @@ func (actor Actor) getDefaultRoute(orgGUID string, spaceGUID string, appName str
 	}, domainWarnings, nil
 }
 
+// parsedRoute is a route broken into its hostname, port and path by
+// parseURL, or the error parsing it returned.
+type parsedRoute struct {
+	route    string
+	hostname string
+	port     types.NullInt
+	path     string
+	err      error
+
+	// host and domain are set when the route names its domain explicitly, as
+	// a RouteSpec does. hostname is then the host and domain joined.
+	host     string
+	domain   string
+	protocol constant.RouteProtocol
+}
+
+// parseRoutes parses each of the routes in order. A route that is provided
+// more than once is only parsed the first time; its result is reused for the
+// rest.
+func (actor Actor) parseRoutes(routes []string) []parsedRoute {
+	parsedByRoute := map[string]parsedRoute{}
+	parsedRoutes := make([]parsedRoute, 0, len(routes))
+	for _, route := range routes {
+		parsed, found := parsedByRoute[route]
+		if !found {
+			parsed = parsedRoute{route: route}
+			parsed.hostname, parsed.port, parsed.path, parsed.err = actor.parseURL(route)
+			parsedByRoute[route] = parsed
+		}
+		parsedRoutes = append(parsedRoutes, parsed)
+	}
+	return parsedRoutes
+}
+
+// name returns the route as Route.String renders it, with its path in the
+// form described by canonicalRoutePath.
+func (parsed parsedRoute) name() string {
+	return v2action.Route{Domain: v2action.Domain{Name: parsed.hostname}, Port: parsed.port, Path: parsed.path}.String()
+}
+
+// inList returns true when one of the routes has the parsed route's name; see
+// routeHasName.
+func (parsed parsedRoute) inList(routes []v2action.Route) bool {
+	name := parsed.name()
+	for _, route := range routes {
+		if routeHasName(route, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseURL breaks a route into its hostname, port and path. The Cloud
+// Controller does not store query strings or fragments, so a route containing
+// either is rejected rather than having them silently folded into the path.
+// A route that starts with a scheme not in RouteSchemes is rejected rather
+// than having the scheme treated as its hostname, as is a route whose path
+// contains characters the Cloud Controller does not allow; see
+// validateRoutePath. A single trailing dot is dropped from the hostname, so
+// that the fully qualified app.example.com. is the same route as
+// app.example.com. The path is taken from the route as it is written and
+// returned in the form described by canonicalRoutePath, so that encoded
+// characters such as %2F are never decoded.
 func (actor Actor) parseURL(route string) (string, types.NullInt, string, error) {
-	if !(actor.startWithProtocol.MatchString(route)) {
-		route = fmt.Sprintf("http://%s", route)
+	log.WithField("route", route).Debug("parsing route")
+	if strings.ContainsAny(route, "?#") {
+		return "", types.NullInt{}, "", actionerror.RouteQueryOrFragmentError{Route: route}
+	}
+
+	scheme, schemelessRoute := splitRouteScheme(route)
+	if scheme != "" && !actor.isRouteScheme(scheme) {
+		return "", types.NullInt{}, "", actionerror.UnsupportedRouteSchemeError{Route: route, Scheme: scheme}
+	}
+	if err := validateRoutePath(route, schemelessRoute); err != nil {
+		return "", types.NullInt{}, "", err
+	}
+
+	// A hostname and port is not a URL, so it is split without giving it an
+	// http:// scheme.
+	if matches := hostPortRegexp.FindStringSubmatch(schemelessRoute); matches != nil {
+		hostname, err := toASCIIHostname(strings.TrimSuffix(matches[1], "."))
+		if err != nil {
+			return "", types.NullInt{}, "", err
+		}
+
+		var port types.NullInt
+		err = port.ParseStringValue(matches[2])
+		return hostname, port, "", err
 	}
-	parsedURL, err := url.Parse(route)
+
+	parsedURL, err := url.Parse(fmt.Sprintf("http://%s", schemelessRoute))
 	if err != nil {
 		return "", types.NullInt{}, "", err
 	}
 
-	path := parsedURL.RequestURI()
-	if path == "/" {
-		path = ""
+	// The path is not taken from parsedURL, since an encoded slash in a path
+	// that also needs escaping would come back decoded. A single trailing
+	// slash is dropped so that /foo and /foo/ are the same route; this also
+	// reduces the root path to an empty path.
+	var path string
+	if pathStart := strings.Index(schemelessRoute, "/"); pathStart != -1 {
+		path = strings.TrimSuffix(canonicalRoutePath(schemelessRoute[pathStart:]), "/")
+	}
+
+	hostname, err := toASCIIHostname(strings.TrimSuffix(parsedURL.Hostname(), "."))
+	if err != nil {
+		return "", types.NullInt{}, "", err
 	}
 
 	var port types.NullInt
 	err = port.ParseStringValue(parsedURL.Port())
-	return parsedURL.Hostname(), port, path, err
+	return hostname, port, path, err
+}
+
+// inParallel calls fn with each of the provided indexes, running up to
+// RouteConcurrency calls at the same time. Indexes are handed out in order.
+// Once any call returns an error or ctx is cancelled, no further calls are
+// started; calls that are already in flight are allowed to finish. The
+// context's error is returned if any call was skipped because of it.
+func (actor Actor) inParallel(ctx context.Context, indexes []int, fn func(index int) error) error {
+	workers := actor.RouteConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	work := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		failed   int32
+		canceled int32
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				if atomic.LoadInt32(&failed) == 1 {
+					continue
+				}
+				if ctx.Err() != nil {
+					atomic.StoreInt32(&canceled, 1)
+					continue
+				}
+				if err := fn(index); err != nil {
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, index := range indexes {
+		select {
+		case work <- index:
+		case <-ctx.Done():
+			atomic.StoreInt32(&canceled, 1)
+			break dispatch
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if atomic.LoadInt32(&canceled) == 1 {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// routeByGUID returns the route in the list with the same GUID as route.
+func (Actor) routeByGUID(route v2action.Route, routes []v2action.Route) (v2action.Route, bool) {
+	for _, r := range routes {
+		if r.GUID == route.GUID {
+			return r, true
+		}
+	}
+
+	return v2action.Route{}, false
 }
 
 func (Actor) routeInListByGUID(route v2action.Route, routes []v2action.Route) bool {
@@ -399,74 +2472,251 @@ func (Actor) routeInListByGUID(route v2action.Route, routes []v2action.Route) bo
 	return false
 }
 
-func (actor Actor) routeInListByName(route string, routes []v2action.Route) (v2action.Route, bool) {
-	strippedRoute := actor.startWithProtocol.ReplaceAllString(route, "")
+// routeInListByGUIDAndMapping returns true if the route is in the list and,
+// when the route has an app port, the route in the list has the same app
+// port.
+func (Actor) routeInListByGUIDAndMapping(route v2action.Route, routes []v2action.Route) bool {
 	for _, r := range routes {
-		if r.String() == strippedRoute {
-			return r, true
+		if r.GUID == route.GUID {
+			return !route.AppPort.IsSet || r.AppPort == route.AppPort
 		}
 	}
 
-	return v2action.Route{}, false
+	return false
 }
 
-func (Actor) routeInListBySettings(route v2action.Route, routes []v2action.Route) (v2action.Route, bool) {
-	for _, r := range routes {
-		if r.Host == route.Host && r.Path == route.Path && r.Port == route.Port &&
-			r.SpaceGUID == route.SpaceGUID && r.Domain.GUID == route.Domain.GUID {
-			return r, true
+// invalidPathCharacters are the printable ASCII characters that are never
+// allowed in a URL path.
+const invalidPathCharacters = "\"<>\\^`{|}"
+
+// validateRoutePath returns an InvalidRoutePathError when the path of the
+// route, once any percent-encoded characters are decoded, contains whitespace,
+// a control character or one of invalidPathCharacters. Other non-ASCII
+// characters, such as those in internationalized paths, are allowed. A path
+// that cannot be decoded is left for url.Parse to reject.
+func validateRoutePath(route string, schemelessRoute string) error {
+	pathStart := strings.Index(schemelessRoute, "/")
+	if pathStart == -1 {
+		return nil
+	}
+
+	path, err := url.PathUnescape(schemelessRoute[pathStart:])
+	if err != nil {
+		return nil
+	}
+
+	for index, char := range []rune(path) {
+		if unicode.IsSpace(char) || unicode.IsControl(char) || strings.ContainsRune(invalidPathCharacters, char) {
+			log.WithField("route", route).Errorf("invalid character %q in path", char)
+			return actionerror.InvalidRoutePathError{Route: route, Path: path, Character: char, Position: index + 1}
 		}
 	}
+	return nil
+}
 
-	return v2action.Route{}, false
+// canonicalRoutePath returns the path in the form used to compare routes.
+// Percent-encoded characters are kept encoded, as they were written, with
+// their hex digits in upper case, so /a%2fb becomes /a%2Fb and is never the
+// same path as /a/b. Any other character that is not allowed in a URL path,
+// including non-ASCII characters and a % that does not start an encoding, is
+// percent-encoded, so /café becomes /caf%C3%A9.
+func canonicalRoutePath(path string) string {
+	const upperHex = "0123456789ABCDEF"
+
+	var canonical strings.Builder
+	for i := 0; i < len(path); i++ {
+		char := path[i]
+		switch {
+		case char == '%' && i+2 < len(path) && isHex(path[i+1]) && isHex(path[i+2]):
+			canonical.WriteByte('%')
+			canonical.WriteString(strings.ToUpper(path[i+1 : i+3]))
+			i += 2
+		case isPathCharacter(char):
+			canonical.WriteByte(char)
+		default:
+			canonical.WriteByte('%')
+			canonical.WriteByte(upperHex[char>>4])
+			canonical.WriteByte(upperHex[char&0x0F])
+		}
+	}
+	return canonical.String()
 }
 
-func (Actor) sanitize(name string) string {
-	sanitizedName := []rune{}
-	validCount := 0
+func isHex(char byte) bool {
+	return '0' <= char && char <= '9' || 'a' <= char && char <= 'f' || 'A' <= char && char <= 'F'
+}
 
-	for _, runeChar := range strings.TrimSpace(name) {
-		switch {
-		case 'a' <= runeChar && runeChar <= 'z':
-			sanitizedName = append(sanitizedName, runeChar)
-			validCount++
-		case 'A' <= runeChar && runeChar <= 'Z':
-			sanitizedName = append(sanitizedName, unicode.ToLower(runeChar))
-			validCount++
-		case ' ' == runeChar || '-' == runeChar:
-			sanitizedName = append(sanitizedName, '-')
-		case '0' <= runeChar && runeChar <= '9':
-			sanitizedName = append(sanitizedName, runeChar)
-			validCount++
+// isPathCharacter returns true for the characters RFC 3986 allows in a URL
+// path without encoding them.
+func isPathCharacter(char byte) bool {
+	switch {
+	case 'a' <= char && char <= 'z', 'A' <= char && char <= 'Z', '0' <= char && char <= '9':
+		return true
+	default:
+		return strings.IndexByte("-._~!$&'()*+,;=:@/", char) != -1
+	}
+}
+
+// splitRouteScheme splits the scheme, if any, off of the start of the route.
+// The scheme is returned without its trailing ://.
+func splitRouteScheme(route string) (string, string) {
+	match := routeSchemeRegexp.FindStringSubmatch(route)
+	if match == nil {
+		return "", route
+	}
+	return match[1], route[len(match[0]):]
+}
+
+// isRouteScheme returns true when scheme is one of the RouteSchemes, or of the
+// DefaultRouteSchemes when RouteSchemes is empty.
+func (actor Actor) isRouteScheme(scheme string) bool {
+	routeSchemes := actor.RouteSchemes
+	if len(routeSchemes) == 0 {
+		routeSchemes = DefaultRouteSchemes
+	}
+
+	for _, routeScheme := range routeSchemes {
+		if strings.EqualFold(routeScheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeNames returns the name of each of the routes, as parsedRoute.name
+// describes it, so that the routes can be compared with routeHasName without
+// being parsed again. A route that cannot be parsed has an empty name, which
+// matches no route.
+func (actor Actor) routeNames(routes []string) []string {
+	names := make([]string, 0, len(routes))
+	for _, parsed := range actor.parseRoutes(routes) {
+		if parsed.err != nil {
+			names = append(names, "")
+			continue
 		}
+		names = append(names, parsed.name())
+	}
+	return names
+}
+
+// routeHasName returns true when the route, rendered as Route.String renders
+// it with its path in the form described by canonicalRoutePath, is name.
+// Hosts, domains and paths are compared case insensitively, the same way the
+// Cloud Controller matches them.
+func routeHasName(route v2action.Route, name string) bool {
+	if name == "" {
+		return false
 	}
 
-	if validCount > 0 {
-		return strings.Trim(string(sanitizedName), "-")
+	route.Path = canonicalRoutePath(route.Path)
+	return strings.EqualFold(route.String(), name)
+}
+
+// sameRoutePort returns true if the ports are the same for routes on the
+// domain. HTTP routes are not reached through a port, so on an HTTP domain an
+// unset port and a port of zero, as the Cloud Controller may return for an
+// HTTP route, are the same. On a TCP domain an unset port asks for a random
+// port, so it only matches another unset port.
+func sameRoutePort(domain v2action.Domain, port types.NullInt, otherPort types.NullInt) bool {
+	if domain.IsHTTP() {
+		return httpRoutePort(port) == httpRoutePort(otherPort)
 	}
+	return port == otherPort
+}
 
-	return ""
+// sameRouteOptions returns true when both routes set the same options to the
+// same values.
+func sameRouteOptions(options map[string]string, otherOptions map[string]string) bool {
+	if len(options) != len(otherOptions) {
+		return false
+	}
+	for name, value := range options {
+		if otherValue, ok := otherOptions[name]; !ok || otherValue != value {
+			return false
+		}
+	}
+	return true
 }
 
-func (actor Actor) splitExistingRoutes(routes []string, existingRoutes []v2action.Route) ([]v2action.Route, []string) {
-	var cachedRoutes []v2action.Route
-	for _, route := range existingRoutes {
-		cachedRoutes = append(cachedRoutes, route)
+// httpRoutePort returns port with a port of zero treated as unset.
+func httpRoutePort(port types.NullInt) types.NullInt {
+	if port.IsSet && port.Value == 0 {
+		return types.NullInt{}
 	}
+	return port
+}
 
-	var unknownRoutes []string
-	for _, route := range routes {
-		if _, found := actor.routeInListByName(route, existingRoutes); !found {
-			log.WithField("route", route).Debug("unable to find route in cache")
-			unknownRoutes = append(unknownRoutes, route)
+// routeInListBySettings returns the route with the same settings as the
+// provided route, compared with RoutesEqual. Session affinity, options and
+// protocols are only compared when the provided route has them.
+func (Actor) routeInListBySettings(route v2action.Route, routes []v2action.Route) (v2action.Route, bool) {
+	for _, r := range routes {
+		wanted := route
+		if !wanted.SessionAffinity.IsSet {
+			wanted.SessionAffinity = r.SessionAffinity
+		}
+		if len(wanted.Options) == 0 {
+			wanted.Options = r.Options
+		}
+		if wanted.Protocol == "" {
+			wanted.Protocol = r.Protocol
+		}
+
+		if RoutesEqual(wanted, r) {
+			return r, true
+		}
+	}
+
+	return v2action.Route{}, false
+}
+
+// sanitize converts name into a hostname truncated to MaxHostnameLength that
+// keeps the AllowedHostnameCharacters; see sanitizeHostname.
+func (actor Actor) sanitize(name string) string {
+	return sanitizeHostname(name, actor.MaxHostnameLength, actor.AllowedHostnameCharacters)
+}
+
+// splitExistingRoutes returns the existingRoutes along with the parsed routes
+// that are not among them; see routeHasName. Routes that could not be parsed
+// are returned with the unknown routes, so that their errors are reported.
+func (actor Actor) splitExistingRoutes(routes []parsedRoute, existingRoutes []v2action.Route) ([]v2action.Route, []parsedRoute) {
+	cachedRoutes := append([]v2action.Route(nil), existingRoutes...)
+
+	var unknownRoutes []parsedRoute
+	for _, parsed := range routes {
+		if parsed.err == nil && parsed.inList(existingRoutes) {
+			continue
 		}
+		log.WithField("route", parsed.route).Debug("unable to find route in cache")
+		unknownRoutes = append(unknownRoutes, parsed)
 	}
 	return cachedRoutes, unknownRoutes
 }
 
+// toASCIIHostname converts an internationalized hostname into its ASCII
+// (punycode) form, which is how the Cloud Controller stores hosts and
+// domains. Hostnames that are already ASCII are returned unchanged.
+func toASCIIHostname(hostname string) (string, error) {
+	for _, runeChar := range hostname {
+		if runeChar >= utf8.RuneSelf {
+			asciiHostname, err := hostnameProfile.ToASCII(hostname)
+			if err != nil {
+				log.Errorln("converting hostname to ASCII:", err)
+				return "", actionerror.InvalidRouteHostnameError{Hostname: hostname, Err: err}
+			}
+			return asciiHostname, nil
+		}
+	}
+	return hostname, nil
+}
+
+// splitHost splits the leftmost label off of url, returning it as the host
+// and the remainder as the domain. When url has fewer than two labels
+// separated by a dot, there is no host to split off and url is returned as
+// the domain.
 func (Actor) splitHost(url string) (string, string) {
 	count := strings.Count(url, ".")
-	if count == 1 {
+	if count <= 1 {
 		return "", url
 	}
 
@@ -0,0 +1,124 @@
+package pushaction
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	log "github.com/sirupsen/logrus"
+)
+
+// RouteCreationResult is what happened to one of the DesiredRoutes in
+// CreateRoutesBestEffort.
+type RouteCreationResult struct {
+	// Route is the created or existing route, or the desired route when it
+	// could not be created.
+	Route v2action.Route
+	// Created is true when the route was created by CreateRoutesBestEffort.
+	Created bool
+	// Err is why the route could not be created or set up, if it could not.
+	Err error
+}
+
+// CreateRoutesBestEffort creates the DesiredRoutes as CreateRoutes does, except
+// that it tries every route instead of stopping at the first error. A result
+// is returned for each of the DesiredRoutes, in order, and it is up to the
+// caller to decide what to do when only some of them succeeded. The metadata
+// and options of each route are set as in CreateRoutes; when setting them
+// fails, the error is in that route's result.
+//
+// When ctx is cancelled, the routes that have not been tried yet have the
+// context's error in their results. The returned error is only for problems
+// with the routes as a whole, such as the TooManyRoutesError or
+// RouteSessionAffinityNotSupportedError CreateRoutes would return, in which
+// case no routes are tried. In DryRun mode nothing is created and each route
+// that would be created has its validation error, if any, in its result.
+func (actor Actor) CreateRoutesBestEffort(ctx context.Context, config ApplicationConfig, progress RouteProgressFunc) ([]RouteCreationResult, RouteWarnings, error) {
+	if config.NoRoute {
+		log.Info("no-route set, skipping route creation")
+		return nil, nil, nil
+	}
+
+	if err := actor.checkRouteLimit(config.DesiredRoutes); err != nil {
+		return nil, nil, err
+	}
+	if err := actor.checkRoutesSessionAffinity(config.DesiredRoutes); err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]RouteCreationResult, len(config.DesiredRoutes))
+	if actor.DryRun {
+		for index, route := range config.DesiredRoutes {
+			results[index] = RouteCreationResult{Route: route}
+			if route.GUID == "" {
+				results[index].Err = route.Validate()
+			}
+		}
+		return results, nil, nil
+	}
+
+	log.Info("creating routes, continuing past failures")
+
+	var warnings warningsCollector
+	tried := make([]bool, len(config.DesiredRoutes))
+
+	var routesToCreate []int
+	for index, route := range config.DesiredRoutes {
+		results[index] = RouteCreationResult{Route: route}
+		if route.GUID == "" {
+			routesToCreate = append(routesToCreate, index)
+		} else {
+			log.WithField("route", route).Debug("already exists, skipping")
+			tried[index] = true
+			warnings.add(index, RouteWarnings{{
+				Message:  fmt.Sprintf("Route %s already exists.", route),
+				Severity: SeverityInfo,
+			}})
+		}
+	}
+
+	ctxErr := actor.inParallel(ctx, routesToCreate, func(index int) error {
+		createdRoute, existed, createWarnings, err := actor.createRoute(config.DesiredRoutes[index])
+		warnings.add(index, createWarnings)
+		tried[index] = true
+		if err != nil {
+			log.WithField("route", config.DesiredRoutes[index]).Errorln("creating route:", err)
+			results[index].Err = err
+			return nil
+		}
+		results[index] = RouteCreationResult{Route: createdRoute, Created: !existed}
+		return nil
+	})
+
+	for index := range results {
+		if !tried[index] {
+			results[index].Err = ctxErr
+			continue
+		}
+		if results[index].Err != nil {
+			continue
+		}
+
+		route := []v2action.Route{results[index].Route}
+		setWarnings, err := actor.setRoutesMetadata(route)
+		if err == nil {
+			var optionsWarnings Warnings
+			optionsWarnings, err = actor.setRoutesOptions(route)
+			setWarnings = append(setWarnings, optionsWarnings...)
+		}
+		warnings.add(index, NewRouteWarnings(SeverityWarning, setWarnings...))
+		results[index].Err = err
+	}
+
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+		case result.Created:
+			progress.report(result.Route, RouteCreated)
+		default:
+			progress.report(result.Route, RouteSkipped)
+		}
+	}
+
+	return results, warnings.drain(), nil
+}
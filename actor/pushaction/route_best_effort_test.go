@@ -0,0 +1,175 @@
+package pushaction_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v3action"
+	"code.cloudfoundry.org/cli/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateRoutesBestEffort", func() {
+	var (
+		actor       *Actor
+		fakeV2Actor *pushactionfakes.FakeV2Actor
+		fakeV3Actor *pushactionfakes.FakeV3Actor
+
+		ctx    context.Context
+		config ApplicationConfig
+
+		results    []RouteCreationResult
+		warnings   RouteWarnings
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
+		fakeV3Actor = new(pushactionfakes.FakeV3Actor)
+		actor = NewActor(fakeV2Actor, fakeV3Actor, nil)
+
+		ctx = context.Background()
+		config = ApplicationConfig{
+			DesiredRoutes: []v2action.Route{
+				{Host: "some-route-1"},
+				{GUID: "some-route-guid-2", Host: "some-route-2"},
+				{Host: "some-route-3"},
+				{Host: "some-route-4"},
+			},
+		}
+
+		fakeV2Actor.CreateRouteStub = func(route v2action.Route, _ bool) (v2action.Route, v2action.Warnings, error) {
+			switch route.Host {
+			case "some-route-1":
+				return v2action.Route{}, v2action.Warnings{"create-route-warning-1"}, errors.New("some-create-error")
+			case "some-route-3":
+				return v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"}, v2action.Warnings{"create-route-warning-3"}, nil
+			default:
+				return v2action.Route{}, v2action.Warnings{"create-route-warning-4"}, actionerror.RouteQuotaExceededError{Route: route.String()}
+			}
+		}
+	})
+
+	JustBeforeEach(func() {
+		results, warnings, executeErr = actor.CreateRoutesBestEffort(ctx, config, nil)
+	})
+
+	Context("when some of the routes cannot be created", func() {
+		It("tries every route and returns a result for each of them", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(3))
+
+			Expect(results).To(Equal([]RouteCreationResult{
+				{Route: v2action.Route{Host: "some-route-1"}, Err: errors.New("some-create-error")},
+				{Route: v2action.Route{GUID: "some-route-guid-2", Host: "some-route-2"}},
+				{Route: v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"}, Created: true},
+				{Route: v2action.Route{Host: "some-route-4"}, Err: actionerror.RouteQuotaExceededError{Route: "some-route-4."}},
+			}))
+		})
+
+		It("returns the warnings of every route", func() {
+			Expect(warnings).To(Equal(RouteWarnings{
+				{Message: "create-route-warning-1", Severity: SeverityWarning},
+				{Message: "Route some-route-2. already exists.", Severity: SeverityInfo},
+				{Message: "create-route-warning-3", Severity: SeverityWarning},
+				{Message: "create-route-warning-4", Severity: SeverityWarning},
+			}))
+		})
+
+		Context("when a progress callback is provided", func() {
+			var events []RouteEvent
+
+			JustBeforeEach(func() {
+				events = nil
+				_, _, executeErr = actor.CreateRoutesBestEffort(ctx, config, func(event RouteEvent) {
+					events = append(events, event)
+				})
+			})
+
+			It("only reports the routes that were created or already existed", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(events).To(Equal([]RouteEvent{
+					{Route: "some-route-2.", Action: RouteSkipped},
+					{Route: "some-route-3.", Action: RouteCreated},
+				}))
+			})
+		})
+	})
+
+	Context("when setting up a created route fails", func() {
+		BeforeEach(func() {
+			config.DesiredRoutes[2].Options = map[string]string{"loadbalancing": "round-robin"}
+			fakeV3Actor.UpdateRouteOptionsReturns(v3action.Warnings{"set-options-warning"}, errors.New("some-options-error"))
+		})
+
+		It("returns the error in the route's result", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(results[2].Created).To(BeTrue())
+			Expect(results[2].Err).To(MatchError("some-options-error"))
+			Expect(warnings.Strings()).To(ContainElement("set-options-warning"))
+
+			Expect(fakeV3Actor.UpdateRouteOptionsCallCount()).To(Equal(1))
+			routeGUID, _ := fakeV3Actor.UpdateRouteOptionsArgsForCall(0)
+			Expect(routeGUID).To(Equal("some-route-guid-3"))
+		})
+	})
+
+	Context("when the context is cancelled", func() {
+		BeforeEach(func() {
+			cancelledCtx, cancel := context.WithCancel(context.Background())
+			cancel()
+			ctx = cancelledCtx
+		})
+
+		It("does not try the routes and returns the context's error for each of them", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+			Expect(results[0].Err).To(MatchError(context.Canceled))
+			Expect(results[1].Err).ToNot(HaveOccurred())
+			Expect(results[2].Err).To(MatchError(context.Canceled))
+			Expect(results[3].Err).To(MatchError(context.Canceled))
+		})
+	})
+
+	Context("when there are more routes than MaxRoutesPerApp", func() {
+		BeforeEach(func() {
+			actor.MaxRoutesPerApp = 3
+		})
+
+		It("returns a TooManyRoutesError without trying any routes", func() {
+			Expect(executeErr).To(MatchError(actionerror.TooManyRoutesError{Routes: 4, MaxRoutes: 3}))
+			Expect(results).To(BeEmpty())
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when a route sets session affinity", func() {
+		BeforeEach(func() {
+			config.DesiredRoutes[2].SessionAffinity = types.NullBool{IsSet: true, Value: true}
+		})
+
+		It("returns a RouteSessionAffinityNotSupportedError without trying any routes", func() {
+			Expect(executeErr).To(MatchError(actionerror.RouteSessionAffinityNotSupportedError{Route: "some-route-3."}))
+			Expect(results).To(BeEmpty())
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when no-route is set", func() {
+		BeforeEach(func() {
+			config.NoRoute = true
+		})
+
+		It("does not create any routes", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(results).To(BeEmpty())
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+		})
+	})
+})
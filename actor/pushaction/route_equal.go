@@ -0,0 +1,32 @@
+package pushaction
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+)
+
+// RoutesEqual returns true when the routes are the same route with the same
+// settings, compared the way push compares a desired route with existing
+// ones. Hosts are compared case insensitively. Paths are compared case
+// insensitively in the form described by canonicalRoutePath, ignoring a single
+// trailing slash, so /Foo/ and /foo are the same path but /a%2Fb and /a/b are
+// not. Ports are compared with sameRoutePort, treating the domain as TCP when
+// either route's domain is. Domain and space GUIDs, session affinity, options
+// and protocols must be the same. Fields that do not change which route is
+// meant, such as the route's GUID, app port and metadata, are ignored.
+func RoutesEqual(a v2action.Route, b v2action.Route) bool {
+	domain := a.Domain
+	if !domain.IsTCP() {
+		domain = b.Domain
+	}
+
+	return strings.EqualFold(a.Host, b.Host) &&
+		strings.EqualFold(strings.TrimSuffix(canonicalRoutePath(a.Path), "/"), strings.TrimSuffix(canonicalRoutePath(b.Path), "/")) &&
+		sameRoutePort(domain, a.Port, b.Port) &&
+		a.Domain.GUID == b.Domain.GUID &&
+		a.SpaceGUID == b.SpaceGUID &&
+		a.SessionAffinity == b.SessionAffinity &&
+		sameRouteOptions(a.Options, b.Options) &&
+		a.Protocol == b.Protocol
+}
@@ -0,0 +1,100 @@
+package pushaction_test
+
+import (
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RoutesEqual", func() {
+	var (
+		httpDomain v2action.Domain
+		tcpDomain  v2action.Domain
+	)
+
+	BeforeEach(func() {
+		httpDomain = v2action.Domain{GUID: "http-domain-guid", Name: "example.com"}
+		tcpDomain = v2action.Domain{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup}
+	})
+
+	httpRoute := func(modify func(*v2action.Route)) v2action.Route {
+		route := v2action.Route{
+			Host:      "app",
+			Domain:    v2action.Domain{GUID: "http-domain-guid", Name: "example.com"},
+			Path:      "/foo",
+			SpaceGUID: "some-space-guid",
+		}
+		if modify != nil {
+			modify(&route)
+		}
+		return route
+	}
+
+	DescribeTable("comparing HTTP routes",
+		func(modifyA func(*v2action.Route), modifyB func(*v2action.Route), equal bool) {
+			a, b := httpRoute(modifyA), httpRoute(modifyB)
+			Expect(RoutesEqual(a, b)).To(Equal(equal))
+			Expect(RoutesEqual(b, a)).To(Equal(equal))
+		},
+
+		Entry("identical routes", nil, nil, true),
+		Entry("hosts that differ in case", nil, func(r *v2action.Route) { r.Host = "APP" }, true),
+		Entry("different hosts", nil, func(r *v2action.Route) { r.Host = "other" }, false),
+		Entry("paths that differ in case", nil, func(r *v2action.Route) { r.Path = "/FOO" }, true),
+		Entry("a trailing slash", nil, func(r *v2action.Route) { r.Path = "/foo/" }, true),
+		Entry("different paths", nil, func(r *v2action.Route) { r.Path = "/bar" }, false),
+		Entry("no path", nil, func(r *v2action.Route) { r.Path = "" }, false),
+		Entry("encodings that differ in case", func(r *v2action.Route) { r.Path = "/a%2fb" }, func(r *v2action.Route) { r.Path = "/a%2Fb" }, true),
+		Entry("an encoded and a decoded slash", func(r *v2action.Route) { r.Path = "/a%2Fb" }, func(r *v2action.Route) { r.Path = "/a/b" }, false),
+		Entry("a non-ASCII path and its encoding", func(r *v2action.Route) { r.Path = "/café" }, func(r *v2action.Route) { r.Path = "/caf%c3%a9" }, true),
+		Entry("an unset port and a port of zero", nil, func(r *v2action.Route) { r.Port = types.NullInt{IsSet: true, Value: 0} }, true),
+		Entry("different domain GUIDs", nil, func(r *v2action.Route) { r.Domain.GUID = "other-domain-guid" }, false),
+		Entry("different space GUIDs", nil, func(r *v2action.Route) { r.SpaceGUID = "other-space-guid" }, false),
+		Entry("the same session affinity", func(r *v2action.Route) { r.SessionAffinity = types.NullBool{IsSet: true, Value: true} }, func(r *v2action.Route) { r.SessionAffinity = types.NullBool{IsSet: true, Value: true} }, true),
+		Entry("different session affinity", func(r *v2action.Route) { r.SessionAffinity = types.NullBool{IsSet: true, Value: true} }, func(r *v2action.Route) { r.SessionAffinity = types.NullBool{IsSet: true, Value: false} }, false),
+		Entry("session affinity on only one route", func(r *v2action.Route) { r.SessionAffinity = types.NullBool{IsSet: true, Value: true} }, nil, false),
+		Entry("the same options", func(r *v2action.Route) { r.Options = map[string]string{"loadbalancing": "round-robin"} }, func(r *v2action.Route) { r.Options = map[string]string{"loadbalancing": "round-robin"} }, true),
+		Entry("different option values", func(r *v2action.Route) { r.Options = map[string]string{"loadbalancing": "round-robin"} }, func(r *v2action.Route) { r.Options = map[string]string{"loadbalancing": "least-connection"} }, false),
+		Entry("options on only one route", func(r *v2action.Route) { r.Options = map[string]string{"loadbalancing": "round-robin"} }, nil, false),
+		Entry("nil and empty options", func(r *v2action.Route) { r.Options = map[string]string{} }, nil, true),
+		Entry("the same protocol", func(r *v2action.Route) { r.Protocol = constant.HTTP2RouteProtocol }, func(r *v2action.Route) { r.Protocol = constant.HTTP2RouteProtocol }, true),
+		Entry("a protocol on only one route", func(r *v2action.Route) { r.Protocol = constant.HTTP2RouteProtocol }, nil, false),
+		Entry("different GUIDs", func(r *v2action.Route) { r.GUID = "some-route-guid" }, func(r *v2action.Route) { r.GUID = "other-route-guid" }, true),
+		Entry("different app ports", func(r *v2action.Route) { r.AppPort = types.NullInt{IsSet: true, Value: 8080} }, nil, true),
+		Entry("different primary flags", func(r *v2action.Route) { r.Primary = true }, nil, true),
+	)
+
+	Context("when the routes are on a TCP domain", func() {
+		It("only treats an unset port as the same as another unset port", func() {
+			unset := v2action.Route{Domain: tcpDomain}
+			zero := v2action.Route{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 0}}
+			Expect(RoutesEqual(unset, unset)).To(BeTrue())
+			Expect(RoutesEqual(unset, zero)).To(BeFalse())
+		})
+
+		It("compares set ports", func() {
+			a := v2action.Route{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 1234}}
+			b := v2action.Route{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 1235}}
+			Expect(RoutesEqual(a, a)).To(BeTrue())
+			Expect(RoutesEqual(a, b)).To(BeFalse())
+		})
+
+		It("treats the domain as TCP when only one route's domain says so", func() {
+			a := v2action.Route{Domain: tcpDomain}
+			b := v2action.Route{Domain: v2action.Domain{GUID: tcpDomain.GUID}, Port: types.NullInt{IsSet: true, Value: 0}}
+			Expect(RoutesEqual(a, b)).To(BeFalse())
+			Expect(RoutesEqual(b, a)).To(BeFalse())
+		})
+	})
+
+	It("does not compare domain names", func() {
+		a := v2action.Route{Domain: httpDomain}
+		b := v2action.Route{Domain: v2action.Domain{GUID: httpDomain.GUID, Name: "EXAMPLE.com"}}
+		Expect(RoutesEqual(a, b)).To(BeTrue())
+	})
+})
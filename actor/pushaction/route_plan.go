@@ -0,0 +1,44 @@
+package pushaction
+
+// RouteOperations counts the route requests that applying a config will make,
+// so that progress can be shown before any of them are made.
+type RouteOperations struct {
+	// Create is the number of DesiredRoutes CreateRoutes will create.
+	Create int
+	// Map is the number of DesiredRoutes MapRoutes will map.
+	Map int
+	// Unmap is the number of routes that will be unmapped: every CurrentRoute
+	// when config has NoRoute set, otherwise the mapped routes that MapRoutes
+	// unmaps before mapping them to a different app port.
+	Unmap int
+}
+
+// Total returns the number of route operations.
+func (operations RouteOperations) Total() int {
+	return operations.Create + operations.Map + operations.Unmap
+}
+
+// PlanRouteOperations returns the number of routes Apply will create, map and
+// unmap for the config, without making any requests.
+func (actor Actor) PlanRouteOperations(config ApplicationConfig) RouteOperations {
+	var operations RouteOperations
+	if config.NoRoute {
+		operations.Unmap = len(config.CurrentRoutes)
+		return operations
+	}
+
+	for _, route := range config.DesiredRoutes {
+		if route.GUID == "" {
+			operations.Create++
+		}
+	}
+
+	for _, route := range actor.RouteDiff(config).Added {
+		operations.Map++
+		if route.GUID != "" && actor.routeInListByGUID(route, config.CurrentRoutes) {
+			operations.Unmap++
+		}
+	}
+
+	return operations
+}
@@ -0,0 +1,92 @@
+package pushaction_test
+
+import (
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PlanRouteOperations", func() {
+	var (
+		actor       *Actor
+		fakeV2Actor *pushactionfakes.FakeV2Actor
+		config      ApplicationConfig
+		operations  RouteOperations
+	)
+
+	BeforeEach(func() {
+		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
+		actor = NewActor(fakeV2Actor, nil, nil)
+		config = ApplicationConfig{}
+	})
+
+	JustBeforeEach(func() {
+		operations = actor.PlanRouteOperations(config)
+	})
+
+	Context("when there are no routes", func() {
+		It("plans no operations", func() {
+			Expect(operations).To(Equal(RouteOperations{}))
+			Expect(operations.Total()).To(Equal(0))
+		})
+	})
+
+	Context("when the desired routes are new, unmapped and already mapped", func() {
+		BeforeEach(func() {
+			config.CurrentRoutes = []v2action.Route{
+				{GUID: "mapped-route-guid", Host: "mapped"},
+				{GUID: "undesired-route-guid", Host: "undesired"},
+			}
+			config.DesiredRoutes = []v2action.Route{
+				{Host: "new-1"},
+				{Host: "new-2"},
+				{GUID: "existing-route-guid", Host: "existing"},
+				{GUID: "mapped-route-guid", Host: "mapped"},
+			}
+		})
+
+		It("plans to create the new routes and map the routes that are not mapped", func() {
+			Expect(operations).To(Equal(RouteOperations{Create: 2, Map: 3}))
+			Expect(operations.Total()).To(Equal(5))
+		})
+
+		It("does not make any requests", func() {
+			Expect(fakeV2Actor.Invocations()).To(BeEmpty())
+		})
+	})
+
+	Context("when a mapped route is desired on a different app port", func() {
+		BeforeEach(func() {
+			config.CurrentRoutes = []v2action.Route{
+				{GUID: "mapped-route-guid", Host: "mapped", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+			}
+			config.DesiredRoutes = []v2action.Route{
+				{GUID: "mapped-route-guid", Host: "mapped", AppPort: types.NullInt{IsSet: true, Value: 9090}},
+			}
+		})
+
+		It("plans to unmap and map the route again", func() {
+			Expect(operations).To(Equal(RouteOperations{Map: 1, Unmap: 1}))
+			Expect(operations.Total()).To(Equal(2))
+		})
+	})
+
+	Context("when no-route is set", func() {
+		BeforeEach(func() {
+			config.NoRoute = true
+			config.CurrentRoutes = []v2action.Route{
+				{GUID: "route-guid-1"},
+				{GUID: "route-guid-2"},
+			}
+			config.DesiredRoutes = []v2action.Route{{Host: "new"}}
+		})
+
+		It("plans to unmap every current route", func() {
+			Expect(operations).To(Equal(RouteOperations{Unmap: 2}))
+		})
+	})
+})
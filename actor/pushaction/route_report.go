@@ -0,0 +1,62 @@
+package pushaction
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/types"
+)
+
+// RouteReport describes an application's routes for tools that consume push
+// results, unlike RouteSummary, which describes the changes made to them. Its
+// JSON form is stable: every route has the same fields, with an empty host and
+// path and a null port when they do not apply.
+type RouteReport struct {
+	Routes []v2action.Route
+}
+
+type routeReportJSON struct {
+	Routes []routeReportRouteJSON `json:"routes"`
+}
+
+type routeReportRouteJSON struct {
+	GUID   string        `json:"guid"`
+	Type   string        `json:"type"`
+	Host   string        `json:"host"`
+	Domain string        `json:"domain"`
+	Path   string        `json:"path"`
+	Port   types.NullInt `json:"port"`
+}
+
+const (
+	routeReportTypeHTTP = "http"
+	routeReportTypeTCP  = "tcp"
+)
+
+// ReportRoutes returns a RouteReport of the config's current routes.
+func (Actor) ReportRoutes(config ApplicationConfig) RouteReport {
+	return RouteReport{Routes: config.CurrentRoutes}
+}
+
+// MarshalJSON serializes the report as an object with a routes list. Each
+// route has its guid, type (http or tcp), host, domain, path and port.
+func (report RouteReport) MarshalJSON() ([]byte, error) {
+	routes := make([]routeReportRouteJSON, 0, len(report.Routes))
+	for _, route := range report.Routes {
+		routeType := routeReportTypeHTTP
+		if route.Domain.IsTCP() {
+			routeType = routeReportTypeTCP
+		}
+
+		routes = append(routes, routeReportRouteJSON{
+			GUID:   route.GUID,
+			Type:   routeType,
+			Host:   route.Host,
+			Domain: route.Domain.Name,
+			Path:   route.Path,
+			Port:   route.Port,
+		})
+	}
+
+	return json.Marshal(routeReportJSON{Routes: routes})
+}
@@ -0,0 +1,80 @@
+package pushaction_test
+
+import (
+	"encoding/json"
+
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouteReport", func() {
+	var actor *Actor
+
+	BeforeEach(func() {
+		actor = NewActor(new(pushactionfakes.FakeV2Actor), nil, nil)
+	})
+
+	Describe("ReportRoutes", func() {
+		It("reports the config's current routes", func() {
+			routes := []v2action.Route{{GUID: "some-route-guid", Host: "app"}}
+			report := actor.ReportRoutes(ApplicationConfig{
+				CurrentRoutes: routes,
+				DesiredRoutes: []v2action.Route{{GUID: "other-route-guid"}},
+			})
+			Expect(report).To(Equal(RouteReport{Routes: routes}))
+		})
+	})
+
+	Describe("MarshalJSON", func() {
+		It("serializes HTTP and TCP routes with the same fields", func() {
+			report := RouteReport{Routes: []v2action.Route{
+				{
+					GUID:   "http-route-guid",
+					Host:   "app",
+					Domain: v2action.Domain{Name: "example.com"},
+					Path:   "/some-path",
+				},
+				{
+					GUID:   "tcp-route-guid",
+					Domain: v2action.Domain{Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup},
+					Port:   types.NullInt{IsSet: true, Value: 1234},
+				},
+			}}
+
+			reportJSON, err := json.Marshal(report)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reportJSON).To(MatchJSON(`{
+				"routes": [
+					{
+						"guid": "http-route-guid",
+						"type": "http",
+						"host": "app",
+						"domain": "example.com",
+						"path": "/some-path",
+						"port": null
+					},
+					{
+						"guid": "tcp-route-guid",
+						"type": "tcp",
+						"host": "",
+						"domain": "tcp.example.com",
+						"path": "",
+						"port": 1234
+					}
+				]
+			}`))
+		})
+
+		It("serializes a report without routes as an empty list", func() {
+			reportJSON, err := json.Marshal(RouteReport{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reportJSON).To(MatchJSON(`{"routes": []}`))
+		})
+	})
+})
@@ -0,0 +1,111 @@
+package pushaction
+
+import (
+	"context"
+	"strings"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// RouteSpec describes a route by its parts instead of as a URL, so that the
+// domain does not have to be found by splitting the hostname.
+type RouteSpec struct {
+	// Host is the route's host. It is empty for a route on the domain itself
+	// and for TCP routes.
+	Host string
+	// Domain is the name of the route's domain.
+	Domain string
+	// Path is the route's path. A leading slash is added when it is missing.
+	Path string
+	// Port is the route's port on a TCP domain.
+	Port types.NullInt
+	// Protocol is the protocol the router uses to talk to the route's
+	// applications. When empty the Cloud Controller picks the default.
+	Protocol constant.RouteProtocol
+}
+
+// String formats the spec as the route it describes; see v2action.Route.
+func (spec RouteSpec) String() string {
+	return v2action.Route{
+		Host:   spec.Host,
+		Domain: v2action.Domain{Name: spec.Domain},
+		Path:   spec.Path,
+		Port:   spec.Port,
+	}.String()
+}
+
+// CalculateRoutesFromSpecs returns a route for each of the provided specs, as
+// CalculateRoutes does for routes written as URLs. Each spec's route is on the
+// domain it names, so only that domain is looked up. Hosts and domains are
+// converted to their ASCII form, a single trailing dot is dropped from the
+// domain and paths are put in the form described by canonicalRoutePath.
+func (actor Actor) CalculateRoutesFromSpecs(ctx context.Context, specs []RouteSpec, orgGUID string, spaceGUID string, existingRoutes []v2action.Route, randomTCPPorts bool) ([]v2action.Route, RouteWarnings, error) {
+	knownRoutes := append([]v2action.Route(nil), existingRoutes...)
+
+	var unknownRoutes []parsedRoute
+	for _, spec := range specs {
+		parsed := actor.parseSpec(spec)
+		if parsed.err == nil && actor.parsedRouteInList(parsed, existingRoutes) {
+			log.WithField("route", parsed.route).Debug("found route in cache")
+			continue
+		}
+		unknownRoutes = append(unknownRoutes, parsed)
+	}
+
+	potentialRoutes, warnings, err := actor.validateParsedRoutes(ctx, unknownRoutes, nil, orgGUID, spaceGUID, randomTCPPorts)
+	allWarnings := NewRouteWarnings(SeverityWarning, warnings...)
+	if err != nil {
+		return nil, allWarnings, err
+	}
+
+	return actor.lookUpRoutes(ctx, knownRoutes, potentialRoutes, allWarnings, orgGUID)
+}
+
+// parseSpec returns the parsed route the spec describes.
+func (actor Actor) parseSpec(spec RouteSpec) parsedRoute {
+	parsed := parsedRoute{
+		route:    spec.String(),
+		port:     spec.Port,
+		protocol: spec.Protocol,
+	}
+
+	parsed.host, parsed.err = toASCIIHostname(spec.Host)
+	if parsed.err != nil {
+		return parsed
+	}
+	parsed.domain, parsed.err = toASCIIHostname(strings.TrimSuffix(spec.Domain, "."))
+	if parsed.err != nil {
+		return parsed
+	}
+
+	parsed.hostname = parsed.domain
+	if parsed.host != "" {
+		parsed.hostname = parsed.host + "." + parsed.domain
+	}
+
+	if spec.Path != "" {
+		path := "/" + strings.TrimPrefix(spec.Path, "/")
+		if parsed.err = validateRoutePath(parsed.route, path); parsed.err != nil {
+			return parsed
+		}
+		parsed.path = strings.TrimSuffix(canonicalRoutePath(path), "/")
+	}
+	return parsed
+}
+
+// parsedRouteInList returns true when one of the routes has the parsed
+// route's host, domain, port and path, compared as routeHasName compares
+// them.
+func (Actor) parsedRouteInList(parsed parsedRoute, routes []v2action.Route) bool {
+	for _, r := range routes {
+		if strings.EqualFold(r.Host, parsed.host) && strings.EqualFold(r.Domain.Name, parsed.domain) &&
+			sameRoutePort(r.Domain, r.Port, parsed.port) &&
+			strings.EqualFold(strings.TrimSuffix(canonicalRoutePath(r.Path), "/"), parsed.path) {
+			return true
+		}
+	}
+	return false
+}
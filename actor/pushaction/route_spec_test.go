@@ -0,0 +1,141 @@
+package pushaction_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CalculateRoutesFromSpecs", func() {
+	var (
+		actor       *Actor
+		fakeV2Actor *pushactionfakes.FakeV2Actor
+		domains     []v2action.Domain
+	)
+
+	BeforeEach(func() {
+		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
+		actor = NewActor(fakeV2Actor, nil, nil)
+
+		domains = []v2action.Domain{
+			{GUID: "domain-guid", Name: "example.com"},
+			{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup},
+			{GUID: "xn--caf-dma-domain-guid", Name: "xn--caf-dma.com"},
+		}
+		fakeV2Actor.GetDomainsByNameAndOrganizationStub = func(names []string, _ string) ([]v2action.Domain, v2action.Warnings, error) {
+			var found []v2action.Domain
+			for _, name := range names {
+				for _, domain := range domains {
+					if domain.Name == name {
+						found = append(found, domain)
+					}
+				}
+			}
+			return found, nil, nil
+		}
+		fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+	})
+
+	DescribeTable("equivalence with CalculateRoutes",
+		func(route string, spec RouteSpec) {
+			stringRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			specRoutes, _, err := actor.CalculateRoutesFromSpecs(context.Background(), []RouteSpec{spec}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(specRoutes).To(HaveLen(1))
+			Expect(specRoutes).To(Equal(stringRoutes))
+		},
+
+		Entry("host and domain", "app.example.com", RouteSpec{Host: "app", Domain: "example.com"}),
+		Entry("no host", "example.com", RouteSpec{Domain: "example.com"}),
+		Entry("path", "app.example.com/foo", RouteSpec{Host: "app", Domain: "example.com", Path: "/foo"}),
+		Entry("path without a leading slash", "app.example.com/foo", RouteSpec{Host: "app", Domain: "example.com", Path: "foo"}),
+		Entry("path with a trailing slash", "app.example.com/foo/", RouteSpec{Host: "app", Domain: "example.com", Path: "/foo/"}),
+		Entry("encoded path", "app.example.com/a%2fb", RouteSpec{Host: "app", Domain: "example.com", Path: "/a%2fb"}),
+		Entry("TCP port", "tcp.example.com:1234", RouteSpec{Domain: "tcp.example.com", Port: types.NullInt{IsSet: true, Value: 1234}}),
+		Entry("domain with a trailing dot", "app.example.com.", RouteSpec{Host: "app", Domain: "example.com."}),
+		Entry("internationalized domain", "app.café.com", RouteSpec{Host: "app", Domain: "café.com"}),
+		Entry("multi-label host", "a.b.example.com", RouteSpec{Host: "a.b", Domain: "example.com"}),
+	)
+
+	DescribeTable("equivalent errors",
+		func(route string, spec RouteSpec, expectedErr error) {
+			_, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).To(MatchError(expectedErr))
+
+			_, _, err = actor.CalculateRoutesFromSpecs(context.Background(), []RouteSpec{spec}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).To(MatchError(expectedErr))
+		},
+
+		Entry("port on an HTTP domain", "app.example.com:1234", RouteSpec{Host: "app", Domain: "example.com", Port: types.NullInt{IsSet: true, Value: 1234}},
+			actionerror.PortWithHTTPDomainError{Route: "app.example.com:1234", Domain: "example.com"}),
+		Entry("TCP route without a port", "tcp.example.com", RouteSpec{Domain: "tcp.example.com"},
+			actionerror.TCPRouteMissingPortError{Route: "tcp.example.com"}),
+		Entry("unknown domain", "app.unknown.com", RouteSpec{Host: "app", Domain: "unknown.com"},
+			actionerror.NoMatchingDomainError{Route: "app.unknown.com"}),
+		Entry("invalid path character", "app.example.com/{id}", RouteSpec{Host: "app", Domain: "example.com", Path: "/{id}"},
+			actionerror.InvalidRoutePathError{Route: "app.example.com/{id}", Path: "/{id}", Character: '{', Position: 2}),
+	)
+
+	Context("when a host is also the name of a domain", func() {
+		BeforeEach(func() {
+			domains = append(domains, v2action.Domain{GUID: "app-domain-guid", Name: "app.example.com"})
+		})
+
+		It("uses the domain the spec names", func() {
+			stringRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stringRoutes[0].Host).To(BeEmpty())
+			Expect(stringRoutes[0].Domain.Name).To(Equal("app.example.com"))
+
+			specRoutes, _, err := actor.CalculateRoutesFromSpecs(context.Background(), []RouteSpec{{Host: "app", Domain: "example.com"}}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(specRoutes[0].Host).To(Equal("app"))
+			Expect(specRoutes[0].Domain.Name).To(Equal("example.com"))
+
+			domainNames, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(1)
+			Expect(domainNames).To(Equal([]string{"example.com"}))
+		})
+	})
+
+	Context("when the spec sets a protocol", func() {
+		It("keeps the protocol on the route", func() {
+			specRoutes, _, err := actor.CalculateRoutesFromSpecs(context.Background(), []RouteSpec{{Host: "app", Domain: "example.com", Protocol: constant.HTTP2RouteProtocol}}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(specRoutes[0].Protocol).To(Equal(constant.HTTP2RouteProtocol))
+		})
+	})
+
+	Context("when the route already exists", func() {
+		It("returns the existing route without looking it up, as CalculateRoutes does", func() {
+			existingRoute := v2action.Route{
+				GUID:   "some-route-guid",
+				Host:   "app",
+				Domain: domains[0],
+				Path:   "/foo",
+			}
+
+			stringRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"APP.example.com/foo/"}, "some-org-guid", "some-space-guid", []v2action.Route{existingRoute}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			specRoutes, _, err := actor.CalculateRoutesFromSpecs(context.Background(), []RouteSpec{{Host: "APP", Domain: "example.com", Path: "/foo/"}}, "some-org-guid", "some-space-guid", []v2action.Route{existingRoute}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(specRoutes).To(Equal([]v2action.Route{existingRoute}))
+			Expect(specRoutes).To(Equal(stringRoutes))
+			Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+			Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -1,29 +1,152 @@
 package pushaction_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"code.cloudfoundry.org/cli/actor/actionerror"
 	. "code.cloudfoundry.org/cli/actor/pushaction"
 	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
 	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v3action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/types"
 	"code.cloudfoundry.org/cli/util/manifest"
+	"code.cloudfoundry.org/cli/util/words/generator/generatorfakes"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 )
 
 var _ = Describe("Routes", func() {
 	var (
 		actor       *Actor
 		fakeV2Actor *pushactionfakes.FakeV2Actor
+		fakeV3Actor *pushactionfakes.FakeV3Actor
 	)
 
 	BeforeEach(func() {
 		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
-		actor = NewActor(fakeV2Actor, nil)
+		fakeV3Actor = new(pushactionfakes.FakeV3Actor)
+		actor = NewActor(fakeV2Actor, fakeV3Actor, nil)
+		actor.MapRouteRetryBackoff = 0
+	})
+
+	Describe("RouteDiff", func() {
+		var (
+			route1 v2action.Route
+			route2 v2action.Route
+			route3 v2action.Route
+			route4 v2action.Route
+
+			config ApplicationConfig
+		)
+
+		BeforeEach(func() {
+			route1 = v2action.Route{GUID: "some-route-guid-1", Host: "some-route-1"}
+			route2 = v2action.Route{GUID: "some-route-guid-2", Host: "some-route-2"}
+			route3 = v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"}
+			route4 = v2action.Route{GUID: "some-route-guid-4", Host: "some-route-4"}
+			config = ApplicationConfig{}
+		})
+
+		Context("when the current and desired routes overlap", func() {
+			BeforeEach(func() {
+				config.CurrentRoutes = []v2action.Route{route4, route2, route1}
+				config.DesiredRoutes = []v2action.Route{route3, route1, route2}
+			})
+
+			It("returns the added, removed and unchanged routes in order", func() {
+				Expect(actor.RouteDiff(config)).To(Equal(RouteChanges{
+					Added:     []v2action.Route{route3},
+					Removed:   []v2action.Route{route4},
+					Unchanged: []v2action.Route{route1, route2},
+				}))
+			})
+		})
+
+		Context("when the current and desired routes are disjoint", func() {
+			BeforeEach(func() {
+				config.CurrentRoutes = []v2action.Route{route2, route1}
+				config.DesiredRoutes = []v2action.Route{route4, route3}
+			})
+
+			It("adds every desired route and removes every current route", func() {
+				Expect(actor.RouteDiff(config)).To(Equal(RouteChanges{
+					Added:   []v2action.Route{route4, route3},
+					Removed: []v2action.Route{route2, route1},
+				}))
+			})
+		})
+
+		Context("when the current and desired routes are the same", func() {
+			BeforeEach(func() {
+				config.CurrentRoutes = []v2action.Route{route1, route2}
+				config.DesiredRoutes = []v2action.Route{route2, route1}
+			})
+
+			It("returns only unchanged routes", func() {
+				Expect(actor.RouteDiff(config)).To(Equal(RouteChanges{
+					Unchanged: []v2action.Route{route2, route1},
+				}))
+			})
+		})
+
+		Context("when desired routes have not been created yet", func() {
+			BeforeEach(func() {
+				config.CurrentRoutes = []v2action.Route{route1, {Host: "some-other-new-route"}}
+				config.DesiredRoutes = []v2action.Route{{Host: "some-new-route"}, route1}
+			})
+
+			It("does not match them against routes without a GUID", func() {
+				Expect(actor.RouteDiff(config)).To(Equal(RouteChanges{
+					Added:     []v2action.Route{{Host: "some-new-route"}},
+					Removed:   []v2action.Route{{Host: "some-other-new-route"}},
+					Unchanged: []v2action.Route{route1},
+				}))
+			})
+		})
+
+		Context("when there are no routes", func() {
+			It("returns no changes", func() {
+				Expect(actor.RouteDiff(config)).To(Equal(RouteChanges{}))
+			})
+		})
+	})
+
+	Describe("MapRoutesDryRun", func() {
+		It("returns the routes that would be mapped without mapping them", func() {
+			config := ApplicationConfig{
+				CurrentRoutes: []v2action.Route{
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+				},
+				DesiredRoutes: []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{Host: "some-new-route"},
+				},
+			}
+
+			returnedConfig, routesToMap := actor.MapRoutesDryRun(config)
+			Expect(routesToMap).To(Equal([]v2action.Route{
+				{GUID: "some-route-guid-1", Host: "some-route-1"},
+				{Host: "some-new-route"},
+			}))
+			Expect(returnedConfig.CurrentRoutes).To(Equal(config.DesiredRoutes))
+
+			Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+		})
 	})
 
 	Describe("UnmapRoutes", func() {
@@ -45,7 +168,7 @@ var _ = Describe("Routes", func() {
 		})
 
 		JustBeforeEach(func() {
-			returnedConfig, warnings, executeErr = actor.UnmapRoutes(config)
+			returnedConfig, warnings, executeErr = actor.UnmapRoutes(context.Background(), config)
 		})
 
 		Context("when there are routes on the application", func() {
@@ -58,49 +181,326 @@ var _ = Describe("Routes", func() {
 
 			Context("when the unmapping is successful", func() {
 				BeforeEach(func() {
-					fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, nil)
+					fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+						return v2action.Warnings{routeGUID + "-warning"}, nil
+					}
 				})
 
-				It("only creates the routes that do not exist", func() {
+				It("unmaps all the routes and returns the warnings in route order", func() {
 					Expect(executeErr).ToNot(HaveOccurred())
-					Expect(warnings).To(ConsistOf("unmap-route-warning", "unmap-route-warning"))
+					Expect(warnings).To(Equal(Warnings{"some-route-guid-1-warning", "some-route-guid-2-warning"}))
 
 					Expect(returnedConfig.CurrentRoutes).To(BeEmpty())
 
 					Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(2))
 
-					routeGUID, appGUID := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(0)
-					Expect(routeGUID).To(Equal("some-route-guid-1"))
-					Expect(appGUID).To(Equal("some-app-guid"))
-
-					routeGUID, appGUID = fakeV2Actor.UnmapRouteFromApplicationArgsForCall(1)
-					Expect(routeGUID).To(Equal("some-route-guid-2"))
-					Expect(appGUID).To(Equal("some-app-guid"))
+					var routeGUIDs []string
+					for i := 0; i < fakeV2Actor.UnmapRouteFromApplicationCallCount(); i++ {
+						routeGUID, appGUID := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(i)
+						Expect(appGUID).To(Equal("some-app-guid"))
+						routeGUIDs = append(routeGUIDs, routeGUID)
+					}
+					Expect(routeGUIDs).To(ConsistOf("some-route-guid-1", "some-route-guid-2"))
 				})
 			})
 
-			Context("when the mapping errors", func() {
+			Context("when the unmapping errors", func() {
 				var expectedErr error
 				BeforeEach(func() {
 					expectedErr = errors.New("oh my")
+					actor.RouteConcurrency = 1
 					fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, expectedErr)
 				})
 
-				It("sends the warnings and errors and returns true", func() {
+				It("sends the warnings and errors", func() {
 					Expect(executeErr).To(MatchError(expectedErr))
 					Expect(warnings).To(ConsistOf("unmap-route-warning"))
 				})
+
+				It("leaves the routes that were not unmapped in CurrentRoutes", func() {
+					Expect(returnedConfig.CurrentRoutes).To(Equal(config.CurrentRoutes))
+				})
+			})
+
+			Context("when some of the routes are unmapped before an error", func() {
+				var expectedErr error
+				BeforeEach(func() {
+					expectedErr = errors.New("oh my")
+					config.CurrentRoutes = append(config.CurrentRoutes, v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"})
+					fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+						if routeGUID == "some-route-guid-2" {
+							return v2action.Warnings{"unmap-route-warning-2"}, expectedErr
+						}
+						return v2action.Warnings{"unmap-route-warning"}, nil
+					}
+				})
+
+				It("returns the error and only the routes that are still mapped", func() {
+					Expect(executeErr).To(MatchError(expectedErr))
+					Expect(warnings).To(ContainElement("unmap-route-warning-2"))
+					Expect(returnedConfig.CurrentRoutes).To(ContainElement(v2action.Route{GUID: "some-route-guid-2", Host: "some-route-2"}))
+					Expect(returnedConfig.CurrentRoutes).ToNot(ContainElement(v2action.Route{GUID: "some-route-guid-1", Host: "some-route-1", Domain: v2action.Domain{Name: "some-domain.com"}}))
+				})
+			})
+		})
+
+		Context("when there are more routes than RouteConcurrency", func() {
+			var (
+				inFlight    int32
+				maxInFlight int32
+			)
+
+			BeforeEach(func() {
+				actor.RouteConcurrency = 3
+				inFlight = 0
+				maxInFlight = 0
+
+				for i := 0; i < 10; i++ {
+					config.CurrentRoutes = append(config.CurrentRoutes, v2action.Route{GUID: fmt.Sprintf("some-route-guid-%d", i)})
+				}
+
+				fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						max := atomic.LoadInt32(&maxInFlight)
+						if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					return v2action.Warnings{routeGUID + "-warning"}, nil
+				}
+			})
+
+			It("never exceeds RouteConcurrency and unmaps every route", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(atomic.LoadInt32(&maxInFlight)).To(BeNumerically("<=", 3))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(10))
+				Expect(returnedConfig.CurrentRoutes).To(BeEmpty())
+
+				for i, warning := range warnings {
+					Expect(warning).To(Equal(fmt.Sprintf("some-route-guid-%d-warning", i)))
+				}
+			})
+		})
+
+		Context("when many routes return warnings concurrently", func() {
+			BeforeEach(func() {
+				actor.RouteConcurrency = 50
+
+				for i := 0; i < 200; i++ {
+					config.CurrentRoutes = append(config.CurrentRoutes, v2action.Route{GUID: fmt.Sprintf("some-route-guid-%d", i)})
+				}
+
+				fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+					var index int
+					_, err := fmt.Sscanf(routeGUID, "some-route-guid-%d", &index)
+					Expect(err).ToNot(HaveOccurred())
+					time.Sleep(time.Duration(200-index) * time.Microsecond)
+					return v2action.Warnings{routeGUID + "-warning-1", routeGUID + "-warning-2"}, nil
+				}
+			})
+
+			It("returns every warning in route order", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+
+				var expectedWarnings Warnings
+				for i := 0; i < 200; i++ {
+					expectedWarnings = append(expectedWarnings,
+						fmt.Sprintf("some-route-guid-%d-warning-1", i),
+						fmt.Sprintf("some-route-guid-%d-warning-2", i),
+					)
+				}
+				Expect(warnings).To(Equal(expectedWarnings))
+			})
+		})
+	})
+
+	Describe("UnmapSpecificRoutes", func() {
+		var (
+			config         ApplicationConfig
+			routesToUnmap  []v2action.Route
+			returnedConfig ApplicationConfig
+			warnings       Warnings
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			config = ApplicationConfig{
+				DesiredApplication: Application{
+					Application: v2action.Application{
+						GUID: "some-app-guid",
+					}},
+				CurrentRoutes: []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-3", Host: "some-route-3"},
+				},
+			}
+			routesToUnmap = []v2action.Route{
+				{GUID: "some-route-guid-3"},
+				{GUID: "some-route-guid-1"},
+				{GUID: "some-unmapped-route-guid"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			returnedConfig, warnings, executeErr = actor.UnmapSpecificRoutes(context.Background(), config, routesToUnmap)
+		})
+
+		Context("when the unmapping is successful", func() {
+			BeforeEach(func() {
+				fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+					return v2action.Warnings{routeGUID + "-warning"}, nil
+				}
+			})
+
+			It("unmaps only the given routes that are mapped", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"some-route-guid-1-warning", "some-route-guid-3-warning"}))
+
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(2))
+				var routeGUIDs []string
+				for i := 0; i < fakeV2Actor.UnmapRouteFromApplicationCallCount(); i++ {
+					routeGUID, appGUID := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(i)
+					Expect(appGUID).To(Equal("some-app-guid"))
+					routeGUIDs = append(routeGUIDs, routeGUID)
+				}
+				Expect(routeGUIDs).To(ConsistOf("some-route-guid-1", "some-route-guid-3"))
+			})
+
+			It("leaves the other routes in CurrentRoutes", func() {
+				Expect(returnedConfig.CurrentRoutes).To(Equal([]v2action.Route{
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+				}))
+			})
+		})
+
+		Context("when one of the unmaps errors", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("oh my")
+				fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+					if routeGUID == "some-route-guid-3" {
+						return v2action.Warnings{"unmap-route-warning-3"}, expectedErr
+					}
+					return v2action.Warnings{"unmap-route-warning"}, nil
+				}
+			})
+
+			It("returns the error, the warnings and the routes that are still mapped", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ContainElement("unmap-route-warning-3"))
+				Expect(returnedConfig.CurrentRoutes).To(ContainElement(v2action.Route{GUID: "some-route-guid-2", Host: "some-route-2"}))
+				Expect(returnedConfig.CurrentRoutes).To(ContainElement(v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"}))
+			})
+		})
+	})
+
+	Describe("RemapRoute", func() {
+		var (
+			route      v2action.Route
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			route = v2action.Route{
+				GUID:      "some-route-guid",
+				Host:      "some-route",
+				Domain:    v2action.Domain{Name: "some-domain.com"},
+				SpaceGUID: "some-space-guid",
+			}
+		})
+
+		JustBeforeEach(func() {
+			warnings, executeErr = actor.RemapRoute(context.Background(), route, "blue-app-guid", "green-app-guid", "some-space-guid")
+		})
+
+		Context("when mapping and unmapping are successful", func() {
+			var calls []string
+
+			BeforeEach(func() {
+				calls = nil
+				fakeV2Actor.MapRouteToApplicationStub = func(routeGUID string, appGUID string) (v2action.Warnings, error) {
+					calls = append(calls, "map "+appGUID)
+					return v2action.Warnings{"map-warning"}, nil
+				}
+				fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, appGUID string) (v2action.Warnings, error) {
+					calls = append(calls, "unmap "+appGUID)
+					return v2action.Warnings{"unmap-warning"}, nil
+				}
+			})
+
+			It("maps the route to the new app before unmapping it from the old app", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"map-warning", "unmap-warning"}))
+				Expect(calls).To(Equal([]string{"map green-app-guid", "unmap blue-app-guid"}))
+
+				routeGUID, _ := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+				Expect(routeGUID).To(Equal("some-route-guid"))
+				routeGUID, _ = fakeV2Actor.UnmapRouteFromApplicationArgsForCall(0)
+				Expect(routeGUID).To(Equal("some-route-guid"))
+			})
+		})
+
+		Context("when the route is in a different space than the new app", func() {
+			BeforeEach(func() {
+				route.SpaceGUID = "other-space-guid"
+			})
+
+			It("returns the error naming the route without mapping or unmapping it", func() {
+				Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{Route: "some-route.some-domain.com"}))
+				Expect(warnings).To(BeEmpty())
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the Cloud Controller reports that the route is in a different space", func() {
+			BeforeEach(func() {
+				fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-warning"}, actionerror.RouteInDifferentSpaceError{})
+			})
+
+			It("returns the error naming the route and leaves it mapped to the old app", func() {
+				Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{Route: "some-route.some-domain.com"}))
+				Expect(warnings).To(Equal(Warnings{"map-warning"}))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when mapping succeeds but unmapping fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("unmap failed")
+				fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-warning"}, nil)
+				fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-warning"}, expectedErr)
+			})
+
+			It("returns a RouteMappedToBothApplicationsError and all warnings", func() {
+				Expect(executeErr).To(MatchError(actionerror.RouteMappedToBothApplicationsError{
+					Route:       "some-route.some-domain.com",
+					FromAppGUID: "blue-app-guid",
+					ToAppGUID:   "green-app-guid",
+					Err:         expectedErr,
+				}))
+				Expect(warnings).To(Equal(Warnings{"map-warning", "unmap-warning"}))
 			})
 		})
 	})
 
 	Describe("MapRoutes", func() {
 		var (
-			config ApplicationConfig
+			config   ApplicationConfig
+			progress RouteProgressFunc
 
 			returnedConfig ApplicationConfig
 			boundRoutes    bool
-			warnings       Warnings
+			mappedRoutes   []v2action.Route
+			warnings       RouteWarnings
 			executeErr     error
 		)
 
@@ -111,10 +511,54 @@ var _ = Describe("Routes", func() {
 						GUID: "some-app-guid",
 					}},
 			}
+			progress = nil
 		})
 
 		JustBeforeEach(func() {
-			returnedConfig, boundRoutes, warnings, executeErr = actor.MapRoutes(config)
+			returnedConfig, boundRoutes, mappedRoutes, warnings, executeErr = actor.MapRoutes(context.Background(), config, progress)
+		})
+
+		Context("when there are no desired routes", func() {
+			BeforeEach(func() {
+				config.CurrentRoutes = []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+				}
+				config.DesiredRoutes = nil
+			})
+
+			It("maps nothing and leaves the current routes untouched", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+				Expect(boundRoutes).To(BeFalse())
+				Expect(mappedRoutes).To(BeEmpty())
+				Expect(returnedConfig).To(Equal(config))
+				Expect(returnedConfig.CurrentRoutes).To(HaveLen(1))
+
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+
+			Context("when the actor is in dry run mode", func() {
+				BeforeEach(func() {
+					actor.DryRun = true
+				})
+
+				It("leaves the current routes untouched", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(mappedRoutes).To(BeEmpty())
+					Expect(returnedConfig).To(Equal(config))
+				})
+			})
+
+			Context("when the routes are reconciled", func() {
+				It("unmaps the current routes", func() {
+					reconciledConfig, summary, _, err := actor.ReconcileRoutes(context.Background(), config)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(summary.Unmapped).To(Equal(config.CurrentRoutes))
+					Expect(reconciledConfig.CurrentRoutes).To(BeEmpty())
+					Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		Context("when routes need to be bound to the application", func() {
@@ -129,6 +573,21 @@ var _ = Describe("Routes", func() {
 				}
 			})
 
+			Context("when no-route is set", func() {
+				BeforeEach(func() {
+					config.NoRoute = true
+				})
+
+				It("does not map any routes and returns the config unchanged", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings.Strings()).To(BeEmpty())
+					Expect(boundRoutes).To(BeFalse())
+					Expect(returnedConfig).To(Equal(config))
+
+					Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the mapping is successful", func() {
 				BeforeEach(func() {
 					fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
@@ -136,8 +595,9 @@ var _ = Describe("Routes", func() {
 
 				It("only creates the routes that do not exist", func() {
 					Expect(executeErr).ToNot(HaveOccurred())
-					Expect(warnings).To(ConsistOf("map-route-warning", "map-route-warning"))
+					Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-warning", "map-route-warning"))
 					Expect(boundRoutes).To(BeTrue())
+					Expect(mappedRoutes).To(Equal([]v2action.Route{config.DesiredRoutes[0], config.DesiredRoutes[2]}))
 
 					Expect(returnedConfig.CurrentRoutes).To(Equal(config.DesiredRoutes))
 
@@ -151,666 +611,4872 @@ var _ = Describe("Routes", func() {
 					Expect(routeGUID).To(Equal("some-route-guid-3"))
 					Expect(appGUID).To(Equal("some-app-guid"))
 				})
-			})
 
-			Context("when the mapping errors", func() {
-				Context("when the route is bound in another space", func() {
+				It("returns an informational warning for the route that is already mapped", func() {
+					Expect(warnings).To(ConsistOf(
+						RouteWarning{Message: "Route some-route-2. is already mapped to the application.", Severity: SeverityInfo},
+						RouteWarning{Message: "map-route-warning", Severity: SeverityWarning},
+						RouteWarning{Message: "map-route-warning", Severity: SeverityWarning},
+					))
+				})
+
+				Context("when a progress callback is provided", func() {
+					var events []RouteEvent
+
 					BeforeEach(func() {
-						fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, actionerror.RouteInDifferentSpaceError{})
+						events = nil
+						progress = func(event RouteEvent) {
+							events = append(events, event)
+						}
 					})
 
-					It("sends the RouteInDifferentSpaceError (with a guid set) and warnings and returns true", func() {
-						Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{Route: "some-route-1.some-domain.com"}))
-						Expect(warnings).To(ConsistOf("map-route-warning"))
+					It("reports the skipped routes and then each route as it is mapped", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(events).To(Equal([]RouteEvent{
+							{Route: "some-route-2.", Action: RouteSkipped},
+							{Route: "some-route-1.some-domain.com", Action: RouteMapped},
+							{Route: "some-route-3.", Action: RouteMapped},
+						}))
 					})
 				})
 
-				Context("generic error", func() {
-					var expectedErr error
+				Context("when a route has the protocol it was asked to have", func() {
 					BeforeEach(func() {
-						expectedErr = errors.New("oh my")
-						fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, expectedErr)
+						config.DesiredRoutes[0].Protocol = constant.HTTP2RouteProtocol
+						config.DesiredRoutes[0].RequestedProtocol = constant.HTTP2RouteProtocol
 					})
 
-					It("sends the warnings and errors and returns true", func() {
-						Expect(executeErr).To(MatchError(expectedErr))
-						Expect(warnings).To(ConsistOf("map-route-warning"))
+					It("does not warn about the route's protocol", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-warning", "map-route-warning"))
 					})
 				})
-			})
-		})
 
-		Context("when no routes need to be bound", func() {
-			It("returns false", func() {
-				Expect(executeErr).ToNot(HaveOccurred())
-			})
-		})
-	})
+				Context("when a route has a different protocol from the one it was asked to have", func() {
+					BeforeEach(func() {
+						config.DesiredRoutes[0].Protocol = constant.HTTP1RouteProtocol
+						config.DesiredRoutes[0].RequestedProtocol = constant.HTTP2RouteProtocol
+					})
 
-	Describe("CalculateRoutes", func() {
-		var (
-			routes         []string
-			orgGUID        string
-			spaceGUID      string
-			existingRoutes []v2action.Route
+					It("maps the route and warns about its protocol", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(mappedRoutes).To(Equal([]v2action.Route{config.DesiredRoutes[0], config.DesiredRoutes[2]}))
+						Expect(warnings.AtLeast(SeverityWarning)).To(ConsistOf(
+							RouteWarning{Message: "map-route-warning", Severity: SeverityWarning},
+							RouteWarning{Message: "map-route-warning", Severity: SeverityWarning},
+							RouteWarning{Message: "Route some-route-1.some-domain.com uses protocol http1 instead of the requested http2.", Severity: SeverityWarning},
+						))
+					})
+				})
 
-			calculatedRoutes []v2action.Route
-			warnings         Warnings
-			executeErr       error
-		)
+				Context("when a route that was asked to have a protocol has none", func() {
+					BeforeEach(func() {
+						config.DesiredRoutes[0].RequestedProtocol = constant.HTTP2RouteProtocol
+					})
 
-		BeforeEach(func() {
-			routes = []string{
-				"a.com",
+					It("does not warn about the route's protocol", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-warning", "map-route-warning"))
+					})
+				})
+			})
+
+			Context("when mapping a route fails and a progress callback is provided", func() {
+				var events []RouteEvent
+
+				BeforeEach(func() {
+					events = nil
+					progress = func(event RouteEvent) {
+						events = append(events, event)
+					}
+					fakeV2Actor.MapRouteToApplicationReturnsOnCall(0, nil, nil)
+					fakeV2Actor.MapRouteToApplicationReturnsOnCall(1, nil, errors.New("map failed"))
+				})
+
+				It("does not report the route that failed", func() {
+					Expect(executeErr).To(MatchError("map failed"))
+					Expect(events).To(Equal([]RouteEvent{
+						{Route: "some-route-2.", Action: RouteSkipped},
+						{Route: "some-route-1.some-domain.com", Action: RouteMapped},
+					}))
+				})
+			})
+
+			Context("when one of the routes is primary", func() {
+				BeforeEach(func() {
+					config.DesiredRoutes[2].Primary = true
+					fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+				})
+
+				It("maps the primary route first", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(mappedRoutes).To(Equal([]v2action.Route{config.DesiredRoutes[2], config.DesiredRoutes[0]}))
+					Expect(returnedConfig.CurrentRoutes).To(Equal([]v2action.Route{
+						config.DesiredRoutes[2],
+						config.DesiredRoutes[0],
+						config.DesiredRoutes[1],
+					}))
+
+					Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(2))
+					routeGUID, _ := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+					Expect(routeGUID).To(Equal("some-route-guid-3"))
+					routeGUID, _ = fakeV2Actor.MapRouteToApplicationArgsForCall(1)
+					Expect(routeGUID).To(Equal("some-route-guid-1"))
+				})
+			})
+
+			Context("when more than one route is primary", func() {
+				BeforeEach(func() {
+					config.DesiredApplication.Name = "some-app"
+					config.DesiredRoutes[0].Primary = true
+					config.DesiredRoutes[2].Primary = true
+				})
+
+				It("returns a MultiplePrimaryRoutesError without mapping any routes", func() {
+					Expect(executeErr).To(MatchError(actionerror.MultiplePrimaryRoutesError{
+						AppName: "some-app",
+						Routes:  []string{"some-route-1.some-domain.com", "some-route-3."},
+					}))
+					Expect(boundRoutes).To(BeFalse())
+					Expect(mappedRoutes).To(BeEmpty())
+
+					Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a deployment GUID is set", func() {
+				BeforeEach(func() {
+					config.DeploymentGUID = "some-deployment-guid"
+					config.DesiredRoutes = []v2action.Route{
+						{GUID: "some-route-guid-1", Host: "some-route-1"},
+						{GUID: "some-route-guid-3", Host: "some-route-3", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+					}
+					fakeV2Actor.MapRouteToApplicationDeploymentReturns(v2action.Warnings{"map-route-deployment-warning"}, nil)
+				})
+
+				It("maps the routes to the deployment", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-deployment-warning", "map-route-deployment-warning"))
+					Expect(boundRoutes).To(BeTrue())
+
+					Expect(fakeV2Actor.MapRouteToApplicationDeploymentCallCount()).To(Equal(2))
+					routeGUID, appGUID, appPort, deploymentGUID := fakeV2Actor.MapRouteToApplicationDeploymentArgsForCall(0)
+					Expect(routeGUID).To(Equal("some-route-guid-1"))
+					Expect(appGUID).To(Equal("some-app-guid"))
+					Expect(appPort).To(Equal(types.NullInt{}))
+					Expect(deploymentGUID).To(Equal("some-deployment-guid"))
+
+					routeGUID, _, appPort, deploymentGUID = fakeV2Actor.MapRouteToApplicationDeploymentArgsForCall(1)
+					Expect(routeGUID).To(Equal("some-route-guid-3"))
+					Expect(appPort).To(Equal(types.NullInt{IsSet: true, Value: 8080}))
+					Expect(deploymentGUID).To(Equal("some-deployment-guid"))
+
+					Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+					Expect(fakeV2Actor.MapRouteToApplicationWithPortCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when routes are mapped to app ports", func() {
+				BeforeEach(func() {
+					config.CurrentRoutes = []v2action.Route{
+						{GUID: "some-route-guid-1", Host: "some-route-1", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+						{GUID: "some-route-guid-2", Host: "some-route-2", AppPort: types.NullInt{IsSet: true, Value: 9090}},
+					}
+					config.DesiredRoutes = []v2action.Route{
+						{GUID: "some-route-guid-1", Host: "some-route-1", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+						{GUID: "some-route-guid-2", Host: "some-route-2", Domain: v2action.Domain{Name: "some-domain.com"}, AppPort: types.NullInt{IsSet: true, Value: 9091}},
+						{GUID: "some-route-guid-3", Host: "some-route-3", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+						{GUID: "some-route-guid-4", Host: "some-route-4"},
+					}
+					fakeV2Actor.MapRouteToApplicationWithPortReturns(v2action.Warnings{"map-route-port-warning"}, nil)
+					fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+				})
+
+				It("maps new routes and routes whose app port changed to their app ports", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-port-warning", "map-route-port-warning", "map-route-warning"))
+					Expect(boundRoutes).To(BeTrue())
+
+					Expect(fakeV2Actor.MapRouteToApplicationWithPortCallCount()).To(Equal(2))
+					routeGUID, appGUID, appPort := fakeV2Actor.MapRouteToApplicationWithPortArgsForCall(0)
+					Expect(routeGUID).To(Equal("some-route-guid-2"))
+					Expect(appGUID).To(Equal("some-app-guid"))
+					Expect(appPort).To(Equal(types.NullInt{IsSet: true, Value: 9091}))
+
+					routeGUID, appGUID, appPort = fakeV2Actor.MapRouteToApplicationWithPortArgsForCall(1)
+					Expect(routeGUID).To(Equal("some-route-guid-3"))
+					Expect(appGUID).To(Equal("some-app-guid"))
+					Expect(appPort).To(Equal(types.NullInt{IsSet: true, Value: 8080}))
+
+					Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+					routeGUID, _ = fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+					Expect(routeGUID).To(Equal("some-route-guid-4"))
+				})
+
+				It("does not map a route again when it is already mapped to the same app port", func() {
+					for i := 0; i < fakeV2Actor.MapRouteToApplicationWithPortCallCount(); i++ {
+						routeGUID, _, _ := fakeV2Actor.MapRouteToApplicationWithPortArgsForCall(i)
+						Expect(routeGUID).ToNot(Equal("some-route-guid-1"))
+					}
+					Expect(warnings.Strings()).To(ContainElement("Route some-route-1. is already mapped to the application."))
+				})
+
+				Context("when a route's app port changed", func() {
+					var calls []string
+
+					BeforeEach(func() {
+						calls = nil
+						fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+							calls = append(calls, "unmap "+routeGUID)
+							return nil, nil
+						}
+						fakeV2Actor.MapRouteToApplicationWithPortStub = func(routeGUID string, _ string, appPort types.NullInt) (v2action.Warnings, error) {
+							calls = append(calls, fmt.Sprintf("map %s:%d", routeGUID, appPort.Value))
+							return nil, nil
+						}
+					})
+
+					It("unmaps the old mapping before mapping the route to the new app port", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(calls).To(Equal([]string{
+							"unmap some-route-guid-2",
+							"map some-route-guid-2:9091",
+							"map some-route-guid-3:8080",
+						}))
+
+						routeGUID, appGUID := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(0)
+						Expect(routeGUID).To(Equal("some-route-guid-2"))
+						Expect(appGUID).To(Equal("some-app-guid"))
+					})
+				})
+
+				Context("when unmapping the route whose app port changed fails", func() {
+					var expectedErr error
+
+					BeforeEach(func() {
+						expectedErr = errors.New("unmap route error")
+						fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, expectedErr)
+					})
+
+					It("returns the error without mapping the route to the new app port", func() {
+						Expect(executeErr).To(MatchError(expectedErr))
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("unmap-route-warning"))
+						Expect(fakeV2Actor.MapRouteToApplicationWithPortCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when mapping to the app port is in a different space", func() {
+					BeforeEach(func() {
+						fakeV2Actor.MapRouteToApplicationWithPortReturns(v2action.Warnings{"map-route-port-warning"}, actionerror.RouteInDifferentSpaceError{})
+					})
+
+					It("returns a RouteInDifferentSpaceError naming the route", func() {
+						Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{Route: "some-route-2.some-domain.com"}))
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-port-warning"))
+					})
+				})
+			})
+
+			Context("when the actor is in dry run mode", func() {
+				BeforeEach(func() {
+					actor.DryRun = true
+				})
+
+				It("does not map any routes and returns the would-be config", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings.Strings()).To(BeEmpty())
+					Expect(boundRoutes).To(BeTrue())
+					Expect(mappedRoutes).To(Equal([]v2action.Route{config.DesiredRoutes[0], config.DesiredRoutes[2]}))
+					Expect(returnedConfig.CurrentRoutes).To(Equal(config.DesiredRoutes))
+
+					Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the mapping errors", func() {
+				Context("when the route is bound in another space", func() {
+					BeforeEach(func() {
+						fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, actionerror.RouteInDifferentSpaceError{})
+					})
+
+					It("sends the RouteInDifferentSpaceError (with a guid set) and warnings and returns true", func() {
+						Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{Route: "some-route-1.some-domain.com"}))
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-warning"))
+					})
+
+					It("does not retry the mapping", func() {
+						Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the route's space is not the application's space", func() {
+					BeforeEach(func() {
+						config.DesiredApplication.SpaceGUID = "some-space-guid"
+						config.DesiredRoutes[0].SpaceGUID = "some-other-space-guid"
+						fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+					})
+
+					It("returns a RouteInDifferentSpaceError naming the route without mapping it", func() {
+						Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{Route: "some-route-1.some-domain.com"}))
+						Expect(mappedRoutes).To(BeEmpty())
+						Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the route's space is the application's space", func() {
+					BeforeEach(func() {
+						config.DesiredApplication.SpaceGUID = "some-space-guid"
+						config.DesiredRoutes[0].SpaceGUID = "some-space-guid"
+						fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+					})
+
+					It("maps the route", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(mappedRoutes).To(ContainElement(config.DesiredRoutes[0]))
+						Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(2))
+					})
+				})
+
+				Context("when the route's path is already mapped to another app", func() {
+					BeforeEach(func() {
+						config.DesiredRoutes[0].Path = "/foo"
+						fakeV2Actor.MapRouteToApplicationReturnsOnCall(0, v2action.Warnings{"map-route-warning"}, ccerror.RouteMappingTakenError{Message: "route mapping taken"})
+						fakeV2Actor.GetRouteApplicationsReturns([]v2action.Application{
+							{GUID: "some-app-guid", Name: "some-app"},
+							{GUID: "other-app-guid", Name: "other-app"},
+						}, v2action.Warnings{"get-route-applications-warning"}, nil)
+					})
+
+					It("returns a RoutePathConflictError naming the path and the owning app", func() {
+						Expect(executeErr).To(MatchError(actionerror.RoutePathConflictError{
+							Route: "some-route-1.some-domain.com/foo",
+							Path:  "/foo",
+							App:   "other-app",
+						}))
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"map-route-warning", "get-route-applications-warning"}))
+						Expect(mappedRoutes).To(BeEmpty())
+
+						Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+						Expect(fakeV2Actor.GetRouteApplicationsCallCount()).To(Equal(1))
+						Expect(fakeV2Actor.GetRouteApplicationsArgsForCall(0)).To(Equal("some-route-guid-1"))
+					})
+
+					Context("when the owning app cannot be looked up", func() {
+						BeforeEach(func() {
+							fakeV2Actor.GetRouteApplicationsReturns(nil, v2action.Warnings{"get-route-applications-warning"}, errors.New("some-lookup-error"))
+						})
+
+						It("returns a RoutePathConflictError without the app", func() {
+							Expect(executeErr).To(MatchError(actionerror.RoutePathConflictError{
+								Route: "some-route-1.some-domain.com/foo",
+								Path:  "/foo",
+							}))
+							Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ContainElement("get-route-applications-warning"))
+						})
+					})
+
+					Context("when the route is mapped through a specific app port", func() {
+						BeforeEach(func() {
+							config.DesiredRoutes[0].AppPort = types.NullInt{IsSet: true, Value: 8080}
+							fakeV2Actor.MapRouteToApplicationWithPortReturns(nil, ccerror.RouteMappingTakenError{Message: "route mapping taken"})
+						})
+
+						It("returns a RoutePathConflictError", func() {
+							Expect(executeErr).To(MatchError(actionerror.RoutePathConflictError{
+								Route: "some-route-1.some-domain.com/foo",
+								Path:  "/foo",
+								App:   "other-app",
+							}))
+							Expect(fakeV2Actor.MapRouteToApplicationWithPortCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when a route without a path is already mapped to another app", func() {
+					BeforeEach(func() {
+						fakeV2Actor.MapRouteToApplicationReturnsOnCall(0, nil, ccerror.RouteMappingTakenError{Message: "route mapping taken"})
+					})
+
+					It("returns the Cloud Controller error", func() {
+						Expect(executeErr).To(MatchError(ccerror.RouteMappingTakenError{Message: "route mapping taken"}))
+						Expect(fakeV2Actor.GetRouteApplicationsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the Cloud Controller fails transiently", func() {
+					Context("when a retry succeeds", func() {
+						BeforeEach(func() {
+							fakeV2Actor.MapRouteToApplicationReturnsOnCall(0, v2action.Warnings{"map-route-warning-1"}, ccerror.V2UnexpectedResponseError{ResponseCode: http.StatusInternalServerError})
+							fakeV2Actor.MapRouteToApplicationReturnsOnCall(1, v2action.Warnings{"map-route-warning-2"}, ccerror.ServiceUnavailableError{})
+							fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+						})
+
+						It("retries the mapping and returns the warnings from every attempt", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+							Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-warning-1", "map-route-warning-2", "map-route-warning", "map-route-warning"))
+							Expect(boundRoutes).To(BeTrue())
+
+							Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(4))
+							for i := 0; i < 3; i++ {
+								routeGUID, _ := fakeV2Actor.MapRouteToApplicationArgsForCall(i)
+								Expect(routeGUID).To(Equal("some-route-guid-1"))
+							}
+							routeGUID, _ := fakeV2Actor.MapRouteToApplicationArgsForCall(3)
+							Expect(routeGUID).To(Equal("some-route-guid-3"))
+						})
+					})
+
+					Context("when every retry fails", func() {
+						BeforeEach(func() {
+							fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, ccerror.ServiceUnavailableError{Message: "unavailable"})
+						})
+
+						It("returns the error after MapRouteRetries retries", func() {
+							Expect(executeErr).To(MatchError(ccerror.ServiceUnavailableError{Message: "unavailable"}))
+							Expect(warnings.AtLeast(SeverityWarning).Strings()).To(HaveLen(DefaultMapRouteRetries + 1))
+							Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(DefaultMapRouteRetries + 1))
+						})
+					})
+
+					Context("when MapRouteRetries is 0", func() {
+						BeforeEach(func() {
+							actor.MapRouteRetries = 0
+							fakeV2Actor.MapRouteToApplicationReturns(nil, ccerror.ServiceUnavailableError{Message: "unavailable"})
+						})
+
+						It("does not retry the mapping", func() {
+							Expect(executeErr).To(MatchError(ccerror.ServiceUnavailableError{Message: "unavailable"}))
+							Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("generic error", func() {
+					var expectedErr error
+					BeforeEach(func() {
+						expectedErr = errors.New("oh my")
+						fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, expectedErr)
+					})
+
+					It("sends the warnings and errors and returns true", func() {
+						Expect(executeErr).To(MatchError(expectedErr))
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(ConsistOf("map-route-warning"))
+					})
+
+					It("does not retry the mapping", func() {
+						Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when a later route fails", func() {
+					BeforeEach(func() {
+						fakeV2Actor.MapRouteToApplicationReturnsOnCall(0, nil, nil)
+						fakeV2Actor.MapRouteToApplicationReturnsOnCall(1, nil, errors.New("oh my"))
+					})
+
+					It("returns the routes mapped before the failure", func() {
+						Expect(executeErr).To(MatchError("oh my"))
+						Expect(boundRoutes).To(BeFalse())
+						Expect(mappedRoutes).To(Equal([]v2action.Route{config.DesiredRoutes[0]}))
+					})
+				})
+			})
+		})
+
+		Context("when no routes need to be bound", func() {
+			BeforeEach(func() {
+				config.CurrentRoutes = []v2action.Route{{GUID: "some-route-guid-1", Host: "some-route-1"}}
+				config.DesiredRoutes = config.CurrentRoutes
+			})
+
+			It("returns false and no mapped routes", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(boundRoutes).To(BeFalse())
+				Expect(mappedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when AtomicMapRoutes is set", func() {
+			BeforeEach(func() {
+				actor.AtomicMapRoutes = true
+				config.DesiredRoutes = []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-3", Host: "some-route-3"},
+				}
+				fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, nil)
+			})
+
+			Context("when the second of three mappings fails", func() {
+				BeforeEach(func() {
+					fakeV2Actor.MapRouteToApplicationReturnsOnCall(0, v2action.Warnings{"map-route-warning-1"}, nil)
+					fakeV2Actor.MapRouteToApplicationReturnsOnCall(1, v2action.Warnings{"map-route-warning-2"}, errors.New("map failed"))
+				})
+
+				It("unmaps the first route and returns the error", func() {
+					Expect(executeErr).To(MatchError("map failed"))
+					Expect(boundRoutes).To(BeFalse())
+					Expect(mappedRoutes).To(BeEmpty())
+
+					Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(2))
+					Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(1))
+					routeGUID, appGUID := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(0)
+					Expect(routeGUID).To(Equal("some-route-guid-1"))
+					Expect(appGUID).To(Equal("some-app-guid"))
+				})
+
+				It("returns the warnings from mapping and rolling back", func() {
+					Expect(warnings.Strings()).To(Equal(Warnings{"map-route-warning-1", "map-route-warning-2", "unmap-route-warning"}))
+				})
+
+				Context("when rolling back fails", func() {
+					BeforeEach(func() {
+						fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, errors.New("unmap failed"))
+					})
+
+					It("returns the mapping error with a warning about the rollback", func() {
+						Expect(executeErr).To(MatchError("map failed"))
+						Expect(warnings.Strings()).To(Equal(Warnings{
+							"map-route-warning-1",
+							"map-route-warning-2",
+							"unmap-route-warning",
+							"Unable to unmap route some-route-1. while rolling back: unmap failed",
+						}))
+					})
+				})
+			})
+
+			Context("when a route whose app port changed fails to map", func() {
+				BeforeEach(func() {
+					config.CurrentRoutes = []v2action.Route{
+						{GUID: "some-route-guid-2", Host: "some-route-2", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+					}
+					config.DesiredRoutes[1].AppPort = types.NullInt{IsSet: true, Value: 9090}
+					fakeV2Actor.MapRouteToApplicationWithPortReturnsOnCall(0, v2action.Warnings{"map-route-port-warning"}, errors.New("map failed"))
+				})
+
+				It("maps the route again with its old port", func() {
+					Expect(executeErr).To(MatchError("map failed"))
+
+					Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(2))
+					routeGUID, _ := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(0)
+					Expect(routeGUID).To(Equal("some-route-guid-2"))
+					routeGUID, _ = fakeV2Actor.UnmapRouteFromApplicationArgsForCall(1)
+					Expect(routeGUID).To(Equal("some-route-guid-1"))
+
+					Expect(fakeV2Actor.MapRouteToApplicationWithPortCallCount()).To(Equal(2))
+					routeGUID, appGUID, appPort := fakeV2Actor.MapRouteToApplicationWithPortArgsForCall(1)
+					Expect(routeGUID).To(Equal("some-route-guid-2"))
+					Expect(appGUID).To(Equal("some-app-guid"))
+					Expect(appPort).To(Equal(types.NullInt{IsSet: true, Value: 8080}))
+				})
+			})
+
+			Context("when every mapping succeeds", func() {
+				BeforeEach(func() {
+					fakeV2Actor.MapRouteToApplicationReturns(nil, nil)
+				})
+
+				It("does not roll anything back", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(mappedRoutes).To(Equal(config.DesiredRoutes))
+					Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
+	Describe("MapRoutesWithRetryBudget", func() {
+		var (
+			config ApplicationConfig
+			budget RetryBudget
+
+			boundRoutes  bool
+			mappedRoutes []v2action.Route
+			warnings     RouteWarnings
+			executeErr   error
+		)
+
+		BeforeEach(func() {
+			actor.MapRouteRetryBackoff = 0
+			config = ApplicationConfig{
+				DesiredApplication: Application{
+					Application: v2action.Application{GUID: "some-app-guid"},
+				},
+				DesiredRoutes: []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-3", Host: "some-route-3"},
+				},
+			}
+			budget = RetryBudget{Retries: types.NullInt{IsSet: true, Value: 3}}
+
+			// The first two routes each need two retries.
+			fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+			for _, call := range []int{0, 1, 3, 4} {
+				fakeV2Actor.MapRouteToApplicationReturnsOnCall(call, v2action.Warnings{"map-route-warning"}, ccerror.ServiceUnavailableError{Message: "unavailable"})
+			}
+		})
+
+		JustBeforeEach(func() {
+			_, boundRoutes, mappedRoutes, warnings, executeErr = actor.MapRoutesWithRetryBudget(context.Background(), config, budget, nil)
+		})
+
+		Context("when the budget is used up partway through", func() {
+			It("returns the next failure without retrying it", func() {
+				Expect(executeErr).To(MatchError(ccerror.ServiceUnavailableError{Message: "unavailable"}))
+				Expect(boundRoutes).To(BeFalse())
+				Expect(mappedRoutes).To(Equal([]v2action.Route{config.DesiredRoutes[0]}))
+				Expect(warnings.Strings()).To(HaveLen(5))
+
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(5))
+				for call, routeGUID := range []string{"some-route-guid-1", "some-route-guid-1", "some-route-guid-1", "some-route-guid-2", "some-route-guid-2"} {
+					actualRouteGUID, _ := fakeV2Actor.MapRouteToApplicationArgsForCall(call)
+					Expect(actualRouteGUID).To(Equal(routeGUID))
+				}
+			})
+		})
+
+		Context("when the budget covers every retry", func() {
+			BeforeEach(func() {
+				budget.Retries.Value = 4
+			})
+
+			It("maps every route", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(mappedRoutes).To(Equal(config.DesiredRoutes))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(7))
+			})
+		})
+
+		Context("when the number of retries is not limited", func() {
+			BeforeEach(func() {
+				budget = RetryBudget{}
+			})
+
+			It("only limits the retries of each route", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(mappedRoutes).To(Equal(config.DesiredRoutes))
+			})
+		})
+
+		Context("when the deadline has passed", func() {
+			BeforeEach(func() {
+				budget = RetryBudget{Deadline: time.Now().Add(-time.Second)}
+			})
+
+			It("does not retry any failure", func() {
+				Expect(executeErr).To(MatchError(ccerror.ServiceUnavailableError{Message: "unavailable"}))
+				Expect(mappedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when a retry would be made after the deadline", func() {
+			BeforeEach(func() {
+				actor.MapRouteRetryBackoff = time.Hour
+				budget = RetryBudget{Deadline: time.Now().Add(time.Minute)}
+			})
+
+			It("returns the failure without waiting", func() {
+				Expect(executeErr).To(MatchError(ccerror.ServiceUnavailableError{Message: "unavailable"}))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("EnsureRoutesMapped", func() {
+		var (
+			config ApplicationConfig
+
+			returnedConfig ApplicationConfig
+			summary        RouteSummary
+			warnings       Warnings
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			config = ApplicationConfig{
+				DesiredApplication: Application{
+					Application: v2action.Application{
+						GUID: "some-app-guid",
+					}},
+				CurrentRoutes: []v2action.Route{
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-4", Host: "some-route-4"},
+				},
+				DesiredRoutes: []v2action.Route{
+					{Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-3", Host: "some-route-3"},
+				},
+			}
+
+			fakeV2Actor.CreateRouteReturns(v2action.Route{GUID: "some-route-guid-1", Host: "some-route-1"}, v2action.Warnings{"create-route-warning"}, nil)
+			fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+			fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			returnedConfig, summary, warnings, executeErr = actor.EnsureRoutesMapped(context.Background(), config)
+		})
+
+		It("creates, maps and unmaps routes to match the desired routes", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(warnings).To(Equal(Warnings{"create-route-warning", "map-route-warning", "map-route-warning", "unmap-route-warning"}))
+
+			createdRoute := v2action.Route{GUID: "some-route-guid-1", Host: "some-route-1"}
+			Expect(summary).To(Equal(RouteSummary{
+				Created:  []v2action.Route{createdRoute},
+				Mapped:   []v2action.Route{createdRoute, {GUID: "some-route-guid-3", Host: "some-route-3"}},
+				Unmapped: []v2action.Route{{GUID: "some-route-guid-4", Host: "some-route-4"}},
+			}))
+			Expect(returnedConfig.CurrentRoutes).To(Equal([]v2action.Route{
+				createdRoute,
+				{GUID: "some-route-guid-2", Host: "some-route-2"},
+				{GUID: "some-route-guid-3", Host: "some-route-3"},
+			}))
+
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(1))
+			Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(2))
+			Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(1))
+			routeGUID, appGUID := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(0)
+			Expect(routeGUID).To(Equal("some-route-guid-4"))
+			Expect(appGUID).To(Equal("some-app-guid"))
+		})
+
+		It("does nothing when run again with the returned config", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			secondConfig, secondSummary, secondWarnings, err := actor.EnsureRoutesMapped(context.Background(), returnedConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secondWarnings).To(BeEmpty())
+			Expect(secondSummary).To(Equal(RouteSummary{}))
+			Expect(secondConfig).To(Equal(returnedConfig))
+
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(1))
+			Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(2))
+			Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(1))
+		})
+
+		Context("when no-route is set", func() {
+			BeforeEach(func() {
+				config.NoRoute = true
+			})
+
+			It("unmaps all of the current routes without creating or mapping any", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"unmap-route-warning", "unmap-route-warning"}))
+				Expect(summary).To(Equal(RouteSummary{Unmapped: config.CurrentRoutes}))
+				Expect(returnedConfig.CurrentRoutes).To(BeEmpty())
+
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the actor is in dry run mode", func() {
+			BeforeEach(func() {
+				actor.DryRun = true
+			})
+
+			It("does not change any routes and reports what would have been done", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+				Expect(summary).To(Equal(RouteSummary{
+					Created:  []v2action.Route{{Host: "some-route-1"}},
+					Mapped:   []v2action.Route{{Host: "some-route-1"}, {GUID: "some-route-guid-3", Host: "some-route-3"}},
+					Unmapped: []v2action.Route{{GUID: "some-route-guid-4", Host: "some-route-4"}},
+				}))
+
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when mapping a route errors", func() {
+			BeforeEach(func() {
+				fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, errors.New("map failed"))
+			})
+
+			It("returns the error and warnings without unmapping any routes", func() {
+				Expect(executeErr).To(MatchError("map failed"))
+				Expect(warnings).To(Equal(Warnings{"create-route-warning", "map-route-warning"}))
+				Expect(summary.Created).To(HaveLen(1))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when unmapping a route errors", func() {
+			BeforeEach(func() {
+				fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, errors.New("unmap failed"))
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError("unmap failed"))
+				Expect(warnings).To(Equal(Warnings{"create-route-warning", "map-route-warning", "map-route-warning", "unmap-route-warning"}))
+			})
+		})
+	})
+
+	Describe("ReconcileRoutes", func() {
+		var (
+			config ApplicationConfig
+
+			returnedConfig ApplicationConfig
+			summary        RouteSummary
+			warnings       Warnings
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			config = ApplicationConfig{
+				DesiredApplication: Application{
+					Application: v2action.Application{
+						GUID: "some-app-guid",
+					}},
+				CurrentRoutes: []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+				},
+			}
+
+			fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+			fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			returnedConfig, summary, warnings, executeErr = actor.ReconcileRoutes(context.Background(), config)
+		})
+
+		Context("when routes are only added", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-3", Host: "some-route-3"},
+				}
+			})
+
+			It("maps the added routes without unmapping any", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"map-route-warning"}))
+				Expect(summary).To(Equal(RouteSummary{
+					Mapped: []v2action.Route{{GUID: "some-route-guid-3", Host: "some-route-3"}},
+				}))
+				Expect(returnedConfig.CurrentRoutes).To(Equal(config.DesiredRoutes))
+
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+				routeGUID, appGUID := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+				Expect(routeGUID).To(Equal("some-route-guid-3"))
+				Expect(appGUID).To(Equal("some-app-guid"))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when routes are only removed", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = []v2action.Route{
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+				}
+			})
+
+			It("unmaps the removed routes without mapping any", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"unmap-route-warning"}))
+				Expect(summary).To(Equal(RouteSummary{
+					Unmapped: []v2action.Route{{GUID: "some-route-guid-1", Host: "some-route-1"}},
+				}))
+				Expect(returnedConfig.CurrentRoutes).To(Equal([]v2action.Route{{GUID: "some-route-guid-2", Host: "some-route-2"}}))
+
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(1))
+				routeGUID, appGUID := fakeV2Actor.UnmapRouteFromApplicationArgsForCall(0)
+				Expect(routeGUID).To(Equal("some-route-guid-1"))
+				Expect(appGUID).To(Equal("some-app-guid"))
+			})
+		})
+
+		Context("when routes are added and removed", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = []v2action.Route{
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-3", Host: "some-route-3"},
+				}
+			})
+
+			It("maps the added routes and then unmaps the removed ones", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"map-route-warning", "unmap-route-warning"}))
+				Expect(summary).To(Equal(RouteSummary{
+					Mapped:   []v2action.Route{{GUID: "some-route-guid-3", Host: "some-route-3"}},
+					Unmapped: []v2action.Route{{GUID: "some-route-guid-1", Host: "some-route-1"}},
+				}))
+				Expect(returnedConfig.CurrentRoutes).To(Equal(config.DesiredRoutes))
+
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(1))
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+			})
+
+			Context("when mapping a route errors", func() {
+				BeforeEach(func() {
+					fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, errors.New("map failed"))
+				})
+
+				It("returns the error without unmapping any routes", func() {
+					Expect(executeErr).To(MatchError("map failed"))
+					Expect(warnings).To(Equal(Warnings{"map-route-warning"}))
+					Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the routes have not changed", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = config.CurrentRoutes
+			})
+
+			It("does nothing", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+				Expect(summary).To(Equal(RouteSummary{}))
+				Expect(returnedConfig).To(Equal(config))
+
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("CalculateRoutes", func() {
+		var (
+			routes         []string
+			orgGUID        string
+			spaceGUID      string
+			existingRoutes []v2action.Route
+
+			calculatedRoutes []v2action.Route
+			warnings         RouteWarnings
+			executeErr       error
+		)
+
+		BeforeEach(func() {
+			routes = []string{
+				"a.com",
 				"b.a.com",
 				"c.b.a.com",
 				"d.c.b.a.com",
 				"a.com/some-path",
 			}
-			orgGUID = "some-org-guid"
-			spaceGUID = "some-space-guid"
+			orgGUID = "some-org-guid"
+			spaceGUID = "some-space-guid"
+		})
+
+		JustBeforeEach(func() {
+			calculatedRoutes, warnings, executeErr = actor.CalculateRoutes(context.Background(), routes, orgGUID, spaceGUID, existingRoutes, false)
+		})
+
+		Context("when there are no known routes", func() {
+			BeforeEach(func() {
+				existingRoutes = []v2action.Route{{
+					GUID: "some-route-5",
+					Host: "banana",
+					Domain: v2action.Domain{
+						GUID: "domain-guid-1",
+						Name: "a.com",
+					},
+					SpaceGUID: spaceGUID,
+				}}
+			})
+
+			Context("when a route looking up the domains is succuessful", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+						{GUID: "domain-guid-1", Name: "a.com"},
+						{GUID: "domain-guid-2", Name: "b.a.com"},
+					}, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, nil)
+				})
+
+				Context("when the route is invalid", func() {
+					BeforeEach(func() {
+						routes = []string{"a.com", "b.a.com", "c.b.a.com:1234"}
+					})
+
+					It("returns back warnings and error", func() {
+						Expect(executeErr).To(MatchError(actionerror.PortWithHTTPDomainError{Route: "c.b.a.com:1234", Domain: "b.a.com"}))
+						Expect(warnings.Strings()).To(ConsistOf("domain-warnings-1", "domains-warnings-2"))
+					})
+				})
+
+				Context("when the route existance check is successful", func() {
+					BeforeEach(func() {
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteNotFoundError{})
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturnsOnCall(3, v2action.Route{
+							GUID: "route-guid-4",
+							Host: "d.c",
+							Domain: v2action.Domain{
+								GUID: "domain-guid-2",
+								Name: "b.a.com",
+							},
+							SpaceGUID: spaceGUID,
+						}, v2action.Warnings{"find-route-warning"}, nil)
+					})
+
+					It("returns new and existing routes", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(warnings.Strings()).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "find-route-warning", "find-route-warning", "find-route-warning", "find-route-warning", "find-route-warning"))
+						Expect(calculatedRoutes).To(ConsistOf(
+							v2action.Route{
+								Domain: v2action.Domain{
+									GUID: "domain-guid-1",
+									Name: "a.com",
+								},
+								SpaceGUID: spaceGUID,
+							},
+							v2action.Route{
+								Domain: v2action.Domain{
+									GUID: "domain-guid-2",
+									Name: "b.a.com",
+								},
+								SpaceGUID: spaceGUID,
+							},
+							v2action.Route{
+								Host: "c",
+								Domain: v2action.Domain{
+									GUID: "domain-guid-2",
+									Name: "b.a.com",
+								},
+								SpaceGUID: spaceGUID,
+							},
+							v2action.Route{
+								GUID: "route-guid-4",
+								Host: "d.c",
+								Domain: v2action.Domain{
+									GUID: "domain-guid-2",
+									Name: "b.a.com",
+								},
+								SpaceGUID: spaceGUID,
+							},
+							v2action.Route{
+								GUID: "some-route-5",
+								Host: "banana",
+								Domain: v2action.Domain{
+									GUID: "domain-guid-1",
+									Name: "a.com",
+								},
+								SpaceGUID: spaceGUID,
+							},
+							v2action.Route{
+								Host: "",
+								Domain: v2action.Domain{
+									GUID: "domain-guid-1",
+									Name: "a.com",
+								},
+								Path:      "/some-path",
+								SpaceGUID: spaceGUID,
+							}))
+
+						Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+						domains, passedOrgGUID := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+						Expect(domains).To(ConsistOf("a.com", "b.a.com", "c.b.a.com", "d.c.b.a.com"))
+						Expect(passedOrgGUID).To(Equal(orgGUID))
+
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(5))
+						// One check is enough here - checking 4th call since it's the only
+						// existing one.
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(3)).To(Equal(v2action.Route{
+							Host: "d.c",
+							Domain: v2action.Domain{
+								GUID: "domain-guid-2",
+								Name: "b.a.com",
+							},
+							SpaceGUID: spaceGUID,
+						}))
+					})
+				})
+
+				Context("when the route existance check fails", func() {
+					var expectedErr error
+
+					BeforeEach(func() {
+						expectedErr = errors.New("oh noes")
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, expectedErr)
+					})
+
+					It("returns back warnings and error", func() {
+						Expect(executeErr).To(MatchError(expectedErr))
+						Expect(warnings.Strings()).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "find-route-warning"))
+					})
+				})
+
+				Context("when a route existance check fails after others succeed", func() {
+					var expectedErr error
+
+					BeforeEach(func() {
+						expectedErr = errors.New("oh noes")
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsStub = func(route v2action.Route) (v2action.Route, v2action.Warnings, error) {
+							if fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount() == 3 {
+								return v2action.Route{}, v2action.Warnings{"find-route-warning"}, expectedErr
+							}
+							return v2action.Route{}, nil, actionerror.RouteNotFoundError{}
+						}
+					})
+
+					It("returns the routes calculated before the failure along with the error", func() {
+						Expect(executeErr).To(MatchError(expectedErr))
+						Expect(warnings.Strings()).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "find-route-warning"))
+						Expect(calculatedRoutes).To(Equal([]v2action.Route{
+							existingRoutes[0],
+							{
+								Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "a.com"},
+								SpaceGUID: spaceGUID,
+							},
+							{
+								Domain:    v2action.Domain{GUID: "domain-guid-2", Name: "b.a.com"},
+								SpaceGUID: spaceGUID,
+							},
+						}))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(3))
+					})
+				})
+
+				Context("when one of the domains does not exist", func() {
+					BeforeEach(func() {
+						fakeV2Actor.GetDomainsByNameAndOrganizationReturns(nil, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, nil)
+					})
+
+					It("returns back warnings and an error listing every route", func() {
+						Expect(executeErr).To(MatchError(actionerror.InvalidRoutesError{Routes: []actionerror.InvalidRoute{
+							{Route: "a.com", Err: actionerror.NoMatchingDomainError{Route: "a.com"}},
+							{Route: "b.a.com", Err: actionerror.NoMatchingDomainError{Route: "b.a.com"}},
+							{Route: "c.b.a.com", Err: actionerror.NoMatchingDomainError{Route: "c.b.a.com"}},
+							{Route: "d.c.b.a.com", Err: actionerror.NoMatchingDomainError{Route: "d.c.b.a.com"}},
+							{Route: "a.com/some-path", Err: actionerror.NoMatchingDomainError{Route: "a.com/some-path"}},
+						}}))
+						Expect(warnings.Strings()).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "domain-warnings-1", "domains-warnings-2"))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when looking up a domain returns an error", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					expectedErr = errors.New("po-tate-toe")
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns(nil, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, expectedErr)
+				})
+
+				It("returns back warnings and error", func() {
+					Expect(executeErr).To(MatchError(expectedErr))
+					Expect(warnings.Strings()).To(ConsistOf("domain-warnings-1", "domains-warnings-2"))
+				})
+			})
+		})
+
+		Context("when the routes differ only in case", func() {
+			BeforeEach(func() {
+				existingRoutes = nil
+				routes = []string{
+					"App.Example.com",
+					"app.example.com/some-path",
+					"APP.EXAMPLE.COM/other-path",
+				}
+
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid-1", Name: "example.com"},
+					{GUID: "domain-guid-2", Name: "app.example.com"},
+				}, nil, nil)
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+			})
+
+			It("looks up each lowercased domain once, in sorted order", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				domains, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+				Expect(domains).To(Equal([]string{"app.example.com", "example.com"}))
+			})
+
+			It("matches the routes to the lowercased domains", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(3))
+				for _, route := range calculatedRoutes {
+					Expect(route.Domain.Name).To(Equal("app.example.com"))
+				}
+			})
+		})
+
+		Context("when the routes contain internationalized hostnames", func() {
+			BeforeEach(func() {
+				existingRoutes = nil
+				routes = []string{
+					"app.café.com",
+					"APP.CAFÉ.com/some-path",
+					"app.xn--caf-dma.com",
+				}
+
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid-1", Name: "xn--caf-dma.com"},
+				}, nil, nil)
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+			})
+
+			It("looks up the domains using their punycode form", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				domains, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+				Expect(domains).To(ConsistOf("xn--caf-dma.com", "app.xn--caf-dma.com"))
+			})
+
+			It("returns routes with the punycode hostnames", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+
+				punycodeRoute := v2action.Route{
+					Host:      "app",
+					Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "xn--caf-dma.com"},
+					SpaceGUID: spaceGUID,
+				}
+				punycodePathRoute := punycodeRoute
+				punycodePathRoute.Path = "/some-path"
+				Expect(calculatedRoutes).To(Equal([]v2action.Route{punycodeRoute, punycodePathRoute}))
+			})
+
+			Context("when a hostname cannot be converted to punycode", func() {
+				BeforeEach(func() {
+					routes = []string{"\u0300café.com"}
+				})
+
+				It("returns an InvalidRouteHostnameError", func() {
+					Expect(executeErr).To(BeAssignableToTypeOf(actionerror.InvalidRouteHostnameError{}))
+					Expect(executeErr.(actionerror.InvalidRouteHostnameError).Hostname).To(Equal("\u0300café.com"))
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when there are known routes", func() {
+			BeforeEach(func() {
+				existingRoutes = []v2action.Route{{
+					GUID: "route-guid-4",
+					Host: "d.c",
+					Domain: v2action.Domain{
+						GUID: "domain-guid-2",
+						Name: "b.a.com",
+					},
+					SpaceGUID: spaceGUID,
+				}}
+
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid-1", Name: "a.com"},
+					{GUID: "domain-guid-2", Name: "b.a.com"},
+				}, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, nil)
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteNotFoundError{})
+			})
+
+			It("does not lookup known routes", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(warnings.Strings()).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "find-route-warning", "find-route-warning", "find-route-warning", "find-route-warning"))
+				Expect(calculatedRoutes).To(ConsistOf(
+					v2action.Route{
+						Domain: v2action.Domain{
+							GUID: "domain-guid-1",
+							Name: "a.com",
+						},
+						SpaceGUID: spaceGUID,
+					},
+					v2action.Route{
+						Domain: v2action.Domain{
+							GUID: "domain-guid-2",
+							Name: "b.a.com",
+						},
+						SpaceGUID: spaceGUID,
+					},
+					v2action.Route{
+						Host: "c",
+						Domain: v2action.Domain{
+							GUID: "domain-guid-2",
+							Name: "b.a.com",
+						},
+						SpaceGUID: spaceGUID,
+					},
+					v2action.Route{
+						GUID: "route-guid-4",
+						Host: "d.c",
+						Domain: v2action.Domain{
+							GUID: "domain-guid-2",
+							Name: "b.a.com",
+						},
+						SpaceGUID: spaceGUID,
+					},
+					v2action.Route{
+						Host: "",
+						Domain: v2action.Domain{
+							GUID: "domain-guid-1",
+							Name: "a.com",
+						},
+						Path:      "/some-path",
+						SpaceGUID: spaceGUID,
+					}))
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				domains, passedOrgGUID := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+				Expect(domains).To(ConsistOf("a.com", "b.a.com", "c.b.a.com"))
+				Expect(passedOrgGUID).To(Equal(orgGUID))
+			})
+		})
+	})
+
+	Describe("route conflicts in CalculateRoutes", func() {
+		var (
+			domain     v2action.Domain
+			warnings   RouteWarnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			domain = v2action.Domain{GUID: "domain-guid-1", Name: "example.com", Type: constant.SharedDomain}
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteInDifferentSpaceError{Route: "app.example.com"})
+		})
+
+		JustBeforeEach(func() {
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+			_, warnings, executeErr = actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+		})
+
+		Context("when the route's domain is shared", func() {
+			It("returns a RouteOwnedByOtherOrganizationError without an organization", func() {
+				Expect(executeErr).To(MatchError(actionerror.RouteOwnedByOtherOrganizationError{Route: "app.example.com"}))
+				Expect(warnings.Strings()).To(ConsistOf("find-route-warning"))
+				Expect(fakeV2Actor.GetOrganizationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the route's domain is private and owned by the targeted organization", func() {
+			BeforeEach(func() {
+				domain.Type = constant.PrivateDomain
+				domain.OwningOrganizationGUID = "some-org-guid"
+			})
+
+			It("returns a RouteOwnedByOtherOrganizationError without an organization", func() {
+				Expect(executeErr).To(MatchError(actionerror.RouteOwnedByOtherOrganizationError{Route: "app.example.com"}))
+				Expect(fakeV2Actor.GetOrganizationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the route's domain is private and owned by another organization", func() {
+			BeforeEach(func() {
+				domain.Type = constant.PrivateDomain
+				domain.OwningOrganizationGUID = "other-org-guid"
+				fakeV2Actor.GetOrganizationReturns(v2action.Organization{GUID: "other-org-guid", Name: "other-org"}, v2action.Warnings{"get-org-warning"}, nil)
+			})
+
+			It("returns a RouteOwnedByOtherOrganizationError naming the owning organization", func() {
+				Expect(executeErr).To(MatchError(actionerror.RouteOwnedByOtherOrganizationError{
+					Route:        "app.example.com",
+					Organization: "other-org",
+				}))
+				Expect(warnings.Strings()).To(ConsistOf("find-route-warning", "get-org-warning"))
+
+				Expect(fakeV2Actor.GetOrganizationCallCount()).To(Equal(1))
+				Expect(fakeV2Actor.GetOrganizationArgsForCall(0)).To(Equal("other-org-guid"))
+			})
+
+			Context("when looking up the owning organization fails", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetOrganizationReturns(v2action.Organization{}, v2action.Warnings{"get-org-warning"}, errors.New("forbidden"))
+				})
+
+				It("returns a RouteOwnedByOtherOrganizationError without an organization", func() {
+					Expect(executeErr).To(MatchError(actionerror.RouteOwnedByOtherOrganizationError{Route: "app.example.com"}))
+					Expect(warnings.Strings()).To(ConsistOf("find-route-warning", "get-org-warning"))
+				})
+			})
+		})
+	})
+
+	Describe("deduplicating routes in CalculateRoutes", func() {
+		var (
+			domain           v2action.Domain
+			routes           []string
+			existingRoutes   []v2action.Route
+			calculatedRoutes []v2action.Route
+			executeErr       error
+		)
+
+		BeforeEach(func() {
+			domain = v2action.Domain{GUID: "domain-guid-1", Name: "example.com"}
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+			existingRoutes = nil
+		})
+
+		JustBeforeEach(func() {
+			calculatedRoutes, _, executeErr = actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", existingRoutes, false)
+		})
+
+		Context("when a route is listed more than once", func() {
+			BeforeEach(func() {
+				routes = []string{"b.example.com", "a.example.com", "b.example.com", "a.example.com"}
+			})
+
+			It("looks up and returns each route once, in the order first listed", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(Equal([]v2action.Route{
+					{Host: "b", Domain: domain, SpaceGUID: "some-space-guid"},
+					{Host: "a", Domain: domain, SpaceGUID: "some-space-guid"},
+				}))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when routes are written differently but have the same settings", func() {
+			BeforeEach(func() {
+				routes = []string{"app.example.com/foo", "http://APP.example.com/foo/", "https://APP.example.com/FOO", "app.example.com/bar"}
+			})
+
+			It("looks up and returns the first of them", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(Equal([]v2action.Route{
+					{Host: "app", Domain: domain, Path: "/foo", SpaceGUID: "some-space-guid"},
+					{Host: "app", Domain: domain, Path: "/bar", SpaceGUID: "some-space-guid"},
+				}))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when a route that is looked up turns out to be an existing route", func() {
+			var existingRoute v2action.Route
+
+			BeforeEach(func() {
+				existingRoute = v2action.Route{GUID: "route-guid-1", Host: "app", Domain: domain, Path: "/foo", SpaceGUID: "some-space-guid"}
+				existingRoutes = []v2action.Route{existingRoute}
+				// The route does not match the existing route by name, so it is
+				// looked up.
+				routes = []string{"app.example.com/foo/bar/.."}
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(existingRoute, nil, nil)
+			})
+
+			It("returns the route once", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(Equal([]v2action.Route{existingRoute}))
+			})
+		})
+	})
+
+	Describe("detecting hosts in multiple domains in CalculateRoutes", func() {
+		var (
+			routes     []string
+			warnings   RouteWarnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+				{GUID: "domain-guid-2", Name: "example.org"},
+				{GUID: "domain-guid-3", Name: "example.net"},
+			}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+			routes = []string{"app.example.com", "APP.example.org", "app.example.com/path", "other.example.com", "app.example.net"}
+		})
+
+		JustBeforeEach(func() {
+			_, warnings, executeErr = actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", nil, false)
+		})
+
+		Context("when the check is not enabled", func() {
+			It("does not warn about the host", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("when the check is enabled", func() {
+			BeforeEach(func() {
+				actor.WarnOnHostInMultipleDomains = true
+			})
+
+			It("warns once about each host used with more than one domain", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(Equal(RouteWarnings{{
+					Message:  "Host app is used with more than one domain: example.com, example.org, example.net.",
+					Severity: SeverityWarning,
+				}}))
+			})
+
+			Context("when every host uses a single domain", func() {
+				BeforeEach(func() {
+					routes = []string{"app.example.com", "app.example.com/path", "other.example.org"}
+				})
+
+				It("does not warn", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("detecting routes in other spaces in CalculateRoutes", func() {
+		var (
+			domain           v2action.Domain
+			calculatedRoutes []v2action.Route
+			warnings         RouteWarnings
+			executeErr       error
+		)
+
+		BeforeEach(func() {
+			domain = v2action.Domain{GUID: "domain-guid-1", Name: "example.com"}
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteNotFoundError{})
+		})
+
+		JustBeforeEach(func() {
+			calculatedRoutes, warnings, executeErr = actor.CalculateRoutes(context.Background(), []string{"app.example.com/some-path"}, "some-org-guid", "some-space-guid", nil, false)
+		})
+
+		It("looks up the routes with the same host and domain in the organization", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeV2Actor.GetOrganizationRoutesWithHostAndDomainCallCount()).To(Equal(1))
+			route, orgGUID := fakeV2Actor.GetOrganizationRoutesWithHostAndDomainArgsForCall(0)
+			Expect(route).To(Equal(v2action.Route{Host: "app", Domain: domain, Path: "/some-path", SpaceGUID: "some-space-guid"}))
+			Expect(orgGUID).To(Equal("some-org-guid"))
+		})
+
+		Context("when a route with the same host and domain exists in another space", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns([]v2action.Route{
+					{GUID: "route-guid-1", Host: "app", Domain: domain, SpaceGUID: "other-space-guid"},
+				}, v2action.Warnings{"org-routes-warning"}, nil)
+			})
+
+			It("flags the partial route and warns about the possible conflict", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(Equal([]v2action.Route{
+					{Host: "app", Domain: domain, Path: "/some-path", SpaceGUID: "some-space-guid", ExistsInOtherSpace: true},
+				}))
+				Expect(warnings).To(Equal(RouteWarnings{
+					{Message: "find-route-warning", Severity: SeverityWarning},
+					{Message: "org-routes-warning", Severity: SeverityWarning},
+					{Message: "Route app.example.com/some-path does not exist in this space, but a route with the same host and domain exists in another space of the organization.", Severity: SeverityWarning},
+				}))
+			})
+		})
+
+		Context("when the routes with the same host and domain are in the same space", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns([]v2action.Route{
+					{GUID: "route-guid-1", Host: "app", Domain: domain, SpaceGUID: "some-space-guid"},
+				}, nil, nil)
+			})
+
+			It("does not flag the partial route", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(calculatedRoutes[0].ExistsInOtherSpace).To(BeFalse())
+				Expect(warnings.Strings()).To(ConsistOf("find-route-warning"))
+			})
+		})
+
+		Context("when looking up the organization's routes fails", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns(nil, v2action.Warnings{"org-routes-warning"}, errors.New("forbidden"))
+			})
+
+			It("returns the partial route without flagging it", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(calculatedRoutes[0].ExistsInOtherSpace).To(BeFalse())
+				Expect(warnings.Strings()).To(ConsistOf("find-route-warning", "org-routes-warning"))
+			})
+		})
+
+		Context("when the route already exists in the space", func() {
+			BeforeEach(func() {
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{GUID: "route-guid-1", Host: "app", Domain: domain, Path: "/some-path", SpaceGUID: "some-space-guid"}, nil, nil)
+			})
+
+			It("does not look up the organization's routes", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeV2Actor.GetOrganizationRoutesWithHostAndDomainCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when FailOnRouteInOtherSpace is set", func() {
+			BeforeEach(func() {
+				actor.FailOnRouteInOtherSpace = true
+			})
+
+			Context("when the route exists in another space", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns([]v2action.Route{
+						{GUID: "route-guid-1", Host: "app", Domain: domain, Path: "/some-path/", SpaceGUID: "other-space-guid"},
+					}, v2action.Warnings{"org-routes-warning"}, nil)
+				})
+
+				It("returns a RouteInDifferentSpaceError without looking the route up in the space", func() {
+					Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{Route: "app.example.com/some-path"}))
+					Expect(calculatedRoutes).To(BeNil())
+					Expect(warnings.Strings()).To(ConsistOf("org-routes-warning"))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when only a route with a different path exists in another space", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns([]v2action.Route{
+						{GUID: "route-guid-1", Host: "app", Domain: domain, Path: "/other-path", SpaceGUID: "other-space-guid"},
+					}, nil, nil)
+				})
+
+				It("calculates the route", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(calculatedRoutes).To(HaveLen(1))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when looking up the organization's routes fails", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					expectedErr = errors.New("forbidden")
+					fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns(nil, v2action.Warnings{"org-routes-warning"}, expectedErr)
+				})
+
+				It("returns the error", func() {
+					Expect(executeErr).To(MatchError(expectedErr))
+					Expect(warnings.Strings()).To(ConsistOf("org-routes-warning"))
+				})
+			})
+		})
+
+		Context("when FailOnRouteInOtherSpace is not set and the route exists in another space", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns([]v2action.Route{
+					{GUID: "route-guid-1", Host: "app", Domain: domain, Path: "/some-path", SpaceGUID: "other-space-guid"},
+				}, nil, nil)
+			})
+
+			It("does not return an error", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(calculatedRoutes[0].ExistsInOtherSpace).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("validating routes in CalculateRoutes", func() {
+		var (
+			routes         []string
+			randomTCPPorts bool
+			warnings       RouteWarnings
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			randomTCPPorts = false
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+				{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup},
+			}, v2action.Warnings{"domain-warning"}, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		JustBeforeEach(func() {
+			_, warnings, executeErr = actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", nil, randomTCPPorts)
+		})
+
+		Context("when several routes are invalid", func() {
+			BeforeEach(func() {
+				routes = []string{
+					"valid.example.com",
+					"app.example.com:1234",
+					"app.example.com/foo?bar=baz",
+					"app.unknown.org",
+					"tcp.example.com:6005-6000",
+					"host.tcp.example.com:1234",
+				}
+			})
+
+			It("returns an error listing every invalid route and its problem", func() {
+				Expect(executeErr).To(MatchError(actionerror.InvalidRoutesError{Routes: []actionerror.InvalidRoute{
+					{Route: "tcp.example.com:6005-6000", Err: actionerror.InvertedRoutePortRangeError{Route: "tcp.example.com:6005-6000"}},
+					{Route: "app.example.com/foo?bar=baz", Err: actionerror.RouteQueryOrFragmentError{Route: "app.example.com/foo?bar=baz"}},
+					{Route: "app.example.com:1234", Err: actionerror.PortWithHTTPDomainError{Route: "app.example.com:1234", Domain: "example.com"}},
+					{Route: "app.unknown.org", Err: actionerror.NoMatchingDomainError{Route: "app.unknown.org"}},
+					{Route: "host.tcp.example.com:1234", Err: actionerror.InvalidTCPRouteSettings{Domain: "tcp.example.com"}},
+				}}))
+				Expect(warnings.Strings()).To(ConsistOf("domain-warning", "domain-warning"))
+			})
+
+			It("does not look up any routes", func() {
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when route validation is skipped", func() {
+			BeforeEach(func() {
+				actor.SkipRouteValidation = true
+				routes = []string{"valid.example.com", "host.tcp.example.com:1234"}
+			})
+
+			It("does not validate the routes and looks them all up", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(2))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(1)).To(Equal(v2action.Route{
+					Host:      "host",
+					Domain:    v2action.Domain{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup},
+					Port:      types.NullInt{IsSet: true, Value: 1234},
+					SpaceGUID: "some-space-guid",
+				}))
+			})
+
+			Context("when a route cannot be parsed", func() {
+				BeforeEach(func() {
+					routes = append(routes, "app.example.com/foo?bar=baz")
+				})
+
+				It("still rejects it", func() {
+					Expect(executeErr).To(MatchError(actionerror.RouteQueryOrFragmentError{Route: "app.example.com/foo?bar=baz"}))
+				})
+			})
+		})
+
+		Context("when a route is on the internal domain name", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "internal-domain-guid", Name: "apps.internal"},
+				}, v2action.Warnings{"domain-warning"}, nil)
+			})
+
+			Context("when the route only has a host", func() {
+				BeforeEach(func() {
+					routes = []string{"app.apps.internal"}
+				})
+
+				It("treats the domain as internal", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)).To(Equal(v2action.Route{
+						Host:      "app",
+						Domain:    v2action.Domain{GUID: "internal-domain-guid", Name: "apps.internal", Internal: true},
+						SpaceGUID: "some-space-guid",
+					}))
+				})
+			})
+
+			Context("when the route has a path", func() {
+				BeforeEach(func() {
+					routes = []string{"app.apps.internal/some-path"}
+				})
+
+				It("returns an InvalidInternalRouteSettings error", func() {
+					Expect(executeErr).To(MatchError(actionerror.InvalidInternalRouteSettings{Domain: "apps.internal"}))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when a domain is shared into the organization after it is first looked up", func() {
+			var sharedDomain v2action.Domain
+
+			BeforeEach(func() {
+				routes = []string{"valid.example.com", "app.shared.org"}
+				sharedDomain = v2action.Domain{GUID: "shared-domain-guid", Name: "shared.org"}
+
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturnsOnCall(0, []v2action.Domain{
+					{GUID: "domain-guid-1", Name: "example.com"},
+				}, v2action.Warnings{"domain-warning"}, nil)
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturnsOnCall(1, []v2action.Domain{sharedDomain}, v2action.Warnings{"retry-domain-warning"}, nil)
+			})
+
+			It("looks up the missing domains once more and uses the domain that appeared", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings.Strings()).To(ConsistOf("domain-warning", "retry-domain-warning"))
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+				retriedNames, orgGUID := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(1)
+				Expect(retriedNames).To(ConsistOf("app.shared.org", "shared.org"))
+				Expect(orgGUID).To(Equal("some-org-guid"))
+
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(2))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(1)).To(Equal(v2action.Route{
+					Host:      "app",
+					Domain:    sharedDomain,
+					SpaceGUID: "some-space-guid",
+				}))
+			})
+
+			Context("when there is a DomainCache", func() {
+				BeforeEach(func() {
+					actor.DomainCache = NewDomainCache(DefaultDomainCacheTTL)
+				})
+
+				It("caches the domain that appeared for later lookups", func() {
+					_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.shared.org"}, "some-org-guid", "some-space-guid", nil, randomTCPPorts)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when the domain is still missing after looking it up again", func() {
+			BeforeEach(func() {
+				routes = []string{"valid.example.com", "app.unknown.org"}
+			})
+
+			It("only retries the lookup once", func() {
+				Expect(executeErr).To(MatchError(actionerror.NoMatchingDomainError{Route: "app.unknown.org"}))
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when looking the missing domains up again fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				routes = []string{"app.unknown.org"}
+				expectedErr = errors.New("retry failed")
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturnsOnCall(1, nil, v2action.Warnings{"retry-domain-warning"}, expectedErr)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings.Strings()).To(ConsistOf("domain-warning", "retry-domain-warning"))
+			})
+		})
+
+		Context("when only one route is invalid", func() {
+			BeforeEach(func() {
+				routes = []string{"valid.example.com", "app.example.com:1234", "other.example.com"}
+			})
+
+			It("returns that route's error without looking up any routes", func() {
+				Expect(executeErr).To(MatchError(actionerror.PortWithHTTPDomainError{Route: "app.example.com:1234", Domain: "example.com"}))
+				Expect(warnings.Strings()).To(ConsistOf("domain-warning"))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a TCP route does not specify a port", func() {
+			BeforeEach(func() {
+				routes = []string{"tcp.example.com"}
+			})
+
+			Context("when random TCP ports are requested", func() {
+				BeforeEach(func() {
+					randomTCPPorts = true
+				})
+
+				It("looks up the route without a port", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0).Port.IsSet).To(BeFalse())
+				})
+			})
+
+			Context("when random TCP ports are not requested", func() {
+				It("returns a TCPRouteMissingPortError without looking up any routes", func() {
+					Expect(executeErr).To(MatchError(actionerror.TCPRouteMissingPortError{Route: "tcp.example.com"}))
+					Expect(warnings.Strings()).To(ConsistOf("domain-warning"))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when a TCP route specifies a port", func() {
+			BeforeEach(func() {
+				routes = []string{"tcp.example.com:1234"}
+			})
+
+			It("looks up the route with that port", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0).Port).To(Equal(types.NullInt{IsSet: true, Value: 1234}))
+			})
+		})
+
+		Context("when a route has the wildcard host", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid-1", Name: "example.com", Type: constant.SharedDomain},
+					{GUID: "private-domain-guid", Name: "apps.example.com", Type: constant.PrivateDomain},
+				}, v2action.Warnings{"domain-warning"}, nil)
+			})
+
+			Context("when the domain allows wildcard hosts", func() {
+				BeforeEach(func() {
+					routes = []string{"*.apps.example.com"}
+				})
+
+				It("looks up the route with the wildcard host", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)).To(Equal(v2action.Route{
+						Host:      "*",
+						Domain:    v2action.Domain{GUID: "private-domain-guid", Name: "apps.example.com", Type: constant.PrivateDomain},
+						SpaceGUID: "some-space-guid",
+					}))
+				})
+			})
+
+			Context("when the domain does not allow wildcard hosts", func() {
+				BeforeEach(func() {
+					routes = []string{"*.example.com"}
+				})
+
+				It("returns a WildcardHostNotAllowedError without looking up any routes", func() {
+					Expect(executeErr).To(MatchError(actionerror.WildcardHostNotAllowedError{Domain: "example.com"}))
+					Expect(warnings.Strings()).To(ConsistOf("domain-warning"))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when a route has no host", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid-1", Name: "example.com", Type: constant.SharedDomain},
+					{GUID: "private-domain-guid", Name: "private.org", Type: constant.PrivateDomain},
+					{GUID: "tcp-domain-guid", Name: "tcp.example.com", Type: constant.SharedDomain, RouterGroupType: constant.TCPRouterGroup},
+				}, v2action.Warnings{"domain-warning"}, nil)
+			})
+
+			Context("when the domain is a shared HTTP domain", func() {
+				BeforeEach(func() {
+					routes = []string{"example.com/some-path"}
+				})
+
+				It("returns a NoHostnameAndSharedDomainError without looking up any routes", func() {
+					Expect(executeErr).To(MatchError(actionerror.NoHostnameAndSharedDomainError{}))
+					Expect(warnings.Strings()).To(ConsistOf("domain-warning"))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the domain is a private domain", func() {
+				BeforeEach(func() {
+					routes = []string{"private.org"}
+				})
+
+				It("looks up the route without a host", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)).To(Equal(v2action.Route{
+						Domain:    v2action.Domain{GUID: "private-domain-guid", Name: "private.org", Type: constant.PrivateDomain},
+						SpaceGUID: "some-space-guid",
+					}))
+				})
+			})
+
+			Context("when the domain is a shared TCP domain", func() {
+				BeforeEach(func() {
+					routes = []string{"tcp.example.com:1234"}
+				})
+
+				It("looks up the route", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the route is given by its parts", func() {
+				It("returns the same error for a shared HTTP domain", func() {
+					_, _, err := actor.CalculateRoutesFromSpecs(context.Background(), []RouteSpec{{Domain: "example.com"}}, "some-org-guid", "some-space-guid", nil, false)
+					Expect(err).To(MatchError(actionerror.NoHostnameAndSharedDomainError{}))
+				})
+			})
+		})
+
+		Context("when an HTTP route specifies a port", func() {
+			BeforeEach(func() {
+				routes = []string{"app.example.com:8080/some-path"}
+			})
+
+			It("returns a PortWithHTTPDomainError naming the route without looking up any routes", func() {
+				Expect(executeErr).To(MatchError(actionerror.PortWithHTTPDomainError{Route: "app.example.com:8080/some-path", Domain: "example.com"}))
+				Expect(warnings.Strings()).To(ConsistOf("domain-warning"))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+			})
+
+			Context("when the HTTP route specifies a port range", func() {
+				BeforeEach(func() {
+					routes = []string{"app.example.com:8080-8081"}
+				})
+
+				It("returns a PortWithHTTPDomainError for each port", func() {
+					Expect(executeErr).To(MatchError(actionerror.InvalidRoutesError{Routes: []actionerror.InvalidRoute{
+						{Route: "app.example.com:8080", Err: actionerror.PortWithHTTPDomainError{Route: "app.example.com:8080", Domain: "example.com"}},
+						{Route: "app.example.com:8081", Err: actionerror.PortWithHTTPDomainError{Route: "app.example.com:8081", Domain: "example.com"}},
+					}}))
+				})
+			})
+		})
+	})
+
+	Describe("caching domains across CalculateRoutes calls", func() {
+		BeforeEach(func() {
+			actor.DomainCache = NewDomainCache(DefaultDomainCacheTTL)
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+			}, v2action.Warnings{"domain-warning"}, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		Context("when the same domains are looked up again", func() {
+			It("uses the cached domains", func() {
+				_, warnings, err := actor.CalculateRoutes(context.Background(), []string{"app-1.example.com", "app-2.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings.Strings()).To(ConsistOf("domain-warning"))
+
+				calculatedRoutes, warnings, err := actor.CalculateRoutes(context.Background(), []string{"app-2.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings.Strings()).To(BeEmpty())
+				Expect(calculatedRoutes).To(ConsistOf(v2action.Route{
+					Host:      "app-2",
+					Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+					SpaceGUID: "some-space-guid",
+				}))
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when only some of the domains are cached", func() {
+			It("only looks up the uncached domains", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = actor.CalculateRoutes(context.Background(), []string{"app.other.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+				domains, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(1)
+				Expect(domains).To(ConsistOf("app.other.example.com", "other.example.com"))
+			})
+		})
+
+		Context("when the domains are in a different organization", func() {
+			It("looks them up again", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-other-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when looking up the domains errors", func() {
+			It("does not cache the failed lookup", func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturnsOnCall(0, nil, nil, errors.New("some-error"))
+
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError("some-error"))
+
+				_, _, err = actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the cache is disabled", func() {
+			BeforeEach(func() {
+				actor.DomainCache = nil
+			})
+
+			It("looks up the domains every time", func() {
+				for i := 0; i < 2; i++ {
+					_, warnings, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(warnings.Strings()).To(ConsistOf("domain-warning"))
+				}
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("batching domain lookups in CalculateRoutes", func() {
+		var routes []string
+
+		BeforeEach(func() {
+			actor.DomainCache = nil
+			actor.DomainBatchSize = 2
+			routes = []string{"app.one.com", "app.two.com", "app.three.com"}
+
+			fakeV2Actor.GetDomainsByNameAndOrganizationStub = func(names []string, _ string) ([]v2action.Domain, v2action.Warnings, error) {
+				var domains []v2action.Domain
+				for _, name := range names {
+					if !strings.HasPrefix(name, "app.") {
+						domains = append(domains, v2action.Domain{GUID: name + "-guid", Name: name})
+					}
+				}
+				return domains, v2action.Warnings{"domain-warning-" + names[0]}, nil
+			}
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		It("looks up the domains DomainBatchSize at a time and merges the results", func() {
+			calculatedRoutes, warnings, err := actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(ConsistOf(
+				v2action.Route{Host: "app", Domain: v2action.Domain{GUID: "one.com-guid", Name: "one.com"}, SpaceGUID: "some-space-guid"},
+				v2action.Route{Host: "app", Domain: v2action.Domain{GUID: "two.com-guid", Name: "two.com"}, SpaceGUID: "some-space-guid"},
+				v2action.Route{Host: "app", Domain: v2action.Domain{GUID: "three.com-guid", Name: "three.com"}, SpaceGUID: "some-space-guid"},
+			))
+
+			Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(3))
+			var allNames []string
+			for i := 0; i < 3; i++ {
+				names, orgGUID := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(i)
+				Expect(names).To(HaveLen(2))
+				Expect(orgGUID).To(Equal("some-org-guid"))
+				allNames = append(allNames, names...)
+				Expect(warnings.Strings()).To(ContainElement("domain-warning-" + names[0]))
+			}
+			Expect(allNames).To(ConsistOf("app.one.com", "one.com", "app.two.com", "two.com", "app.three.com", "three.com"))
+			Expect(warnings.Strings()).To(HaveLen(3))
+		})
+
+		Context("when a batch fails", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturnsOnCall(0, nil, v2action.Warnings{"batch-1-warning"}, nil)
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturnsOnCall(1, nil, v2action.Warnings{"batch-2-warning"}, errors.New("some-error"))
+			})
+
+			It("returns the error and the warnings from every batch so far", func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationStub = nil
+
+				_, warnings, err := actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError("some-error"))
+				Expect(warnings.Strings()).To(ConsistOf("batch-1-warning", "batch-2-warning"))
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when DomainBatchSize is 0", func() {
+			BeforeEach(func() {
+				actor.DomainBatchSize = 0
+			})
+
+			It("looks up all of the domains in one request", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				names, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+				Expect(names).To(HaveLen(6))
+			})
+		})
+	})
+
+	Describe("splitting hosts from domains in CalculateRoutes", func() {
+		BeforeEach(func() {
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+			}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		Context("when the route has no dots", func() {
+			It("returns a NoMatchingDomainError naming the route", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"localhost"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(actionerror.NoMatchingDomainError{Route: "localhost"}))
+			})
+		})
+
+		Context("when the route has one dot", func() {
+			It("uses the whole route as the domain", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(ConsistOf(v2action.Route{
+					Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+					SpaceGUID: "some-space-guid",
+				}))
+			})
+
+			It("returns a NoMatchingDomainError when the domain does not exist", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"example.org"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(actionerror.NoMatchingDomainError{Route: "example.org"}))
+			})
+		})
+
+		Context("when the route has multiple dots", func() {
+			It("uses the leftmost labels as the host", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"a.b.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(ConsistOf(v2action.Route{
+					Host:      "a.b",
+					Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+					SpaceGUID: "some-space-guid",
+				}))
+			})
+		})
+	})
+
+	Describe("matching overlapping domains in CalculateRoutes", func() {
+		var domains []v2action.Domain
+
+		BeforeEach(func() {
+			domains = []v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+				{GUID: "domain-guid-2", Name: "sub.example.com"},
+			}
+			fakeV2Actor.GetDomainsByNameAndOrganizationStub = func(names []string, _ string) ([]v2action.Domain, v2action.Warnings, error) {
+				var found []v2action.Domain
+				for _, name := range names {
+					for _, domain := range domains {
+						if domain.Name == name {
+							found = append(found, domain)
+						}
+					}
+				}
+				return found, nil, nil
+			}
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		It("uses the longest domain that matches the route", func() {
+			calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"host.sub.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(ConsistOf(v2action.Route{
+				Host:      "host",
+				Domain:    v2action.Domain{GUID: "domain-guid-2", Name: "sub.example.com"},
+				SpaceGUID: "some-space-guid",
+			}))
+		})
+
+		It("uses the parent domain for routes outside of the subdomain", func() {
+			calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"host.other.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(ConsistOf(v2action.Route{
+				Host:      "host.other",
+				Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+				SpaceGUID: "some-space-guid",
+			}))
+		})
+
+		It("uses the subdomain itself for a route without a host", func() {
+			calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"sub.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(ConsistOf(v2action.Route{
+				Domain:    v2action.Domain{GUID: "domain-guid-2", Name: "sub.example.com"},
+				SpaceGUID: "some-space-guid",
+			}))
+		})
+
+		Context("when only the parent domain exists", func() {
+			BeforeEach(func() {
+				domains = domains[:1]
+			})
+
+			It("keeps the subdomain in the host", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"host.sub.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(ConsistOf(v2action.Route{
+					Host:      "host.sub",
+					Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+					SpaceGUID: "some-space-guid",
+				}))
+			})
+		})
+	})
+
+	Describe("limiting route labels in CalculateRoutes", func() {
+		deepRoute := func(labels int) string {
+			return strings.Repeat("h.", labels-2) + "example.com"
+		}
+
+		BeforeEach(func() {
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+			}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		Context("when the route has MaxRouteLabels labels", func() {
+			It("calculates the route", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{deepRoute(DefaultMaxRouteLabels)}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Host).To(Equal(strings.TrimSuffix(strings.Repeat("h.", DefaultMaxRouteLabels-2), ".")))
+				Expect(calculatedRoutes[0].Domain.Name).To(Equal("example.com"))
+			})
+		})
+
+		Context("when the route has more than MaxRouteLabels labels", func() {
+			It("returns a RouteTooManyLabelsError without looking up domains", func() {
+				route := deepRoute(DefaultMaxRouteLabels + 1)
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(actionerror.RouteTooManyLabelsError{Route: route, MaxLabels: DefaultMaxRouteLabels}))
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when MaxRouteLabels is 0", func() {
+			BeforeEach(func() {
+				actor.MaxRouteLabels = 0
+			})
+
+			It("calculates routes of any depth", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{deepRoute(1000)}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Domain.Name).To(Equal("example.com"))
+			})
+		})
+	})
+
+	Describe("limiting routes per app in CalculateRoutes", func() {
+		var existingRoutes []v2action.Route
+
+		BeforeEach(func() {
+			actor.MaxRoutesPerApp = 3
+			existingRoutes = []v2action.Route{{
+				GUID:   "some-route-guid",
+				Host:   "existing",
+				Domain: v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+			}}
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+			}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		Context("when the routes are within the limit", func() {
+			It("calculates the routes", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"a.example.com", "b.example.com", "A.example.com"}, "some-org-guid", "some-space-guid", existingRoutes, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(3))
+			})
+		})
+
+		Context("when the routes, counting the existing routes, are over the limit", func() {
+			It("returns a TooManyRoutesError without looking up any routes", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"a.example.com", "b.example.com", "c.example.com"}, "some-org-guid", "some-space-guid", existingRoutes, false)
+				Expect(err).To(MatchError(actionerror.TooManyRoutesError{Routes: 4, MaxRoutes: 3}))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a port range is over the limit", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup},
+				}, nil, nil)
+			})
+
+			It("counts each port as a route", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp.example.com:6000-6004"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(actionerror.TooManyRoutesError{Routes: 5, MaxRoutes: 3}))
+			})
+		})
+
+		Context("when MaxRoutesPerApp is 0", func() {
+			BeforeEach(func() {
+				actor.MaxRoutesPerApp = 0
+			})
+
+			It("calculates any number of routes", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"a.example.com", "b.example.com", "c.example.com"}, "some-org-guid", "some-space-guid", existingRoutes, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(4))
+			})
+		})
+	})
+
+	Describe("keeping the fields of existing routes in CalculateRoutes", func() {
+		var (
+			createdAt time.Time
+			updatedAt time.Time
+			found     v2action.Route
+		)
+
+		BeforeEach(func() {
+			createdAt = time.Date(2016, 6, 8, 16, 41, 22, 0, time.UTC)
+			updatedAt = time.Date(2016, 6, 8, 16, 41, 26, 0, time.UTC)
+			found = v2action.Route{
+				GUID:      "some-route-guid",
+				Host:      "app",
+				Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+				Path:      "/foo",
+				SpaceGUID: "some-space-guid",
+				Protocol:  constant.HTTP2RouteProtocol,
+				CreatedAt: createdAt,
+				UpdatedAt: &updatedAt,
+			}
+
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+			}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(found, nil, nil)
+		})
+
+		It("returns the found route with all of its fields", func() {
+			calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com/foo"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(Equal([]v2action.Route{found}))
+		})
+
+		It("returns the found route for a route given by its parts", func() {
+			specRoutes, _, err := actor.CalculateRoutesFromSpecs(context.Background(), []RouteSpec{{Host: "app", Domain: "example.com", Path: "/foo", Protocol: constant.HTTP2RouteProtocol}}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(specRoutes).To(HaveLen(1))
+			Expect(specRoutes[0].GUID).To(Equal("some-route-guid"))
+			Expect(specRoutes[0].CreatedAt).To(Equal(createdAt))
+			Expect(specRoutes[0].UpdatedAt).To(Equal(&updatedAt))
+			Expect(specRoutes[0].Protocol).To(Equal(constant.HTTP2RouteProtocol))
+		})
+	})
+
+	Describe("matching routes with different capitalization in CalculateRoutes", func() {
+		var (
+			existingRoute    v2action.Route
+			calculatedRoutes []v2action.Route
+			executeErr       error
+		)
+
+		BeforeEach(func() {
+			existingRoute = v2action.Route{
+				GUID: "some-route-guid",
+				Host: "myapp",
+				Domain: v2action.Domain{
+					GUID: "domain-guid-1",
+					Name: "example.com",
+				},
+				Path:      "/some-path",
+				SpaceGUID: "some-space-guid",
+			}
+		})
+
+		JustBeforeEach(func() {
+			calculatedRoutes, _, executeErr = actor.CalculateRoutes(context.Background(),
+				[]string{"MyApp.Example.COM/Some-Path", "http://MYAPP.example.com/some-path", "myapp.EXAMPLE.com/SOME-PATH"},
+				"some-org-guid",
+				"some-space-guid",
+				[]v2action.Route{existingRoute},
+				false,
+			)
+		})
+
+		It("treats them as the existing route and does not look them up", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(ConsistOf(existingRoute))
+
+			Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+			Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+		})
+
+		Context("when the routes are then created", func() {
+			var createdRoutes []v2action.Route
+
+			JustBeforeEach(func() {
+				_, createdRoutes, _, executeErr = actor.CreateRoutes(context.Background(), ApplicationConfig{DesiredRoutes: calculatedRoutes}, nil)
+			})
+
+			It("does not create a duplicate route", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(createdRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("parsing routes in CalculateRoutes", func() {
+		BeforeEach(func() {
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+				{GUID: "domain-guid-1", Name: "example.com"},
+			}, nil, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+		})
+
+		DescribeTable("query strings and fragments",
+			func(route string, expectedPath string, expectedErr error) {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+				if expectedErr != nil {
+					Expect(err).To(MatchError(expectedErr))
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+					return
+				}
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Host).To(Equal("app"))
+				Expect(calculatedRoutes[0].Path).To(Equal(expectedPath))
+			},
+
+			Entry("no path", "app.example.com", "", nil),
+			Entry("path", "app.example.com/foo", "/foo", nil),
+			Entry("protocol and path", "https://app.example.com/foo", "/foo", nil),
+			Entry("root path", "app.example.com/", "", nil),
+			Entry("path with a trailing slash", "app.example.com/foo/", "/foo", nil),
+			Entry("multi-segment path", "app.example.com/foo/bar", "/foo/bar", nil),
+			Entry("multi-segment path with a trailing slash", "app.example.com/foo/bar/", "/foo/bar", nil),
+			Entry("path with several trailing slashes", "app.example.com/foo//", "/foo/", nil),
+			Entry("query", "app.example.com/foo?bar=baz", "",
+				actionerror.RouteQueryOrFragmentError{Route: "app.example.com/foo?bar=baz"}),
+			Entry("empty query", "app.example.com/foo?", "",
+				actionerror.RouteQueryOrFragmentError{Route: "app.example.com/foo?"}),
+			Entry("query without a path", "app.example.com?bar=baz", "",
+				actionerror.RouteQueryOrFragmentError{Route: "app.example.com?bar=baz"}),
+			Entry("fragment", "app.example.com/foo#bar", "",
+				actionerror.RouteQueryOrFragmentError{Route: "app.example.com/foo#bar"}),
+			Entry("query and fragment", "http://app.example.com/foo?bar=baz#qux", "",
+				actionerror.RouteQueryOrFragmentError{Route: "http://app.example.com/foo?bar=baz#qux"}),
+		)
+
+		DescribeTable("round trips",
+			func(route string, expectedString string) {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid-1", Name: "example.com"},
+					{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup},
+				}, nil, nil)
+
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].String()).To(Equal(expectedString))
+
+				existingRoute := calculatedRoutes[0]
+				existingRoute.GUID = "some-route-guid"
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, errors.New("should not look the route up"))
+				knownRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", []v2action.Route{existingRoute}, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(knownRoutes).To(Equal([]v2action.Route{existingRoute}))
+			},
+
+			Entry("host and domain", "app.example.com", "app.example.com"),
+			Entry("scheme", "https://app.example.com", "app.example.com"),
+			Entry("path", "app.example.com/foo", "app.example.com/foo"),
+			Entry("multi-segment path", "app.example.com/foo/bar", "app.example.com/foo/bar"),
+			Entry("path with a trailing slash", "app.example.com/foo/", "app.example.com/foo"),
+			Entry("path with several trailing slashes", "app.example.com/foo//", "app.example.com/foo/"),
+			Entry("TCP port", "tcp.example.com:1234", "tcp.example.com:1234"),
+			Entry("TCP port with a scheme", "tcp://tcp.example.com:1234", "tcp.example.com:1234"),
+			Entry("path with an encoded slash", "app.example.com/a%2fb", "app.example.com/a%2Fb"),
+			Entry("path with encoded characters", "app.example.com/caf%c3%a9/a%2Fb", "app.example.com/caf%C3%A9/a%2Fb"),
+		)
+
+		DescribeTable("encoded paths",
+			func(routes []string, expectedPaths []string) {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), routes, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var paths []string
+				for _, route := range calculatedRoutes {
+					paths = append(paths, route.Path)
+				}
+				Expect(paths).To(Equal(expectedPaths))
+			},
+
+			Entry("the same encoded slash in different cases is one route",
+				[]string{"app.example.com/a%2fb", "app.example.com/a%2Fb"}, []string{"/a%2Fb"}),
+			Entry("an encoded slash is not the same as a slash",
+				[]string{"app.example.com/a%2Fb", "app.example.com/a/b"}, []string{"/a%2Fb", "/a/b"}),
+			Entry("an encoded character is the same as the character it encodes when that must be encoded",
+				[]string{"app.example.com/café", "app.example.com/caf%c3%a9"}, []string{"/caf%C3%A9"}),
+		)
+
+		Context("when an existing route's path is not in the canonical form", func() {
+			It("matches the route by its canonical path", func() {
+				existingRoute := v2action.Route{
+					GUID:   "some-route-guid",
+					Host:   "app",
+					Domain: v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+					Path:   "/a%2fb/café",
+				}
+
+				knownRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com/a%2Fb/caf%C3%A9"}, "some-org-guid", "some-space-guid", []v2action.Route{existingRoute}, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(knownRoutes).To(Equal([]v2action.Route{existingRoute}))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+			})
+		})
+
+		DescribeTable("trailing dots",
+			func(route string, expectedPath string) {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(Equal([]v2action.Route{{
+					Host:      "app",
+					Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+					Path:      expectedPath,
+					SpaceGUID: "some-space-guid",
+				}}))
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				domainNames, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+				Expect(domainNames).To(ConsistOf("app.example.com", "example.com"))
+			},
+
+			Entry("no trailing dot", "app.example.com", ""),
+			Entry("trailing dot", "app.example.com.", ""),
+			Entry("trailing dot with a scheme", "https://app.example.com.", ""),
+			Entry("trailing dot with a path", "app.example.com./foo", "/foo"),
+		)
+
+		DescribeTable("path characters",
+			func(route string, expectedPath string, expectedErr error) {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+				if expectedErr != nil {
+					Expect(err).To(MatchError(expectedErr))
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					return
+				}
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Path).To(Equal(expectedPath))
+			},
+
+			Entry("unreserved and reserved characters", "app.example.com/a-b_c.d~e/f:g@h!$&'()*+,;=", "/a-b_c.d~e/f:g@h!$&'()*+,;=", nil),
+			Entry("percent-encoded valid characters", "app.example.com/%41%2Db", "/%41%2Db", nil),
+			Entry("internationalized characters", "app.example.com/café", "/caf%C3%A9", nil),
+			Entry("percent-encoded internationalized characters", "app.example.com/caf%C3%A9", "/caf%C3%A9", nil),
+			Entry("encoded slash", "app.example.com/a%2Fb", "/a%2Fb", nil),
+			Entry("lower case encoded slash", "app.example.com/a%2fb", "/a%2Fb", nil),
+			Entry("encoded slash and internationalized characters", "app.example.com/a%2fb/café", "/a%2Fb/caf%C3%A9", nil),
+			Entry("trailing encoded slash", "app.example.com/a%2F", "/a%2F", nil),
+			Entry("encoded percent sign", "app.example.com/100%25", "/100%25", nil),
+			Entry("space", "app.example.com/foo bar", "",
+				actionerror.InvalidRoutePathError{Route: "app.example.com/foo bar", Path: "/foo bar", Character: ' ', Position: 5}),
+			Entry("percent-encoded space", "app.example.com/foo%20bar", "",
+				actionerror.InvalidRoutePathError{Route: "app.example.com/foo%20bar", Path: "/foo bar", Character: ' ', Position: 5}),
+			Entry("tab", "app.example.com/foo\tbar", "",
+				actionerror.InvalidRoutePathError{Route: "app.example.com/foo\tbar", Path: "/foo\tbar", Character: '\t', Position: 5}),
+			Entry("control character", "app.example.com/foo/\x00", "",
+				actionerror.InvalidRoutePathError{Route: "app.example.com/foo/\x00", Path: "/foo/\x00", Character: '\x00', Position: 6}),
+			Entry("percent-encoded control character", "app.example.com/%7F", "",
+				actionerror.InvalidRoutePathError{Route: "app.example.com/%7F", Path: "/\x7f", Character: '\x7f', Position: 2}),
+			Entry("character that is never valid in a URL", "app.example.com/café/{id}", "",
+				actionerror.InvalidRoutePathError{Route: "app.example.com/café/{id}", Path: "/café/{id}", Character: '{', Position: 7}),
+		)
+
+		DescribeTable("schemes",
+			func(route string, expectedErr error) {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+				if expectedErr != nil {
+					Expect(err).To(MatchError(expectedErr))
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					return
+				}
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Host).To(Equal("app"))
+				Expect(calculatedRoutes[0].Domain.Name).To(Equal("example.com"))
+				Expect(calculatedRoutes[0].Path).To(Equal("/foo"))
+			},
+
+			Entry("no scheme", "app.example.com/foo", nil),
+			Entry("http", "http://app.example.com/foo", nil),
+			Entry("https", "https://app.example.com/foo", nil),
+			Entry("upper case", "HTTPS://app.example.com/foo", nil),
+			Entry("unknown scheme", "ws://app.example.com/foo",
+				actionerror.UnsupportedRouteSchemeError{Route: "ws://app.example.com/foo", Scheme: "ws"}),
+			Entry("unknown scheme with a port", "ftp://app.example.com:21/foo",
+				actionerror.UnsupportedRouteSchemeError{Route: "ftp://app.example.com:21/foo", Scheme: "ftp"}),
+		)
+
+		DescribeTable("hosts, ports and paths",
+			func(route string, expectedRoute v2action.Route) {
+				httpDomain := v2action.Domain{GUID: "domain-guid-1", Name: "example.com"}
+				tcpDomain := v2action.Domain{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup}
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{httpDomain, tcpDomain}, nil, nil)
+
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Host).To(Equal(expectedRoute.Host))
+				Expect(calculatedRoutes[0].Domain.Name).To(Equal(expectedRoute.Domain.Name))
+				Expect(calculatedRoutes[0].Port).To(Equal(expectedRoute.Port))
+				Expect(calculatedRoutes[0].Path).To(Equal(expectedRoute.Path))
+			},
+
+			Entry("domain and port", "tcp.example.com:1234",
+				v2action.Route{Domain: v2action.Domain{Name: "tcp.example.com"}, Port: types.NullInt{IsSet: true, Value: 1234}}),
+			Entry("domain and port with a scheme", "tcp://tcp.example.com:1234",
+				v2action.Route{Domain: v2action.Domain{Name: "tcp.example.com"}, Port: types.NullInt{IsSet: true, Value: 1234}}),
+			Entry("domain", "example.com",
+				v2action.Route{Domain: v2action.Domain{Name: "example.com"}}),
+			Entry("domain and path", "example.com/path",
+				v2action.Route{Domain: v2action.Domain{Name: "example.com"}, Path: "/path"}),
+			Entry("host and domain", "app.example.com",
+				v2action.Route{Host: "app", Domain: v2action.Domain{Name: "example.com"}}),
+			Entry("host, domain and path", "app.example.com/path",
+				v2action.Route{Host: "app", Domain: v2action.Domain{Name: "example.com"}, Path: "/path"}),
+		)
+
+		It("returns an error for a port that is not a number", func() {
+			_, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp.example.com:port"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+		})
+
+		It("splits a tcp route into its domain and port", func() {
+			tcpDomain := v2action.Domain{GUID: "tcp-domain-guid", Name: "tcp.example.com", RouterGroupType: constant.TCPRouterGroup}
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{tcpDomain}, nil, nil)
+
+			calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp://tcp.example.com:1234"}, "some-org-guid", "some-space-guid", nil, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(Equal([]v2action.Route{
+				{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 1234}, SpaceGUID: "some-space-guid"},
+			}))
+		})
+
+		Context("when RouteSchemes includes another scheme", func() {
+			BeforeEach(func() {
+				actor.RouteSchemes = []string{"http", "https", "tcp", "ws"}
+			})
+
+			It("drops that scheme from routes", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"ws://app.example.com/foo"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Host).To(Equal("app"))
+				Expect(calculatedRoutes[0].Path).To(Equal("/foo"))
+			})
+		})
+
+		Context("when RouteSchemes is empty", func() {
+			BeforeEach(func() {
+				actor.RouteSchemes = nil
+			})
+
+			It("drops the default schemes from routes", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"https://app.example.com/foo"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Host).To(Equal("app"))
+				Expect(calculatedRoutes[0].Path).To(Equal("/foo"))
+			})
+
+			It("rejects other schemes", func() {
+				_, _, err := actor.CalculateRoutes(context.Background(), []string{"ws://app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(actionerror.UnsupportedRouteSchemeError{Route: "ws://app.example.com", Scheme: "ws"}))
+			})
+		})
+
+		It("matches an existing route without a trailing slash", func() {
+			existingRoute := v2action.Route{
+				GUID:      "some-route-guid",
+				Host:      "app",
+				Domain:    v2action.Domain{GUID: "domain-guid-1", Name: "example.com"},
+				Path:      "/foo",
+				SpaceGUID: "some-space-guid",
+			}
+
+			calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com/foo/"}, "some-org-guid", "some-space-guid", []v2action.Route{existingRoute}, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calculatedRoutes).To(ConsistOf(existingRoute))
+			Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+		})
+
+		Context("when a route is provided more than once", func() {
+			var logHook *logtest.Hook
+
+			BeforeEach(func() {
+				logHook = logtest.NewGlobal()
+				log.SetOutput(ioutil.Discard)
+				log.SetLevel(log.DebugLevel)
+			})
+
+			AfterEach(func() {
+				log.SetOutput(os.Stderr)
+				logHook.Reset()
+			})
+
+			It("parses the route once", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com/foo", "other.example.com", "app.example.com/foo"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(2))
+				Expect(calculatedRoutes[0].Path).To(Equal("/foo"))
+
+				parsedRoutes := map[string]int{}
+				for _, entry := range logHook.AllEntries() {
+					if entry.Message == "parsing route" {
+						parsedRoutes[entry.Data["route"].(string)]++
+					}
+				}
+				Expect(parsedRoutes).To(Equal(map[string]int{
+					"app.example.com/foo": 1,
+					"other.example.com":   1,
+				}))
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				domainNames, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+				Expect(domainNames).To(ConsistOf("app.example.com", "other.example.com", "example.com"))
+			})
+		})
+
+		Context("when a route has a port range", func() {
+			var tcpDomain v2action.Domain
+
+			BeforeEach(func() {
+				tcpDomain = v2action.Domain{
+					GUID:            "tcp-domain-guid",
+					Name:            "tcp.example.com",
+					RouterGroupType: constant.TCPRouterGroup,
+				}
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{tcpDomain}, nil, nil)
+			})
+
+			It("returns a route for each port in the range", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp://tcp.example.com:6000-6002"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(Equal([]v2action.Route{
+					{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 6000}, SpaceGUID: "some-space-guid"},
+					{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 6001}, SpaceGUID: "some-space-guid"},
+					{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 6002}, SpaceGUID: "some-space-guid"},
+				}))
+
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(3))
+				for i, route := range calculatedRoutes {
+					Expect(route.RandomTCPPort()).To(BeFalse())
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(i)).To(Equal(route))
+				}
+			})
+
+			It("allows a range of a single port", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp.example.com:6000-6000"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(ConsistOf(
+					v2action.Route{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 6000}, SpaceGUID: "some-space-guid"},
+				))
+			})
+
+			It("does not look up ports in the range that are already known", func() {
+				existingRoute := v2action.Route{
+					GUID:      "existing-route-guid",
+					Domain:    tcpDomain,
+					Port:      types.NullInt{IsSet: true, Value: 6001},
+					SpaceGUID: "some-space-guid",
+				}
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp.example.com:6000-6001"}, "some-org-guid", "some-space-guid", []v2action.Route{existingRoute}, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(ConsistOf(
+					existingRoute,
+					v2action.Route{Domain: tcpDomain, Port: types.NullInt{IsSet: true, Value: 6000}, SpaceGUID: "some-space-guid"},
+				))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+			})
+
+			It("allows up to MaxRoutePortRangeSize ports", func() {
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp.example.com:6000-6099"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(calculatedRoutes).To(HaveLen(MaxRoutePortRangeSize))
+			})
+
+			DescribeTable("invalid ranges",
+				func(route string, expectedErr error) {
+					_, warnings, err := actor.CalculateRoutes(context.Background(), []string{route}, "some-org-guid", "some-space-guid", nil, false)
+					Expect(err).To(MatchError(expectedErr))
+					Expect(warnings.Strings()).To(BeEmpty())
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				},
+
+				Entry("inverted", "tcp.example.com:6005-6000",
+					actionerror.InvertedRoutePortRangeError{Route: "tcp.example.com:6005-6000"}),
+				Entry("too large", "tcp.example.com:6000-6100",
+					actionerror.RoutePortRangeTooLargeError{Route: "tcp.example.com:6000-6100", MaxPorts: 100}),
+				Entry("too large to parse", "tcp.example.com:6000-99999999999999999999",
+					actionerror.RoutePortRangeTooLargeError{Route: "tcp.example.com:6000-99999999999999999999", MaxPorts: 100}),
+			)
+		})
+
+		Context("when the routes are then created", func() {
+			It("creates a route for each port in the range and returns all the warnings", func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{{
+					GUID:            "tcp-domain-guid",
+					Name:            "tcp.example.com",
+					RouterGroupType: constant.TCPRouterGroup,
+				}}, nil, nil)
+				fakeV2Actor.CreateRouteStub = func(route v2action.Route, generatePort bool) (v2action.Route, v2action.Warnings, error) {
+					route.GUID = fmt.Sprintf("route-guid-%d", route.Port.Value)
+					return route, v2action.Warnings{fmt.Sprintf("create-warning-%d", route.Port.Value)}, nil
+				}
+
+				calculatedRoutes, _, err := actor.CalculateRoutes(context.Background(), []string{"tcp.example.com:6000-6002"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				config, createdRoutes, warnings, err := actor.CreateRoutes(context.Background(), ApplicationConfig{DesiredRoutes: calculatedRoutes}, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(createdRoutes).To(Equal(config.DesiredRoutes))
+				Expect(warnings.Strings()).To(Equal(Warnings{"create-warning-6000", "create-warning-6001", "create-warning-6002"}))
+				Expect(config.DesiredRoutes).To(HaveLen(3))
+				Expect(config.DesiredRoutes[0].GUID).To(Equal("route-guid-6000"))
+				Expect(config.DesiredRoutes[2].GUID).To(Equal("route-guid-6002"))
+
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(3))
+				for i := 0; i < 3; i++ {
+					_, generatePort := fakeV2Actor.CreateRouteArgsForCall(i)
+					Expect(generatePort).To(BeFalse())
+				}
+			})
+		})
+	})
+
+	Describe("cancelling route operations", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+			config ApplicationConfig
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			config = ApplicationConfig{
+				DesiredApplication: Application{
+					Application: v2action.Application{
+						GUID: "some-app-guid",
+					}},
+				CurrentRoutes: []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+				},
+				DesiredRoutes: []v2action.Route{
+					{Host: "some-route-3"},
+					{Host: "some-route-4"},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		Context("when the context is cancelled before the operation starts", func() {
+			BeforeEach(func() {
+				cancel()
+			})
+
+			It("CalculateRoutes does not look anything up", func() {
+				_, _, err := actor.CalculateRoutes(ctx, []string{"some-route.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+			})
+
+			It("CreateRoutes does not create any routes", func() {
+				_, createdRoutes, _, err := actor.CreateRoutes(ctx, config, nil)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(createdRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+			})
+
+			It("MapRoutes does not map any routes", func() {
+				_, boundRoutes, _, _, err := actor.MapRoutes(ctx, config, nil)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(boundRoutes).To(BeFalse())
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+			})
+
+			It("UnmapRoutes does not unmap any routes", func() {
+				returnedConfig, _, err := actor.UnmapRoutes(ctx, config)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(returnedConfig.CurrentRoutes).To(Equal(config.CurrentRoutes))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the context is cancelled part way through", func() {
+			BeforeEach(func() {
+				actor.RouteConcurrency = 1
+			})
+
+			It("CalculateRoutes stops looking up routes", func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid", Name: "example.com"},
+				}, v2action.Warnings{"domain-warning"}, nil)
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsStub = func(route v2action.Route) (v2action.Route, v2action.Warnings, error) {
+					cancel()
+					return v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteNotFoundError{}
+				}
+
+				_, warnings, err := actor.CalculateRoutes(ctx, []string{"a.example.com", "b.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(warnings.Strings()).To(ConsistOf("domain-warning", "find-route-warning"))
+				Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+			})
+
+			It("CreateRoutes stops creating routes", func() {
+				fakeV2Actor.CreateRouteStub = func(route v2action.Route, _ bool) (v2action.Route, v2action.Warnings, error) {
+					cancel()
+					route.GUID = "some-route-guid"
+					return route, v2action.Warnings{"create-route-warning"}, nil
+				}
+
+				_, createdRoutes, warnings, err := actor.CreateRoutes(ctx, config, nil)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(createdRoutes).To(HaveLen(1))
+				Expect(createdRoutes[0].GUID).To(Equal("some-route-guid"))
+				Expect(warnings.Strings()).To(ConsistOf("create-route-warning"))
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(1))
+			})
+
+			It("MapRoutes stops mapping routes", func() {
+				fakeV2Actor.MapRouteToApplicationStub = func(string, string) (v2action.Warnings, error) {
+					cancel()
+					return v2action.Warnings{"map-route-warning"}, nil
+				}
+
+				_, _, mappedRoutes, warnings, err := actor.MapRoutes(ctx, config, nil)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(mappedRoutes).To(HaveLen(1))
+				Expect(warnings.Strings()).To(ConsistOf("map-route-warning"))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+			})
+
+			It("MapRoutes stops waiting to retry a route mapping", func() {
+				actor.MapRouteRetryBackoff = time.Hour
+				fakeV2Actor.MapRouteToApplicationStub = func(string, string) (v2action.Warnings, error) {
+					cancel()
+					return v2action.Warnings{"map-route-warning"}, ccerror.ServiceUnavailableError{Message: "unavailable"}
+				}
+
+				_, _, mappedRoutes, warnings, err := actor.MapRoutes(ctx, config, nil)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(mappedRoutes).To(BeEmpty())
+				Expect(warnings.Strings()).To(ConsistOf("map-route-warning"))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+			})
+
+			It("UnmapRoutes stops unmapping routes and leaves the rest in CurrentRoutes", func() {
+				fakeV2Actor.UnmapRouteFromApplicationStub = func(string, string) (v2action.Warnings, error) {
+					cancel()
+					return v2action.Warnings{"unmap-route-warning"}, nil
+				}
+
+				returnedConfig, warnings, err := actor.UnmapRoutes(ctx, config)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(warnings).To(ConsistOf("unmap-route-warning"))
+				Expect(returnedConfig.CurrentRoutes).To(Equal([]v2action.Route{
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+				}))
+				Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("treating warnings as errors in route operations", func() {
+		var config ApplicationConfig
+
+		BeforeEach(func() {
+			actor.WarningsAsErrors = true
+			config = ApplicationConfig{
+				DesiredApplication: Application{
+					Application: v2action.Application{
+						GUID: "some-app-guid",
+					}},
+				CurrentRoutes: []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+				},
+				DesiredRoutes: []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+				},
+			}
+		})
+
+		Context("when MapRoutes produces warnings", func() {
+			BeforeEach(func() {
+				fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, nil)
+			})
+
+			It("maps the routes and returns a RouteWarningsError", func() {
+				returnedConfig, boundRoutes, mappedRoutes, warnings, err := actor.MapRoutes(context.Background(), config, nil)
+				Expect(err).To(MatchError(actionerror.RouteWarningsError{Warnings: []string{"map-route-warning"}}))
+				Expect(boundRoutes).To(BeTrue())
+				Expect(mappedRoutes).To(Equal([]v2action.Route{{GUID: "some-route-guid-2", Host: "some-route-2"}}))
+				Expect(returnedConfig.CurrentRoutes).To(Equal(config.DesiredRoutes))
+				Expect(warnings.Strings()).To(ConsistOf(
+					"Route some-route-1. is already mapped to the application.",
+					"map-route-warning",
+				))
+			})
+
+			Context("when WarningsAsErrors is not set", func() {
+				BeforeEach(func() {
+					actor.WarningsAsErrors = false
+				})
+
+				It("only returns the warnings", func() {
+					_, _, _, warnings, err := actor.MapRoutes(context.Background(), config, nil)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(warnings.Strings()).To(ContainElement("map-route-warning"))
+				})
+			})
+		})
+
+		Context("when MapRoutes only produces info notes", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = config.CurrentRoutes
+			})
+
+			It("does not return an error", func() {
+				_, _, _, warnings, err := actor.MapRoutes(context.Background(), config, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings.AtLeast(SeverityWarning)).To(BeEmpty())
+				Expect(warnings).To(HaveLen(1))
+			})
+		})
+
+		Context("when mapping fails", func() {
+			BeforeEach(func() {
+				fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-route-warning"}, errors.New("some-map-error"))
+			})
+
+			It("returns the mapping error", func() {
+				_, _, _, _, err := actor.MapRoutes(context.Background(), config, nil)
+				Expect(err).To(MatchError("some-map-error"))
+			})
+		})
+
+		Context("when CreateRoutes produces warnings", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = []v2action.Route{{Host: "some-route-3"}}
+				fakeV2Actor.CreateRouteReturns(v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"}, v2action.Warnings{"create-route-warning"}, nil)
+			})
+
+			It("creates the routes and returns a RouteWarningsError", func() {
+				returnedConfig, createdRoutes, warnings, err := actor.CreateRoutes(context.Background(), config, nil)
+				Expect(err).To(MatchError(actionerror.RouteWarningsError{Warnings: []string{"create-route-warning"}}))
+				Expect(createdRoutes).To(Equal([]v2action.Route{{GUID: "some-route-guid-3", Host: "some-route-3"}}))
+				Expect(returnedConfig.DesiredRoutes).To(Equal(createdRoutes))
+				Expect(warnings.Strings()).To(ConsistOf("create-route-warning"))
+			})
+		})
+
+		Context("when CalculateRoutes produces warnings", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
+					{GUID: "domain-guid", Name: "example.com"},
+				}, v2action.Warnings{"domains-warning"}, nil)
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+			})
+
+			It("calculates the routes and returns a RouteWarningsError", func() {
+				calculatedRoutes, warnings, err := actor.CalculateRoutes(context.Background(), []string{"app.example.com"}, "some-org-guid", "some-space-guid", nil, false)
+				Expect(err).To(MatchError(actionerror.RouteWarningsError{Warnings: []string{"domains-warning"}}))
+				Expect(calculatedRoutes).To(HaveLen(1))
+				Expect(calculatedRoutes[0].Host).To(Equal("app"))
+				Expect(warnings.Strings()).To(ConsistOf("domains-warning"))
+			})
+		})
+	})
+
+	Describe("CreateAndMapDefaultApplicationRoute", func() {
+		var (
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			warnings, executeErr = actor.CreateAndMapDefaultApplicationRoute("some-org-guid", "some-space-guid",
+				v2action.Application{Name: "some-app", GUID: "some-app-guid"})
+		})
+
+		Context("when SkipDefaultRoute is set", func() {
+			BeforeEach(func() {
+				actor.SkipDefaultRoute = true
+			})
+
+			It("does not create or map a route", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+
+				Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting organization domains errors", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationDomainsReturns(
+					[]v2action.Domain{},
+					v2action.Warnings{"domain-warning"},
+					errors.New("some-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(executeErr).To(MatchError("some-error"))
+				Expect(warnings).To(ConsistOf("domain-warning"))
+			})
+		})
+
+		Context("when getting organization domains succeeds", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetOrganizationDomainsReturns(
+					[]v2action.Domain{
+						{
+							GUID: "some-domain-guid",
+							Name: "some-domain",
+						},
+					},
+					v2action.Warnings{"domain-warning"},
+					nil,
+				)
+			})
+
+			Context("when getting the application routes errors", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetApplicationRoutesReturns(
+						[]v2action.Route{},
+						v2action.Warnings{"route-warning"},
+						errors.New("some-error"),
+					)
+				})
+
+				It("returns the error", func() {
+					Expect(executeErr).To(MatchError("some-error"))
+					Expect(warnings).To(ConsistOf("domain-warning", "route-warning"))
+				})
+			})
+
+			Context("when getting the application routes succeeds", func() {
+				// TODO: do we need this context
+				Context("when the route is already bound to the app", func() {
+					BeforeEach(func() {
+						fakeV2Actor.GetApplicationRoutesReturns(
+							[]v2action.Route{
+								{
+									Host: "some-app",
+									Domain: v2action.Domain{
+										GUID: "some-domain-guid",
+										Name: "some-domain",
+									},
+									GUID:      "some-route-guid",
+									SpaceGUID: "some-space-guid",
+								},
+							},
+							v2action.Warnings{"route-warning"},
+							nil,
+						)
+					})
+
+					It("returns any warnings", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings).To(ConsistOf("domain-warning", "route-warning"))
+
+						Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(1), "Expected GetOrganizationDomains to be called once, but it was not")
+						orgGUID := fakeV2Actor.GetOrganizationDomainsArgsForCall(0)
+						Expect(orgGUID).To(Equal("some-org-guid"))
+
+						Expect(fakeV2Actor.GetApplicationRoutesCallCount()).To(Equal(1), "Expected GetApplicationRoutes to be called once, but it was not")
+						appGUID := fakeV2Actor.GetApplicationRoutesArgsForCall(0)
+						Expect(appGUID).To(Equal("some-app-guid"))
+
+						Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0), "Expected CreateRoute to not be called but it was")
+						Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0), "Expected MapRouteToApplication to not be called but it was")
+					})
+				})
+
+				Context("when the route isn't bound to the app", func() {
+					Context("when getting the space routes errors", func() {
+						BeforeEach(func() {
+							fakeV2Actor.GetSpaceRoutesReturns(nil, v2action.Warnings{"space-routes-warning"}, errors.New("some-error"))
+						})
+
+						It("returns the error", func() {
+							Expect(executeErr).To(MatchError("some-error"))
+							Expect(warnings).To(ConsistOf("domain-warning", "space-routes-warning"))
+							Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when an equivalent route exists in the space", func() {
+						BeforeEach(func() {
+							fakeV2Actor.GetSpaceRoutesReturns([]v2action.Route{
+								{
+									GUID: "some-other-route-guid",
+									Host: "some-app",
+									Domain: v2action.Domain{
+										Name: "some-other-domain",
+										GUID: "some-other-domain-guid",
+									},
+									SpaceGUID: "some-space-guid",
+								},
+								{
+									GUID: "some-route-guid",
+									Host: "Some-App",
+									Path: "/",
+									Domain: v2action.Domain{
+										Name: "some-domain",
+										GUID: "some-domain-guid",
+									},
+									SpaceGUID: "some-space-guid",
+								},
+							}, v2action.Warnings{"space-routes-warning"}, nil)
+							fakeV2Actor.MapRouteToApplicationReturns(v2action.Warnings{"map-warning"}, nil)
+						})
+
+						It("maps that route instead of creating another one", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+							Expect(warnings).To(ConsistOf("domain-warning", "space-routes-warning", "map-warning"))
+
+							Expect(fakeV2Actor.GetSpaceRoutesCallCount()).To(Equal(1))
+							Expect(fakeV2Actor.GetSpaceRoutesArgsForCall(0)).To(Equal("some-space-guid"))
+							Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+							Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+
+							Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1))
+							routeGUID, appGUID := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+							Expect(routeGUID).To(Equal("some-route-guid"))
+							Expect(appGUID).To(Equal("some-app-guid"))
+						})
+					})
+
+					Context("when a route in the space differs only by a port of zero", func() {
+						var spaceRouteDomain v2action.Domain
+
+						BeforeEach(func() {
+							spaceRouteDomain = v2action.Domain{Name: "some-domain", GUID: "some-domain-guid"}
+							fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+							fakeV2Actor.CreateRouteReturns(v2action.Route{GUID: "new-route-guid"}, nil, nil)
+						})
+
+						Context("when the domain is an HTTP domain", func() {
+							BeforeEach(func() {
+								fakeV2Actor.GetSpaceRoutesReturns([]v2action.Route{{
+									GUID:      "some-route-guid",
+									Host:      "some-app",
+									Domain:    spaceRouteDomain,
+									Port:      types.NullInt{IsSet: true, Value: 0},
+									SpaceGUID: "some-space-guid",
+								}}, nil, nil)
+							})
+
+							It("treats the port of zero as unset and reuses the route", func() {
+								Expect(executeErr).ToNot(HaveOccurred())
+								Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+								routeGUID, _ := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+								Expect(routeGUID).To(Equal("some-route-guid"))
+							})
+						})
+
+						Context("when the domain is a TCP domain", func() {
+							BeforeEach(func() {
+								spaceRouteDomain.RouterGroupType = constant.TCPRouterGroup
+								fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{spaceRouteDomain}, v2action.Warnings{"domain-warning"}, nil)
+								fakeV2Actor.GetSpaceRoutesReturns([]v2action.Route{{
+									GUID:      "some-route-guid",
+									Host:      "some-app",
+									Domain:    spaceRouteDomain,
+									Port:      types.NullInt{IsSet: true, Value: 0},
+									SpaceGUID: "some-space-guid",
+								}}, nil, nil)
+							})
+
+							It("does not treat the port of zero as unset", func() {
+								Expect(executeErr).ToNot(HaveOccurred())
+								Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(1))
+								routeGUID, _ := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+								Expect(routeGUID).To(Equal("new-route-guid"))
+							})
+						})
+					})
+
+					Context("when finding route in space errors", func() {
+						BeforeEach(func() {
+							fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(
+								v2action.Route{},
+								v2action.Warnings{"route-warning"},
+								errors.New("some-error"),
+							)
+						})
+
+						It("returns the error", func() {
+							Expect(executeErr).To(MatchError("some-error"))
+							Expect(warnings).To(ConsistOf("domain-warning", "route-warning"))
+						})
+					})
+
+					Context("when the route exists", func() {
+						BeforeEach(func() {
+							fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(
+								v2action.Route{
+									GUID: "some-route-guid",
+									Host: "some-app",
+									Domain: v2action.Domain{
+										Name: "some-domain",
+										GUID: "some-domain-guid",
+									},
+									SpaceGUID: "some-space-guid",
+								},
+								v2action.Warnings{"route-warning"},
+								nil,
+							)
+						})
+
+						Context("when the map command returns an error", func() {
+							BeforeEach(func() {
+								fakeV2Actor.MapRouteToApplicationReturns(
+									v2action.Warnings{"map-warning"},
+									errors.New("some-error"),
+								)
+							})
+
+							It("returns the error", func() {
+								Expect(executeErr).To(MatchError("some-error"))
+								Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "map-warning"))
+							})
+						})
+
+						Context("when the map command succeeds", func() {
+							BeforeEach(func() {
+								fakeV2Actor.MapRouteToApplicationReturns(
+									v2action.Warnings{"map-warning"},
+									nil,
+								)
+							})
+
+							It("maps the route to the app and returns any warnings", func() {
+								Expect(executeErr).ToNot(HaveOccurred())
+								Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "map-warning"))
+
+								Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1), "Expected FindRouteBoundToSpaceWithSettings to be called once, but it was not")
+								spaceRoute := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
+								Expect(spaceRoute).To(Equal(v2action.Route{
+									Host: "some-app",
+									Domain: v2action.Domain{
+										Name: "some-domain",
+										GUID: "some-domain-guid",
+									},
+									SpaceGUID: "some-space-guid",
+								}))
+
+								Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1), "Expected MapRouteToApplication to be called once, but it was not")
+								routeGUID, appGUID := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+								Expect(routeGUID).To(Equal("some-route-guid"))
+								Expect(appGUID).To(Equal("some-app-guid"))
+							})
+						})
+					})
+
+					Context("when the route does not exist", func() {
+						BeforeEach(func() {
+							fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(
+								v2action.Route{},
+								v2action.Warnings{"route-warning"},
+								actionerror.RouteNotFoundError{},
+							)
+						})
+
+						Context("when the create route command errors", func() {
+							BeforeEach(func() {
+								fakeV2Actor.CreateRouteReturns(
+									v2action.Route{},
+									v2action.Warnings{"route-create-warning"},
+									errors.New("some-error"),
+								)
+							})
+
+							It("returns the error", func() {
+								Expect(executeErr).To(MatchError("some-error"))
+								Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "route-create-warning"))
+							})
+						})
+
+						Context("when the create route command succeeds", func() {
+							BeforeEach(func() {
+								fakeV2Actor.CreateRouteReturns(
+									v2action.Route{
+										GUID: "some-route-guid",
+										Host: "some-app",
+										Domain: v2action.Domain{
+											Name: "some-domain",
+											GUID: "some-domain-guid",
+										},
+										SpaceGUID: "some-space-guid",
+									},
+									v2action.Warnings{"route-create-warning"},
+									nil,
+								)
+							})
+
+							Context("when the map command errors", func() {
+								BeforeEach(func() {
+									fakeV2Actor.MapRouteToApplicationReturns(
+										v2action.Warnings{"map-warning"},
+										errors.New("some-error"),
+									)
+								})
+
+								It("returns the error", func() {
+									Expect(executeErr).To(MatchError("some-error"))
+									Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "route-create-warning", "map-warning"))
+								})
+							})
+							Context("when the map command succeeds", func() {
+
+								BeforeEach(func() {
+									fakeV2Actor.MapRouteToApplicationReturns(
+										v2action.Warnings{"map-warning"},
+										nil,
+									)
+								})
+
+								It("creates the route, maps it to the app, and returns any warnings", func() {
+									Expect(executeErr).ToNot(HaveOccurred())
+									Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "route-create-warning", "map-warning"))
+
+									Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(1), "Expected CreateRoute to be called once, but it was not")
+									defaultRoute, shouldGeneratePort := fakeV2Actor.CreateRouteArgsForCall(0)
+									Expect(defaultRoute).To(Equal(v2action.Route{
+										Host: "some-app",
+										Domain: v2action.Domain{
+											Name: "some-domain",
+											GUID: "some-domain-guid",
+										},
+										SpaceGUID: "some-space-guid",
+									}))
+									Expect(shouldGeneratePort).To(BeFalse())
+
+									Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1), "Expected FindRouteBoundToSpaceWithSettings to be called once, but it was not")
+									spaceRoute := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
+									Expect(spaceRoute).To(Equal(v2action.Route{
+										Host: "some-app",
+										Domain: v2action.Domain{
+											Name: "some-domain",
+											GUID: "some-domain-guid",
+										},
+										SpaceGUID: "some-space-guid",
+									}))
+
+									Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1), "Expected MapRouteToApplication to be called once, but it was not")
+									routeGUID, appGUID := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
+									Expect(routeGUID).To(Equal("some-route-guid"))
+									Expect(appGUID).To(Equal("some-app-guid"))
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Describe("CreateRoutes", func() {
+		var (
+			config   ApplicationConfig
+			progress RouteProgressFunc
+
+			returnedConfig ApplicationConfig
+			createdRoutes  []v2action.Route
+			warnings       RouteWarnings
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			config = ApplicationConfig{}
+			progress = nil
 		})
 
 		JustBeforeEach(func() {
-			calculatedRoutes, warnings, executeErr = actor.CalculateRoutes(routes, orgGUID, spaceGUID, existingRoutes)
+			returnedConfig, createdRoutes, warnings, executeErr = actor.CreateRoutes(context.Background(), config, progress)
 		})
 
-		Context("when there are no known routes", func() {
+		Context("when there are no desired routes", func() {
 			BeforeEach(func() {
-				existingRoutes = []v2action.Route{{
-					GUID: "some-route-5",
-					Host: "banana",
-					Domain: v2action.Domain{
-						GUID: "domain-guid-1",
-						Name: "a.com",
-					},
-					SpaceGUID: spaceGUID,
-				}}
+				config.CurrentRoutes = []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+				}
+				config.DesiredRoutes = nil
 			})
 
-			Context("when a route looking up the domains is succuessful", func() {
+			It("creates nothing and returns the config unchanged", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+				Expect(createdRoutes).To(BeEmpty())
+				Expect(returnedConfig).To(Equal(config))
+
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+				Expect(fakeV3Actor.UpdateRouteMetadataCallCount()).To(Equal(0))
+			})
+		})
+
+		Describe("when routes need to be created", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = []v2action.Route{
+					{GUID: "", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "", Host: "some-route-3"},
+					{GUID: "", Host: "", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}},
+				}
+			})
+
+			Context("when no-route is set", func() {
 				BeforeEach(func() {
-					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
-						{GUID: "domain-guid-1", Name: "a.com"},
-						{GUID: "domain-guid-2", Name: "b.a.com"},
-					}, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, nil)
+					config.NoRoute = true
+				})
+
+				It("does not create any routes and returns the config unchanged", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings.Strings()).To(BeEmpty())
+					Expect(createdRoutes).To(BeEmpty())
+					Expect(returnedConfig).To(Equal(config))
+
+					Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+				})
+
+				Context("when the actor is in dry run mode", func() {
+					BeforeEach(func() {
+						actor.DryRun = true
+					})
+
+					It("reports no routes to create", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(createdRoutes).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("when there are more desired routes than MaxRoutesPerApp", func() {
+				BeforeEach(func() {
+					actor.MaxRoutesPerApp = 3
+				})
+
+				It("returns a TooManyRoutesError without creating any routes", func() {
+					Expect(executeErr).To(MatchError(actionerror.TooManyRoutesError{Routes: 4, MaxRoutes: 3}))
+					Expect(createdRoutes).To(BeEmpty())
+					Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+				})
+
+				Context("when the actor is in dry run mode", func() {
+					BeforeEach(func() {
+						actor.DryRun = true
+					})
+
+					It("returns the same error", func() {
+						Expect(executeErr).To(MatchError(actionerror.TooManyRoutesError{Routes: 4, MaxRoutes: 3}))
+						Expect(createdRoutes).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("when the desired routes are within MaxRoutesPerApp", func() {
+				BeforeEach(func() {
+					actor.MaxRoutesPerApp = 4
+				})
+
+				It("creates the routes", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(3))
+				})
+			})
+
+			Context("when the actor is in dry run mode", func() {
+				BeforeEach(func() {
+					actor.DryRun = true
+				})
+
+				It("does not create any routes and returns the would-be config", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings.Strings()).To(BeEmpty())
+					Expect(createdRoutes).To(Equal([]v2action.Route{
+						config.DesiredRoutes[0],
+						config.DesiredRoutes[2],
+						config.DesiredRoutes[3],
+					}))
+					Expect(returnedConfig.DesiredRoutes).To(Equal(config.DesiredRoutes))
+
+					Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the creation is successful", func() {
+				BeforeEach(func() {
+					fakeV2Actor.CreateRouteStub = func(route v2action.Route, _ bool) (v2action.Route, v2action.Warnings, error) {
+						switch route.Host {
+						case "some-route-1":
+							return v2action.Route{GUID: "some-route-guid-1", Host: "some-route-1"}, v2action.Warnings{"create-route-warning-1"}, nil
+						case "some-route-3":
+							return v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"}, v2action.Warnings{"create-route-warning-3"}, nil
+						default:
+							return v2action.Route{GUID: "some-route-guid-4", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}}, v2action.Warnings{"create-route-warning-4"}, nil
+						}
+					}
+				})
+
+				It("only creates the routes that do not exist", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning-3", "create-route-warning-4"}))
+					Expect(createdRoutes).To(Equal([]v2action.Route{
+						{GUID: "some-route-guid-1", Host: "some-route-1"},
+						{GUID: "some-route-guid-3", Host: "some-route-3"},
+						{GUID: "some-route-guid-4", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}},
+					}))
+					Expect(returnedConfig.DesiredRoutes).To(Equal([]v2action.Route{
+						{GUID: "some-route-guid-1", Host: "some-route-1"},
+						{GUID: "some-route-guid-2", Host: "some-route-2"},
+						{GUID: "some-route-guid-3", Host: "some-route-3"},
+						{GUID: "some-route-guid-4", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}},
+					}))
+
+					Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(3))
+
+					var passedRoutes []v2action.Route
+					for i := 0; i < fakeV2Actor.CreateRouteCallCount(); i++ {
+						passedRoute, randomRoute := fakeV2Actor.CreateRouteArgsForCall(i)
+						Expect(randomRoute).To(Equal(passedRoute.Domain.IsTCP()))
+						passedRoutes = append(passedRoutes, passedRoute)
+					}
+					Expect(passedRoutes).To(ConsistOf(
+						v2action.Route{Host: "some-route-1"},
+						v2action.Route{Host: "some-route-3"},
+						v2action.Route{GUID: "", Host: "", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}},
+					))
+				})
+
+				It("returns an informational warning for the route that already exists", func() {
+					Expect(warnings).To(Equal(RouteWarnings{
+						{Message: "create-route-warning-1", Severity: SeverityWarning},
+						{Message: "Route some-route-2. already exists.", Severity: SeverityInfo},
+						{Message: "create-route-warning-3", Severity: SeverityWarning},
+						{Message: "create-route-warning-4", Severity: SeverityWarning},
+					}))
+				})
+
+				It("does not set any route metadata", func() {
+					Expect(fakeV3Actor.GetRouteMetadataCallCount()).To(Equal(0))
+					Expect(fakeV3Actor.UpdateRouteMetadataCallCount()).To(Equal(0))
 				})
 
-				Context("when the route is invalid", func() {
+				Context("when a route is created with a different protocol from the one it was asked to have", func() {
 					BeforeEach(func() {
-						routes = []string{"a.com", "b.a.com", "c.b.a.com:1234"}
+						config.DesiredRoutes[0].Protocol = constant.HTTP2RouteProtocol
+						fakeV2Actor.CreateRouteReturns(v2action.Route{GUID: "some-route-guid-1", Host: "some-route-1", Protocol: constant.HTTP1RouteProtocol}, nil, nil)
+						fakeV2Actor.CreateRouteStub = nil
 					})
 
-					It("returns back warnings and error", func() {
-						Expect(executeErr).To(MatchError(actionerror.InvalidHTTPRouteSettings{Domain: "b.a.com"}))
-						Expect(warnings).To(ConsistOf("domain-warnings-1", "domains-warnings-2"))
+					It("keeps the protocol the route has and the one it was asked to have", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(returnedConfig.DesiredRoutes[0].Protocol).To(Equal(constant.HTTP1RouteProtocol))
+						Expect(returnedConfig.DesiredRoutes[0].RequestedProtocol).To(Equal(constant.HTTP2RouteProtocol))
 					})
 				})
 
-				Context("when the route existance check is successful", func() {
+				Context("when a progress callback is provided", func() {
+					var events []RouteEvent
+
 					BeforeEach(func() {
-						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteNotFoundError{})
-						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturnsOnCall(3, v2action.Route{
-							GUID: "route-guid-4",
-							Host: "d.c",
-							Domain: v2action.Domain{
-								GUID: "domain-guid-2",
-								Name: "b.a.com",
-							},
-							SpaceGUID: spaceGUID,
-						}, v2action.Warnings{"find-route-warning"}, nil)
+						events = nil
+						progress = func(event RouteEvent) {
+							events = append(events, event)
+						}
+
+						// Finish creating the last route before the first so that
+						// the order of the events cannot follow completion order.
+						createStub := fakeV2Actor.CreateRouteStub
+						lastRouteCreated := make(chan struct{})
+						fakeV2Actor.CreateRouteStub = func(route v2action.Route, generatePort bool) (v2action.Route, v2action.Warnings, error) {
+							switch route.Host {
+							case "some-route-1":
+								<-lastRouteCreated
+							case "":
+								defer close(lastRouteCreated)
+							}
+							return createStub(route, generatePort)
+						}
 					})
 
-					It("returns new and existing routes", func() {
-						Expect(executeErr).NotTo(HaveOccurred())
-						Expect(warnings).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "find-route-warning", "find-route-warning", "find-route-warning", "find-route-warning", "find-route-warning"))
-						Expect(calculatedRoutes).To(ConsistOf(
-							v2action.Route{
-								Domain: v2action.Domain{
-									GUID: "domain-guid-1",
-									Name: "a.com",
-								},
-								SpaceGUID: spaceGUID,
-							},
-							v2action.Route{
-								Domain: v2action.Domain{
-									GUID: "domain-guid-2",
-									Name: "b.a.com",
-								},
-								SpaceGUID: spaceGUID,
-							},
-							v2action.Route{
-								Host: "c",
-								Domain: v2action.Domain{
-									GUID: "domain-guid-2",
-									Name: "b.a.com",
-								},
-								SpaceGUID: spaceGUID,
-							},
-							v2action.Route{
-								GUID: "route-guid-4",
-								Host: "d.c",
-								Domain: v2action.Domain{
-									GUID: "domain-guid-2",
-									Name: "b.a.com",
-								},
-								SpaceGUID: spaceGUID,
-							},
-							v2action.Route{
-								GUID: "some-route-5",
-								Host: "banana",
-								Domain: v2action.Domain{
-									GUID: "domain-guid-1",
-									Name: "a.com",
+					It("reports every route in DesiredRoutes order", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(events).To(Equal([]RouteEvent{
+							{Route: "some-route-1.", Action: RouteCreated},
+							{Route: "some-route-2.", Action: RouteSkipped},
+							{Route: "some-route-3.", Action: RouteCreated},
+							{Route: ":????", Action: RouteCreated},
+						}))
+					})
+				})
+
+				Context("when some of the routes have metadata", func() {
+					var (
+						createdMetadata  v2action.Metadata
+						existingMetadata v2action.Metadata
+					)
+
+					BeforeEach(func() {
+						createdMetadata = v2action.Metadata{Labels: map[string]string{"env": "production"}}
+						existingMetadata = v2action.Metadata{Annotations: map[string]string{"owner": "some-team"}}
+						config.DesiredRoutes[0].Metadata = createdMetadata
+						config.DesiredRoutes[1].Metadata = existingMetadata
+
+						fakeV3Actor.GetRouteMetadataReturns(v3action.Metadata{}, v3action.Warnings{"get-metadata-warning"}, nil)
+						fakeV3Actor.UpdateRouteMetadataReturns(v3action.Warnings{"metadata-warning"}, nil)
+					})
+
+					It("sets the metadata on the created and existing routes", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning-3", "create-route-warning-4", "get-metadata-warning", "metadata-warning", "get-metadata-warning", "metadata-warning"}))
+						Expect(returnedConfig.DesiredRoutes[0].Metadata).To(Equal(createdMetadata))
+
+						Expect(fakeV3Actor.GetRouteMetadataCallCount()).To(Equal(2))
+						Expect(fakeV3Actor.GetRouteMetadataArgsForCall(0)).To(Equal("some-route-guid-1"))
+						Expect(fakeV3Actor.GetRouteMetadataArgsForCall(1)).To(Equal("some-route-guid-2"))
+
+						Expect(fakeV3Actor.UpdateRouteMetadataCallCount()).To(Equal(2))
+						routeGUID, metadata := fakeV3Actor.UpdateRouteMetadataArgsForCall(0)
+						Expect(routeGUID).To(Equal("some-route-guid-1"))
+						Expect(metadata).To(Equal(v3action.Metadata{
+							Labels:      map[string]types.FilteredString{"env": {IsSet: true, Value: "production"}},
+							Annotations: map[string]types.FilteredString{},
+						}))
+
+						routeGUID, metadata = fakeV3Actor.UpdateRouteMetadataArgsForCall(1)
+						Expect(routeGUID).To(Equal("some-route-guid-2"))
+						Expect(metadata).To(Equal(v3action.Metadata{
+							Labels:      map[string]types.FilteredString{},
+							Annotations: map[string]types.FilteredString{"owner": {IsSet: true, Value: "some-team"}},
+						}))
+					})
+
+					Context("when a route has labels that its metadata no longer declares", func() {
+						BeforeEach(func() {
+							fakeV3Actor.GetRouteMetadataReturnsOnCall(0, v3action.Metadata{
+								Labels: map[string]types.FilteredString{
+									"env":  {IsSet: true, Value: "staging"},
+									"tier": {IsSet: true, Value: "frontend"},
 								},
-								SpaceGUID: spaceGUID,
-							},
-							v2action.Route{
-								Host: "",
-								Domain: v2action.Domain{
-									GUID: "domain-guid-1",
-									Name: "a.com",
+								Annotations: map[string]types.FilteredString{"contact": {IsSet: true, Value: "someone"}},
+							}, nil, nil)
+						})
+
+						It("removes those labels and leaves the annotations alone", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+
+							_, metadata := fakeV3Actor.UpdateRouteMetadataArgsForCall(0)
+							Expect(metadata).To(Equal(v3action.Metadata{
+								Labels: map[string]types.FilteredString{
+									"env":  {IsSet: true, Value: "production"},
+									"tier": {},
 								},
-								Path:      "/some-path",
-								SpaceGUID: spaceGUID,
+								Annotations: map[string]types.FilteredString{},
 							}))
+						})
+					})
 
-						Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
-						domains, passedOrgGUID := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
-						Expect(domains).To(ConsistOf("a.com", "b.a.com", "c.b.a.com", "d.c.b.a.com"))
-						Expect(passedOrgGUID).To(Equal(orgGUID))
+					Context("when getting the current metadata errors", func() {
+						BeforeEach(func() {
+							fakeV3Actor.GetRouteMetadataReturns(v3action.Metadata{}, v3action.Warnings{"get-metadata-warning"}, errors.New("get metadata failed"))
+						})
 
-						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(5))
-						// One check is enough here - checking 4th call since it's the only
-						// existing one.
-						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(3)).To(Equal(v2action.Route{
-							Host: "d.c",
-							Domain: v2action.Domain{
-								GUID: "domain-guid-2",
-								Name: "b.a.com",
-							},
-							SpaceGUID: spaceGUID,
+						It("returns the error without setting any metadata", func() {
+							Expect(executeErr).To(MatchError("get metadata failed"))
+							Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning-3", "create-route-warning-4", "get-metadata-warning"}))
+							Expect(fakeV3Actor.UpdateRouteMetadataCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when setting the metadata errors", func() {
+						BeforeEach(func() {
+							fakeV3Actor.UpdateRouteMetadataReturns(v3action.Warnings{"metadata-warning"}, errors.New("metadata failed"))
+						})
+
+						It("returns the error, the created routes and all warnings", func() {
+							Expect(executeErr).To(MatchError("metadata failed"))
+							Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning-3", "create-route-warning-4", "get-metadata-warning", "metadata-warning"}))
+							Expect(createdRoutes).To(HaveLen(3))
+							Expect(fakeV3Actor.UpdateRouteMetadataCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when there is no V3 actor", func() {
+						BeforeEach(func() {
+							actor.V3Actor = nil
+						})
+
+						It("returns a RouteRequiresV3APIError naming the first route with metadata", func() {
+							Expect(executeErr).To(MatchError(actionerror.RouteRequiresV3APIError{Route: "some-route-1."}))
+							Expect(createdRoutes).To(HaveLen(3))
+						})
+					})
+				})
+
+				Context("when a route is marked as ephemeral", func() {
+					BeforeEach(func() {
+						config.DesiredRoutes[0].Ephemeral = true
+						config.DesiredRoutes[0].Metadata = v2action.Metadata{Annotations: map[string]string{"owner": "some-team"}}
+					})
+
+					It("sets the ephemeral annotation along with the route's own metadata", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+
+						Expect(fakeV3Actor.UpdateRouteMetadataCallCount()).To(Equal(1))
+						routeGUID, metadata := fakeV3Actor.UpdateRouteMetadataArgsForCall(0)
+						Expect(routeGUID).To(Equal("some-route-guid-1"))
+						Expect(metadata.Annotations).To(Equal(map[string]types.FilteredString{
+							"owner":                           {IsSet: true, Value: "some-team"},
+							v2action.EphemeralRouteAnnotation: {IsSet: true, Value: "true"},
 						}))
+						Expect(config.DesiredRoutes[0].Metadata.Annotations).To(HaveLen(1))
 					})
 				})
 
-				Context("when the route existance check fails", func() {
-					var expectedErr error
+				Context("when some of the routes set session affinity", func() {
+					BeforeEach(func() {
+						config.DesiredRoutes[1].SessionAffinity = types.NullBool{IsSet: true, Value: false}
+						config.DesiredRoutes[2].SessionAffinity = types.NullBool{IsSet: true, Value: true}
+					})
+
+					It("returns a RouteSessionAffinityNotSupportedError naming the first of them without creating any routes", func() {
+						Expect(executeErr).To(MatchError(actionerror.RouteSessionAffinityNotSupportedError{Route: "some-route-2."}))
+						Expect(createdRoutes).To(BeEmpty())
+						Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+						Expect(fakeV3Actor.UpdateRouteMetadataCallCount()).To(Equal(0))
+					})
+
+					Context("when the actor is in dry run mode", func() {
+						BeforeEach(func() {
+							actor.DryRun = true
+						})
+
+						It("returns the same error", func() {
+							Expect(executeErr).To(MatchError(actionerror.RouteSessionAffinityNotSupportedError{Route: "some-route-2."}))
+							Expect(createdRoutes).To(BeEmpty())
+						})
+					})
+				})
 
+				Context("when some of the routes set options", func() {
 					BeforeEach(func() {
-						expectedErr = errors.New("oh noes")
-						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, expectedErr)
+						config.DesiredRoutes[0].Options = map[string]string{"loadbalancing": "least-connection"}
+						config.DesiredRoutes[1].Options = map[string]string{"loadbalancing": "round-robin"}
+
+						fakeV3Actor.UpdateRouteOptionsReturns(v3action.Warnings{"options-warning"}, nil)
 					})
 
-					It("returns back warnings and error", func() {
+					It("sets the options on the created routes and changes them on the existing routes", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning-3", "create-route-warning-4", "options-warning", "options-warning"}))
+						Expect(returnedConfig.DesiredRoutes[0].Options).To(Equal(map[string]string{"loadbalancing": "least-connection"}))
+
+						Expect(fakeV3Actor.UpdateRouteOptionsCallCount()).To(Equal(2))
+						routeGUID, options := fakeV3Actor.UpdateRouteOptionsArgsForCall(0)
+						Expect(routeGUID).To(Equal("some-route-guid-1"))
+						Expect(options).To(Equal(map[string]string{"loadbalancing": "least-connection"}))
+
+						routeGUID, options = fakeV3Actor.UpdateRouteOptionsArgsForCall(1)
+						Expect(routeGUID).To(Equal("some-route-guid-2"))
+						Expect(options).To(Equal(map[string]string{"loadbalancing": "round-robin"}))
+					})
+
+					Context("when setting the options errors", func() {
+						BeforeEach(func() {
+							fakeV3Actor.UpdateRouteOptionsReturns(v3action.Warnings{"options-warning"}, errors.New("options failed"))
+						})
+
+						It("returns the error, the created routes and all warnings", func() {
+							Expect(executeErr).To(MatchError("options failed"))
+							Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning-3", "create-route-warning-4", "options-warning"}))
+							Expect(createdRoutes).To(HaveLen(3))
+							Expect(fakeV3Actor.UpdateRouteOptionsCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when the Cloud Controller does not support route options", func() {
+						BeforeEach(func() {
+							fakeV3Actor.UpdateRouteOptionsReturns(nil, actionerror.RouteOptionsNotSupportedError{
+								CurrentVersion: "3.100.0",
+								MinimumVersion: "3.183.0",
+							})
+						})
+
+						It("returns the error naming the route", func() {
+							Expect(executeErr).To(MatchError(actionerror.RouteOptionsNotSupportedError{
+								Route:          "some-route-1.",
+								CurrentVersion: "3.100.0",
+								MinimumVersion: "3.183.0",
+							}))
+							Expect(createdRoutes).To(HaveLen(3))
+							Expect(fakeV3Actor.UpdateRouteOptionsCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when there is no V3 actor", func() {
+						BeforeEach(func() {
+							actor.V3Actor = nil
+						})
+
+						It("returns a RouteRequiresV3APIError naming the route", func() {
+							Expect(executeErr).To(MatchError(actionerror.RouteRequiresV3APIError{Route: "some-route-1."}))
+							Expect(createdRoutes).To(HaveLen(3))
+						})
+					})
+				})
+
+				Context("when one of the created routes is primary", func() {
+					BeforeEach(func() {
+						config.DesiredRoutes[0].Primary = true
+					})
+
+					It("keeps the route marked as primary", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(returnedConfig.DesiredRoutes[0].Primary).To(BeTrue())
+						Expect(returnedConfig.DesiredRoutes[2].Primary).To(BeFalse())
+					})
+				})
+
+				It("does not set any route options", func() {
+					Expect(fakeV3Actor.UpdateRouteOptionsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the creation errors", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					expectedErr = errors.New("oh my")
+					fakeV2Actor.CreateRouteReturns(
+						v2action.Route{},
+						v2action.Warnings{"create-route-warning"},
+						expectedErr)
+				})
+
+				It("sends the warnings and errors", func() {
+					Expect(executeErr).To(MatchError(expectedErr))
+					Expect(warnings.AtLeast(SeverityWarning).Strings()).ToNot(BeEmpty())
+					for _, warning := range warnings.AtLeast(SeverityWarning).Strings() {
+						Expect(warning).To(Equal("create-route-warning"))
+					}
+					Expect(createdRoutes).To(BeEmpty())
+				})
+
+				Context("when routes are created one at a time", func() {
+					BeforeEach(func() {
+						actor.RouteConcurrency = 1
+						fakeV2Actor.CreateRouteReturnsOnCall(0, v2action.Route{GUID: "some-route-guid-1"}, v2action.Warnings{"create-route-warning-1"}, nil)
+					})
+
+					It("stops creating routes after the first failure", func() {
 						Expect(executeErr).To(MatchError(expectedErr))
-						Expect(warnings).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "find-route-warning"))
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning"}))
+						Expect(createdRoutes).To(Equal([]v2action.Route{{GUID: "some-route-guid-1"}}))
+
+						Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(2))
 					})
 				})
+			})
 
-				Context("when one of the domains does not exist", func() {
+			Context("when a route with a path cannot be created", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					actor.RouteConcurrency = 1
+					config.DesiredRoutes[2].Path = "/foo"
+					expectedErr = ccerror.V2UnexpectedResponseError{ResponseCode: http.StatusInternalServerError}
+					fakeV2Actor.CreateRouteReturnsOnCall(0, v2action.Route{GUID: "some-route-guid-1"}, nil, nil)
+					fakeV2Actor.CreateRouteReturnsOnCall(1, v2action.Route{}, v2action.Warnings{"create-route-warning-3"}, expectedErr)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteInDifferentSpaceError{Route: "some-route-3./foo"})
+				})
+
+				It("returns the error without looking the route up", func() {
+					Expect(executeErr).To(MatchError(expectedErr))
+					Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-3"}))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a route without a path cannot be created", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					expectedErr = errors.New("route taken")
+					fakeV2Actor.CreateRouteReturns(v2action.Route{}, nil, expectedErr)
+				})
+
+				It("returns the error without looking the route up", func() {
+					Expect(executeErr).To(MatchError(expectedErr))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a route is created by another process before it can be created", func() {
+				var existingRoute v2action.Route
+
+				BeforeEach(func() {
+					actor.RouteConcurrency = 1
+					existingRoute = v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3", SpaceGUID: "some-space-guid"}
+					fakeV2Actor.CreateRouteReturnsOnCall(0, v2action.Route{GUID: "some-route-guid-1"}, nil, nil)
+					fakeV2Actor.CreateRouteReturnsOnCall(1, v2action.Route{}, v2action.Warnings{"create-route-warning-3"}, actionerror.RouteAlreadyExistsError{Route: "some-route-3."})
+					fakeV2Actor.CreateRouteReturnsOnCall(2, v2action.Route{GUID: "some-route-guid-4"}, nil, nil)
+				})
+
+				Context("when the existing route is found", func() {
 					BeforeEach(func() {
-						fakeV2Actor.GetDomainsByNameAndOrganizationReturns(nil, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, nil)
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(existingRoute, v2action.Warnings{"find-route-warning"}, nil)
 					})
 
-					It("returns back warnings and error", func() {
-						Expect(executeErr).To(MatchError(actionerror.NoMatchingDomainError{Route: "a.com"}))
-						Expect(warnings).To(ConsistOf("domain-warnings-1", "domains-warnings-2"))
+					It("uses the existing route and returns a warning", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{
+							"create-route-warning-3",
+							"find-route-warning",
+							"Route some-route-3. was created by another process; using the existing route.",
+						}))
+						Expect(returnedConfig.DesiredRoutes[2]).To(Equal(existingRoute))
+						Expect(createdRoutes).To(Equal([]v2action.Route{{GUID: "some-route-guid-1"}, {GUID: "some-route-guid-4"}}))
+
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)).To(Equal(config.DesiredRoutes[2]))
+					})
+				})
+
+				Context("when the existing route cannot be found", func() {
+					BeforeEach(func() {
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteNotFoundError{})
 					})
+
+					It("returns the RouteAlreadyExistsError", func() {
+						Expect(executeErr).To(MatchError(actionerror.RouteAlreadyExistsError{Route: "some-route-3."}))
+						Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-3", "find-route-warning"}))
+					})
+				})
+			})
+
+			Context("when the route quota is exceeded", func() {
+				BeforeEach(func() {
+					actor.RouteConcurrency = 1
+					fakeV2Actor.CreateRouteReturnsOnCall(0, v2action.Route{GUID: "some-route-guid-1"}, v2action.Warnings{"create-route-warning-1"}, nil)
+					fakeV2Actor.CreateRouteReturnsOnCall(1, v2action.Route{}, v2action.Warnings{"create-route-warning-3"}, ccerror.RouteQuotaExceededError{
+						Message: "You have exceeded the total routes for your space's quota.",
+					})
+				})
+
+				It("returns a RouteQuotaExceededError naming the route that exceeded the quota", func() {
+					Expect(executeErr).To(MatchError(actionerror.RouteQuotaExceededError{
+						Route:   "some-route-3.",
+						Message: "You have exceeded the total routes for your space's quota.",
+					}))
+					Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"create-route-warning-1", "create-route-warning-3"}))
+					Expect(createdRoutes).To(Equal([]v2action.Route{{GUID: "some-route-guid-1"}}))
+
+					Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(2))
 				})
 			})
+		})
+
+		Context("when there are more routes to create than RouteConcurrency", func() {
+			var (
+				inFlight    int32
+				maxInFlight int32
+			)
+
+			BeforeEach(func() {
+				actor.RouteConcurrency = 2
+				inFlight = 0
+				maxInFlight = 0
+
+				for i := 0; i < 10; i++ {
+					config.DesiredRoutes = append(config.DesiredRoutes, v2action.Route{Host: fmt.Sprintf("some-route-%d", i)})
+				}
+
+				fakeV2Actor.CreateRouteStub = func(route v2action.Route, _ bool) (v2action.Route, v2action.Warnings, error) {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						max := atomic.LoadInt32(&maxInFlight)
+						if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+
+					route.GUID = route.Host + "-guid"
+					return route, v2action.Warnings{route.Host + "-warning"}, nil
+				}
+			})
+
+			It("never exceeds RouteConcurrency and preserves the route order", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(createdRoutes).To(Equal(returnedConfig.DesiredRoutes))
+				Expect(atomic.LoadInt32(&maxInFlight)).To(BeNumerically("<=", 2))
+
+				Expect(returnedConfig.DesiredRoutes).To(HaveLen(10))
+				for i, route := range returnedConfig.DesiredRoutes {
+					Expect(route.GUID).To(Equal(fmt.Sprintf("some-route-%d-guid", i)))
+					Expect(warnings[i].Message).To(Equal(fmt.Sprintf("some-route-%d-warning", i)))
+				}
+			})
+		})
 
-			Context("when looking up a domain returns an error", func() {
-				var expectedErr error
+		Context("when many routes return warnings concurrently", func() {
+			BeforeEach(func() {
+				actor.RouteConcurrency = 50
+
+				for i := 0; i < 200; i++ {
+					route := v2action.Route{Host: fmt.Sprintf("some-route-%d", i)}
+					if i%10 == 0 {
+						route.GUID = route.Host + "-guid"
+					}
+					config.DesiredRoutes = append(config.DesiredRoutes, route)
+				}
 
-				BeforeEach(func() {
-					expectedErr = errors.New("po-tate-toe")
-					fakeV2Actor.GetDomainsByNameAndOrganizationReturns(nil, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, expectedErr)
-				})
+				fakeV2Actor.CreateRouteStub = func(route v2action.Route, _ bool) (v2action.Route, v2action.Warnings, error) {
+					var index int
+					_, err := fmt.Sscanf(route.Host, "some-route-%d", &index)
+					Expect(err).ToNot(HaveOccurred())
+					time.Sleep(time.Duration(200-index) * time.Microsecond)
 
-				It("returns back warnings and error", func() {
-					Expect(executeErr).To(MatchError(expectedErr))
-					Expect(warnings).To(ConsistOf("domain-warnings-1", "domains-warnings-2"))
-				})
+					route.GUID = route.Host + "-guid"
+					return route, v2action.Warnings{route.Host + "-warning-1", route.Host + "-warning-2"}, nil
+				}
+			})
+
+			It("returns every warning in route order", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+
+				var expectedWarnings RouteWarnings
+				for i := 0; i < 200; i++ {
+					if i%10 == 0 {
+						expectedWarnings = append(expectedWarnings, RouteWarning{
+							Message:  fmt.Sprintf("Route some-route-%d. already exists.", i),
+							Severity: SeverityInfo,
+						})
+						continue
+					}
+					expectedWarnings = append(expectedWarnings,
+						NewRouteWarnings(SeverityWarning,
+							fmt.Sprintf("some-route-%d-warning-1", i),
+							fmt.Sprintf("some-route-%d-warning-2", i),
+						)...,
+					)
+				}
+				Expect(warnings).To(Equal(expectedWarnings))
 			})
 		})
 
-		Context("when there are known routes", func() {
+		Context("when no routes are created", func() {
 			BeforeEach(func() {
-				existingRoutes = []v2action.Route{{
-					GUID: "route-guid-4",
-					Host: "d.c",
-					Domain: v2action.Domain{
-						GUID: "domain-guid-2",
-						Name: "b.a.com",
-					},
-					SpaceGUID: spaceGUID,
-				}}
+				config.DesiredRoutes = []v2action.Route{
+					{GUID: "some-route-guid-1", Host: "some-route-1"},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{GUID: "some-route-guid-3", Host: "some-route-3"},
+				}
+			})
 
-				fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{
-					{GUID: "domain-guid-1", Name: "a.com"},
-					{GUID: "domain-guid-2", Name: "b.a.com"},
-				}, v2action.Warnings{"domain-warnings-1", "domains-warnings-2"}, nil)
-				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"find-route-warning"}, actionerror.RouteNotFoundError{})
+			It("returns no created routes", func() {
+				Expect(createdRoutes).To(BeEmpty())
 			})
+		})
+	})
 
-			It("does not lookup known routes", func() {
-				Expect(executeErr).NotTo(HaveOccurred())
-				Expect(warnings).To(ConsistOf("domain-warnings-1", "domains-warnings-2", "find-route-warning", "find-route-warning", "find-route-warning", "find-route-warning"))
-				Expect(calculatedRoutes).To(ConsistOf(
-					v2action.Route{
-						Domain: v2action.Domain{
-							GUID: "domain-guid-1",
-							Name: "a.com",
-						},
-						SpaceGUID: spaceGUID,
-					},
-					v2action.Route{
-						Domain: v2action.Domain{
-							GUID: "domain-guid-2",
-							Name: "b.a.com",
-						},
-						SpaceGUID: spaceGUID,
-					},
-					v2action.Route{
-						Host: "c",
-						Domain: v2action.Domain{
-							GUID: "domain-guid-2",
-							Name: "b.a.com",
-						},
-						SpaceGUID: spaceGUID,
-					},
-					v2action.Route{
-						GUID: "route-guid-4",
-						Host: "d.c",
-						Domain: v2action.Domain{
-							GUID: "domain-guid-2",
-							Name: "b.a.com",
-						},
-						SpaceGUID: spaceGUID,
-					},
-					v2action.Route{
-						Host: "",
-						Domain: v2action.Domain{
-							GUID: "domain-guid-1",
-							Name: "a.com",
-						},
-						Path:      "/some-path",
-						SpaceGUID: spaceGUID,
-					}))
+	Describe("CreateRoutesDryRun", func() {
+		var (
+			config ApplicationConfig
 
-				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
-				domains, passedOrgGUID := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
-				Expect(domains).To(ConsistOf("a.com", "b.a.com", "c.b.a.com"))
-				Expect(passedOrgGUID).To(Equal(orgGUID))
+			returnedConfig ApplicationConfig
+			routesToCreate []v2action.Route
+			executeErr     error
+		)
+
+		BeforeEach(func() {
+			config = ApplicationConfig{
+				DesiredRoutes: []v2action.Route{
+					{Host: "some-route-1", Domain: v2action.Domain{Name: "some-domain.com"}},
+					{GUID: "some-route-guid-2", Host: "some-route-2"},
+					{Domain: v2action.Domain{Name: "tcp.some-domain.com", RouterGroupType: constant.TCPRouterGroup}},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			returnedConfig, routesToCreate, executeErr = actor.CreateRoutesDryRun(config)
+		})
+
+		It("returns the routes that would be created without creating them", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(routesToCreate).To(Equal([]v2action.Route{
+				{Host: "some-route-1", Domain: v2action.Domain{Name: "some-domain.com"}},
+				{Domain: v2action.Domain{Name: "tcp.some-domain.com", RouterGroupType: constant.TCPRouterGroup}},
+			}))
+			Expect(returnedConfig).To(Equal(config))
+
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+		})
+
+		Context("when a route that would be created is invalid", func() {
+			BeforeEach(func() {
+				config.DesiredRoutes = append(config.DesiredRoutes, v2action.Route{
+					Host:   "some-host",
+					Domain: v2action.Domain{Name: "tcp.some-domain.com", RouterGroupType: constant.TCPRouterGroup},
+				})
+			})
+
+			It("returns the validation error", func() {
+				Expect(executeErr).To(MatchError(actionerror.InvalidTCPRouteSettings{Domain: "tcp.some-domain.com"}))
+				Expect(routesToCreate).To(BeNil())
 			})
 		})
 	})
 
-	Describe("CreateAndMapDefaultApplicationRoute", func() {
+	Describe("ReserveRoutes", func() {
 		var (
-			warnings   Warnings
-			executeErr error
+			ctx    context.Context
+			domain v2action.Domain
+
+			reservedRoutes []v2action.Route
+			warnings       RouteWarnings
+			executeErr     error
 		)
 
+		BeforeEach(func() {
+			ctx = context.Background()
+			domain = v2action.Domain{GUID: "some-domain-guid", Name: "example.com"}
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{domain}, v2action.Warnings{"domain-warning"}, nil)
+			fakeV2Actor.FindRouteBoundToSpaceWithSettingsStub = func(route v2action.Route) (v2action.Route, v2action.Warnings, error) {
+				if route.Host == "existing" {
+					route.GUID = "existing-route-guid"
+					return route, nil, nil
+				}
+				return v2action.Route{}, nil, actionerror.RouteNotFoundError{}
+			}
+			fakeV2Actor.CreateRouteStub = func(route v2action.Route, _ bool) (v2action.Route, v2action.Warnings, error) {
+				route.GUID = route.Host + "-route-guid"
+				return route, v2action.Warnings{"create-route-warning"}, nil
+			}
+		})
+
 		JustBeforeEach(func() {
-			warnings, executeErr = actor.CreateAndMapDefaultApplicationRoute("some-org-guid", "some-space-guid",
-				v2action.Application{Name: "some-app", GUID: "some-app-guid"})
+			reservedRoutes, warnings, executeErr = actor.ReserveRoutes(ctx, []string{"new.example.com", "existing.example.com"}, "some-org-guid", "some-space-guid")
 		})
 
-		Context("when getting organization domains errors", func() {
+		It("creates the routes that do not exist and returns them", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"domain-warning", "create-route-warning"}))
+			Expect(reservedRoutes).To(Equal([]v2action.Route{{
+				GUID:      "new-route-guid",
+				Host:      "new",
+				Domain:    domain,
+				SpaceGUID: "some-space-guid",
+			}}))
+
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(1))
+			createdRoute, _ := fakeV2Actor.CreateRouteArgsForCall(0)
+			Expect(createdRoute.Host).To(Equal("new"))
+		})
+
+		It("does not map the routes to any application", func() {
+			Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+			Expect(fakeV2Actor.MapRouteToApplicationWithPortCallCount()).To(Equal(0))
+		})
+
+		Context("when the context is cancelled", func() {
 			BeforeEach(func() {
-				fakeV2Actor.GetOrganizationDomainsReturns(
-					[]v2action.Domain{},
-					v2action.Warnings{"domain-warning"},
-					errors.New("some-error"))
+				cancelledCtx, cancel := context.WithCancel(context.Background())
+				cancel()
+				ctx = cancelledCtx
 			})
 
-			It("returns the error", func() {
-				Expect(executeErr).To(MatchError("some-error"))
-				Expect(warnings).To(ConsistOf("domain-warning"))
+			It("returns the context's error without creating any routes", func() {
+				Expect(executeErr).To(MatchError(context.Canceled))
+				Expect(reservedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
 			})
 		})
 
-		Context("when getting organization domains succeeds", func() {
+		Context("when a route cannot be calculated", func() {
 			BeforeEach(func() {
-				fakeV2Actor.GetOrganizationDomainsReturns(
-					[]v2action.Domain{
-						{
-							GUID: "some-domain-guid",
-							Name: "some-domain",
-						},
-					},
-					v2action.Warnings{"domain-warning"},
-					nil,
-				)
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns(nil, v2action.Warnings{"domain-warning"}, nil)
 			})
 
-			Context("when getting the application routes errors", func() {
-				BeforeEach(func() {
-					fakeV2Actor.GetApplicationRoutesReturns(
-						[]v2action.Route{},
-						v2action.Warnings{"route-warning"},
-						errors.New("some-error"),
-					)
-				})
+			It("returns the error without creating any routes", func() {
+				Expect(executeErr).To(HaveOccurred())
+				Expect(warnings.Strings()).To(ContainElement("domain-warning"))
+				Expect(reservedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+			})
+		})
 
-				It("returns the error", func() {
-					Expect(executeErr).To(MatchError("some-error"))
-					Expect(warnings).To(ConsistOf("domain-warning", "route-warning"))
-				})
+		Context("when creating a route fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("create route failed")
+				fakeV2Actor.CreateRouteReturns(v2action.Route{}, v2action.Warnings{"create-route-warning"}, expectedErr)
 			})
 
-			Context("when getting the application routes succeeds", func() {
-				// TODO: do we need this context
-				Context("when the route is already bound to the app", func() {
-					BeforeEach(func() {
-						fakeV2Actor.GetApplicationRoutesReturns(
-							[]v2action.Route{
-								{
-									Host: "some-app",
-									Domain: v2action.Domain{
-										GUID: "some-domain-guid",
-										Name: "some-domain",
-									},
-									GUID:      "some-route-guid",
-									SpaceGUID: "some-space-guid",
-								},
-							},
-							v2action.Warnings{"route-warning"},
-							nil,
-						)
-					})
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings.AtLeast(SeverityWarning).Strings()).To(Equal(Warnings{"domain-warning", "create-route-warning"}))
+				Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0))
+			})
+		})
+	})
 
-					It("returns any warnings", func() {
-						Expect(executeErr).ToNot(HaveOccurred())
-						Expect(warnings).To(ConsistOf("domain-warning", "route-warning"))
+	Describe("DeleteUnmappedRoutes", func() {
+		var (
+			config ApplicationConfig
 
-						Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(1), "Expected GetOrganizationDomains to be called once, but it was not")
-						orgGUID := fakeV2Actor.GetOrganizationDomainsArgsForCall(0)
-						Expect(orgGUID).To(Equal("some-org-guid"))
+			returnedConfig ApplicationConfig
+			deletedRoutes  []v2action.Route
+			retainedRoutes []v2action.Route
+			warnings       Warnings
+			executeErr     error
+		)
 
-						Expect(fakeV2Actor.GetApplicationRoutesCallCount()).To(Equal(1), "Expected GetApplicationRoutes to be called once, but it was not")
-						appGUID := fakeV2Actor.GetApplicationRoutesArgsForCall(0)
-						Expect(appGUID).To(Equal("some-app-guid"))
+		BeforeEach(func() {
+			actor.RouteConcurrency = 1
+			config = ApplicationConfig{
+				DesiredApplication: Application{
+					Application: v2action.Application{
+						GUID: "some-app-guid",
+					}},
+				CurrentRoutes: []v2action.Route{
+					{GUID: "exclusive-route-guid-1", Host: "exclusive-route-1"},
+					{GUID: "shared-route-guid", Host: "shared-route"},
+					{GUID: "exclusive-route-guid-2", Host: "exclusive-route-2"},
+				},
+			}
 
-						Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0), "Expected CreateRoute to not be called but it was")
-						Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(0), "Expected MapRouteToApplication to not be called but it was")
-					})
-				})
+			fakeV2Actor.UnmapRouteFromApplicationStub = func(routeGUID string, _ string) (v2action.Warnings, error) {
+				return v2action.Warnings{"unmap-route-warning-" + routeGUID}, nil
+			}
+			fakeV2Actor.GetRouteApplicationsStub = func(routeGUID string) ([]v2action.Application, v2action.Warnings, error) {
+				warnings := v2action.Warnings{"get-route-apps-warning-" + routeGUID}
+				if routeGUID == "shared-route-guid" {
+					return []v2action.Application{{GUID: "some-other-app-guid"}}, warnings, nil
+				}
+				return nil, warnings, nil
+			}
+			fakeV2Actor.DeleteRouteStub = func(routeGUID string) (v2action.Warnings, error) {
+				return v2action.Warnings{"delete-route-warning-" + routeGUID}, nil
+			}
+		})
 
-				Context("when the route isn't bound to the app", func() {
-					Context("when finding route in space errors", func() {
-						BeforeEach(func() {
-							fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(
-								v2action.Route{},
-								v2action.Warnings{"route-warning"},
-								errors.New("some-error"),
-							)
-						})
+		JustBeforeEach(func() {
+			returnedConfig, deletedRoutes, retainedRoutes, warnings, executeErr = actor.DeleteUnmappedRoutes(context.Background(), config)
+		})
 
-						It("returns the error", func() {
-							Expect(executeErr).To(MatchError("some-error"))
-							Expect(warnings).To(ConsistOf("domain-warning", "route-warning"))
-						})
-					})
+		It("unmaps every route and deletes the routes that are no longer mapped", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(returnedConfig.CurrentRoutes).To(BeEmpty())
+			Expect(deletedRoutes).To(Equal([]v2action.Route{config.CurrentRoutes[0], config.CurrentRoutes[2]}))
+			Expect(retainedRoutes).To(Equal([]v2action.Route{config.CurrentRoutes[1]}))
+			Expect(warnings).To(Equal(Warnings{
+				"unmap-route-warning-exclusive-route-guid-1",
+				"unmap-route-warning-shared-route-guid",
+				"unmap-route-warning-exclusive-route-guid-2",
+				"get-route-apps-warning-exclusive-route-guid-1",
+				"delete-route-warning-exclusive-route-guid-1",
+				"get-route-apps-warning-shared-route-guid",
+				"get-route-apps-warning-exclusive-route-guid-2",
+				"delete-route-warning-exclusive-route-guid-2",
+			}))
+
+			Expect(fakeV2Actor.UnmapRouteFromApplicationCallCount()).To(Equal(3))
+			Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(2))
+			Expect(fakeV2Actor.DeleteRouteArgsForCall(0)).To(Equal("exclusive-route-guid-1"))
+			Expect(fakeV2Actor.DeleteRouteArgsForCall(1)).To(Equal("exclusive-route-guid-2"))
+		})
 
-					Context("when the route exists", func() {
-						BeforeEach(func() {
-							fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(
-								v2action.Route{
-									GUID: "some-route-guid",
-									Host: "some-app",
-									Domain: v2action.Domain{
-										Name: "some-domain",
-										GUID: "some-domain-guid",
-									},
-									SpaceGUID: "some-space-guid",
-								},
-								v2action.Warnings{"route-warning"},
-								nil,
-							)
-						})
+		Context("when unmapping a route fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("unmap failed")
+				fakeV2Actor.UnmapRouteFromApplicationReturns(v2action.Warnings{"unmap-route-warning"}, expectedErr)
+			})
 
-						Context("when the map command returns an error", func() {
-							BeforeEach(func() {
-								fakeV2Actor.MapRouteToApplicationReturns(
-									v2action.Warnings{"map-warning"},
-									errors.New("some-error"),
-								)
-							})
+			It("does not delete any routes", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ContainElement("unmap-route-warning"))
+				Expect(deletedRoutes).To(BeEmpty())
+				Expect(retainedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(0))
+			})
+		})
 
-							It("returns the error", func() {
-								Expect(executeErr).To(MatchError("some-error"))
-								Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "map-warning"))
-							})
-						})
+		Context("when deleting a route fails", func() {
+			var expectedErr error
 
-						Context("when the map command succeeds", func() {
-							BeforeEach(func() {
-								fakeV2Actor.MapRouteToApplicationReturns(
-									v2action.Warnings{"map-warning"},
-									nil,
-								)
-							})
+			BeforeEach(func() {
+				expectedErr = errors.New("delete failed")
+				fakeV2Actor.DeleteRouteStub = func(routeGUID string) (v2action.Warnings, error) {
+					if routeGUID == "exclusive-route-guid-1" {
+						return v2action.Warnings{"delete-route-warning"}, expectedErr
+					}
+					return nil, nil
+				}
+			})
 
-							It("maps the route to the app and returns any warnings", func() {
-								Expect(executeErr).ToNot(HaveOccurred())
-								Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "map-warning"))
+			It("continues deleting and retains the route that was not deleted", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ContainElement("delete-route-warning"))
+				Expect(deletedRoutes).To(Equal([]v2action.Route{config.CurrentRoutes[2]}))
+				Expect(retainedRoutes).To(Equal([]v2action.Route{config.CurrentRoutes[0], config.CurrentRoutes[1]}))
+			})
+		})
+	})
 
-								Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1), "Expected FindRouteBoundToSpaceWithSettings to be called once, but it was not")
-								spaceRoute := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
-								Expect(spaceRoute).To(Equal(v2action.Route{
-									Host: "some-app",
-									Domain: v2action.Domain{
-										Name: "some-domain",
-										GUID: "some-domain-guid",
-									},
-									SpaceGUID: "some-space-guid",
-								}))
+	Describe("RollbackCreatedRoutes", func() {
+		var (
+			ctx    context.Context
+			routes []v2action.Route
 
-								Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1), "Expected MapRouteToApplication to be called once, but it was not")
-								routeGUID, appGUID := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
-								Expect(routeGUID).To(Equal("some-route-guid"))
-								Expect(appGUID).To(Equal("some-app-guid"))
-							})
-						})
-					})
+			undeletedRoutes []v2action.Route
+			warnings        Warnings
+			executeErr      error
+		)
 
-					Context("when the route does not exist", func() {
-						BeforeEach(func() {
-							fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(
-								v2action.Route{},
-								v2action.Warnings{"route-warning"},
-								actionerror.RouteNotFoundError{},
-							)
-						})
+		BeforeEach(func() {
+			ctx = context.Background()
+			routes = []v2action.Route{
+				{GUID: "some-route-guid-1", Host: "some-route-1"},
+				{GUID: "some-route-guid-2", Host: "some-route-2"},
+				{GUID: "some-route-guid-3", Host: "some-route-3"},
+			}
 
-						Context("when the create route command errors", func() {
-							BeforeEach(func() {
-								fakeV2Actor.CreateRouteReturns(
-									v2action.Route{},
-									v2action.Warnings{"route-create-warning"},
-									errors.New("some-error"),
-								)
-							})
+			fakeV2Actor.GetRouteApplicationsStub = func(routeGUID string) ([]v2action.Application, v2action.Warnings, error) {
+				warnings := v2action.Warnings{"get-route-apps-warning-" + routeGUID}
+				switch routeGUID {
+				case "some-route-guid-2":
+					return []v2action.Application{{GUID: "some-app-guid"}, {GUID: "some-other-app-guid"}}, warnings, nil
+				case "some-route-guid-3":
+					return []v2action.Application{{GUID: "some-app-guid"}}, warnings, nil
+				default:
+					return nil, warnings, nil
+				}
+			}
+			fakeV2Actor.DeleteRouteStub = func(routeGUID string) (v2action.Warnings, error) {
+				return v2action.Warnings{"delete-route-warning-" + routeGUID}, nil
+			}
+		})
 
-							It("returns the error", func() {
-								Expect(executeErr).To(MatchError("some-error"))
-								Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "route-create-warning"))
-							})
-						})
+		JustBeforeEach(func() {
+			undeletedRoutes, warnings, executeErr = actor.RollbackCreatedRoutes(ctx, "some-app-guid", routes)
+		})
 
-						Context("when the create route command succeeds", func() {
-							BeforeEach(func() {
-								fakeV2Actor.CreateRouteReturns(
-									v2action.Route{
-										GUID: "some-route-guid",
-										Host: "some-app",
-										Domain: v2action.Domain{
-											Name: "some-domain",
-											GUID: "some-domain-guid",
-										},
-										SpaceGUID: "some-space-guid",
-									},
-									v2action.Warnings{"route-create-warning"},
-									nil,
-								)
-							})
+		It("deletes the routes that are not mapped to other applications", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(undeletedRoutes).To(Equal([]v2action.Route{routes[1]}))
+			Expect(warnings).To(Equal(Warnings{
+				"get-route-apps-warning-some-route-guid-1",
+				"delete-route-warning-some-route-guid-1",
+				"get-route-apps-warning-some-route-guid-2",
+				"get-route-apps-warning-some-route-guid-3",
+				"delete-route-warning-some-route-guid-3",
+			}))
+
+			Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(2))
+			Expect(fakeV2Actor.DeleteRouteArgsForCall(0)).To(Equal("some-route-guid-1"))
+			Expect(fakeV2Actor.DeleteRouteArgsForCall(1)).To(Equal("some-route-guid-3"))
+		})
 
-							Context("when the map command errors", func() {
-								BeforeEach(func() {
-									fakeV2Actor.MapRouteToApplicationReturns(
-										v2action.Warnings{"map-warning"},
-										errors.New("some-error"),
-									)
-								})
+		Context("when deleting a route fails", func() {
+			var expectedErr error
 
-								It("returns the error", func() {
-									Expect(executeErr).To(MatchError("some-error"))
-									Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "route-create-warning", "map-warning"))
-								})
-							})
-							Context("when the map command succeeds", func() {
+			BeforeEach(func() {
+				expectedErr = errors.New("delete failed")
+				fakeV2Actor.DeleteRouteStub = func(routeGUID string) (v2action.Warnings, error) {
+					if routeGUID == "some-route-guid-1" {
+						return v2action.Warnings{"delete-route-warning"}, expectedErr
+					}
+					return nil, nil
+				}
+			})
 
-								BeforeEach(func() {
-									fakeV2Actor.MapRouteToApplicationReturns(
-										v2action.Warnings{"map-warning"},
-										nil,
-									)
-								})
+			It("continues deleting and returns the routes that were not deleted with the error", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(undeletedRoutes).To(Equal([]v2action.Route{routes[0], routes[1]}))
+				Expect(warnings).To(ContainElement("delete-route-warning"))
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(2))
+			})
+		})
 
-								It("creates the route, maps it to the app, and returns any warnings", func() {
-									Expect(executeErr).ToNot(HaveOccurred())
-									Expect(warnings).To(ConsistOf("domain-warning", "route-warning", "route-create-warning", "map-warning"))
+		Context("when getting a route's applications fails", func() {
+			var expectedErr error
 
-									Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(1), "Expected CreateRoute to be called once, but it was not")
-									defaultRoute, shouldGeneratePort := fakeV2Actor.CreateRouteArgsForCall(0)
-									Expect(defaultRoute).To(Equal(v2action.Route{
-										Host: "some-app",
-										Domain: v2action.Domain{
-											Name: "some-domain",
-											GUID: "some-domain-guid",
-										},
-										SpaceGUID: "some-space-guid",
-									}))
-									Expect(shouldGeneratePort).To(BeFalse())
+			BeforeEach(func() {
+				expectedErr = errors.New("get apps failed")
+				fakeV2Actor.GetRouteApplicationsStub = func(routeGUID string) ([]v2action.Application, v2action.Warnings, error) {
+					if routeGUID == "some-route-guid-1" {
+						return nil, v2action.Warnings{"get-route-apps-warning"}, expectedErr
+					}
+					return nil, nil, nil
+				}
+			})
 
-									Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1), "Expected FindRouteBoundToSpaceWithSettings to be called once, but it was not")
-									spaceRoute := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
-									Expect(spaceRoute).To(Equal(v2action.Route{
-										Host: "some-app",
-										Domain: v2action.Domain{
-											Name: "some-domain",
-											GUID: "some-domain-guid",
-										},
-										SpaceGUID: "some-space-guid",
-									}))
+			It("does not delete that route", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(undeletedRoutes).To(Equal([]v2action.Route{routes[0]}))
+				Expect(warnings).To(ContainElement("get-route-apps-warning"))
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(2))
+				Expect(fakeV2Actor.DeleteRouteArgsForCall(0)).To(Equal("some-route-guid-2"))
+			})
+		})
 
-									Expect(fakeV2Actor.MapRouteToApplicationCallCount()).To(Equal(1), "Expected MapRouteToApplication to be called once, but it was not")
-									routeGUID, appGUID := fakeV2Actor.MapRouteToApplicationArgsForCall(0)
-									Expect(routeGUID).To(Equal("some-route-guid"))
-									Expect(appGUID).To(Equal("some-app-guid"))
-								})
-							})
-						})
-					})
-				})
+		Context("when the context is cancelled", func() {
+			BeforeEach(func() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(context.Background())
+				fakeV2Actor.DeleteRouteStub = func(string) (v2action.Warnings, error) {
+					cancel()
+					return nil, nil
+				}
+			})
+
+			It("stops deleting routes and returns the remaining routes", func() {
+				Expect(executeErr).To(MatchError(context.Canceled))
+				Expect(undeletedRoutes).To(Equal([]v2action.Route{routes[1], routes[2]}))
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when there are no routes", func() {
+			BeforeEach(func() {
+				routes = nil
+			})
+
+			It("does nothing", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(undeletedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.GetRouteApplicationsCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(0))
 			})
 		})
 	})
 
-	Describe("CreateRoutes", func() {
+	Describe("GetOrphanedRoutes", func() {
 		var (
-			config ApplicationConfig
-
-			returnedConfig ApplicationConfig
-			createdRoutes  bool
+			ctx            context.Context
+			orphanedRoutes []v2action.Route
 			warnings       Warnings
 			executeErr     error
 		)
 
 		BeforeEach(func() {
-			config = ApplicationConfig{}
+			ctx = context.Background()
+			fakeV2Actor.GetSpaceRoutesReturns([]v2action.Route{
+				{GUID: "some-route-guid-1", Host: "some-route-1"},
+				{GUID: "some-route-guid-2", Host: "some-route-2"},
+				{GUID: "some-route-guid-3", Host: "some-route-3"},
+				{GUID: "some-route-guid-4", Host: "some-route-4"},
+			}, v2action.Warnings{"get-space-routes-warning"}, nil)
+
+			fakeV2Actor.GetRouteApplicationsStub = func(routeGUID string) ([]v2action.Application, v2action.Warnings, error) {
+				warnings := v2action.Warnings{"get-route-apps-warning-" + routeGUID}
+				switch routeGUID {
+				case "some-route-guid-1", "some-route-guid-3":
+					return []v2action.Application{{GUID: "some-app-guid"}}, warnings, nil
+				default:
+					return nil, warnings, nil
+				}
+			}
 		})
 
 		JustBeforeEach(func() {
-			returnedConfig, createdRoutes, warnings, executeErr = actor.CreateRoutes(config)
+			orphanedRoutes, warnings, executeErr = actor.GetOrphanedRoutes(ctx, "some-space-guid")
 		})
 
-		Describe("when routes need to be created", func() {
+		Context("when the context is cancelled", func() {
 			BeforeEach(func() {
-				config.DesiredRoutes = []v2action.Route{
-					{GUID: "", Host: "some-route-1"},
-					{GUID: "some-route-guid-2", Host: "some-route-2"},
-					{GUID: "", Host: "some-route-3"},
-					{GUID: "", Host: "", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}},
-				}
+				cancelledCtx, cancel := context.WithCancel(context.Background())
+				cancel()
+				ctx = cancelledCtx
 			})
 
-			Context("when the creation is successful", func() {
-				BeforeEach(func() {
-					fakeV2Actor.CreateRouteReturnsOnCall(0, v2action.Route{GUID: "some-route-guid-1", Host: "some-route-1"}, v2action.Warnings{"create-route-warning"}, nil)
-					fakeV2Actor.CreateRouteReturnsOnCall(1, v2action.Route{GUID: "some-route-guid-3", Host: "some-route-3"}, v2action.Warnings{"create-route-warning"}, nil)
-					fakeV2Actor.CreateRouteReturnsOnCall(2, v2action.Route{GUID: "some-route-guid-4", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}}, v2action.Warnings{"create-route-warning"}, nil)
-				})
+			It("returns the context's error without looking up any applications", func() {
+				Expect(executeErr).To(MatchError(context.Canceled))
+				Expect(orphanedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.GetRouteApplicationsCallCount()).To(Equal(0))
+			})
+		})
 
-				It("only creates the routes that do not exist", func() {
-					Expect(executeErr).ToNot(HaveOccurred())
-					Expect(warnings).To(ConsistOf("create-route-warning", "create-route-warning", "create-route-warning"))
-					Expect(createdRoutes).To(BeTrue())
-					Expect(returnedConfig.DesiredRoutes).To(Equal([]v2action.Route{
-						{GUID: "some-route-guid-1", Host: "some-route-1"},
-						{GUID: "some-route-guid-2", Host: "some-route-2"},
-						{GUID: "some-route-guid-3", Host: "some-route-3"},
-						{GUID: "some-route-guid-4", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}},
-					}))
+		It("returns the routes that are not mapped to any application", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(orphanedRoutes).To(Equal([]v2action.Route{
+				{GUID: "some-route-guid-2", Host: "some-route-2"},
+				{GUID: "some-route-guid-4", Host: "some-route-4"},
+			}))
+			Expect(warnings).To(Equal(Warnings{
+				"get-space-routes-warning",
+				"get-route-apps-warning-some-route-guid-1",
+				"get-route-apps-warning-some-route-guid-2",
+				"get-route-apps-warning-some-route-guid-3",
+				"get-route-apps-warning-some-route-guid-4",
+			}))
+
+			Expect(fakeV2Actor.GetSpaceRoutesCallCount()).To(Equal(1))
+			Expect(fakeV2Actor.GetSpaceRoutesArgsForCall(0)).To(Equal("some-space-guid"))
+			Expect(fakeV2Actor.GetRouteApplicationsCallCount()).To(Equal(4))
+		})
 
-					Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(3))
+		Context("when every route is mapped to an application", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetRouteApplicationsStub = nil
+				fakeV2Actor.GetRouteApplicationsReturns([]v2action.Application{{GUID: "some-app-guid"}}, nil, nil)
+			})
 
-					passedRoute, randomRoute := fakeV2Actor.CreateRouteArgsForCall(0)
-					Expect(passedRoute).To(Equal(v2action.Route{Host: "some-route-1"}))
-					Expect(randomRoute).To(BeFalse())
+			It("returns no routes", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(orphanedRoutes).To(BeEmpty())
+			})
+		})
 
-					passedRoute, randomRoute = fakeV2Actor.CreateRouteArgsForCall(1)
-					Expect(passedRoute).To(Equal(v2action.Route{Host: "some-route-3"}))
-					Expect(randomRoute).To(BeFalse())
+		Context("when getting the space routes fails", func() {
+			var expectedErr error
 
-					passedRoute, randomRoute = fakeV2Actor.CreateRouteArgsForCall(2)
-					Expect(passedRoute).To(Equal(v2action.Route{GUID: "", Host: "", Domain: v2action.Domain{RouterGroupType: constant.TCPRouterGroup}}))
-					Expect(randomRoute).To(BeTrue())
-				})
+			BeforeEach(func() {
+				expectedErr = errors.New("space routes failed")
+				fakeV2Actor.GetSpaceRoutesReturns(nil, v2action.Warnings{"get-space-routes-warning"}, expectedErr)
 			})
 
-			Context("when the creation errors", func() {
-				var expectedErr error
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ConsistOf("get-space-routes-warning"))
+				Expect(fakeV2Actor.GetRouteApplicationsCallCount()).To(Equal(0))
+			})
+		})
 
-				BeforeEach(func() {
-					expectedErr = errors.New("oh my")
-					fakeV2Actor.CreateRouteReturns(
-						v2action.Route{},
-						v2action.Warnings{"create-route-warning"},
-						expectedErr)
-				})
+		Context("when getting a route's applications fails", func() {
+			var expectedErr error
 
-				It("sends the warnings and errors and returns true", func() {
-					Expect(executeErr).To(MatchError(expectedErr))
-					Expect(warnings).To(ConsistOf("create-route-warning"))
-				})
+			BeforeEach(func() {
+				actor.RouteConcurrency = 1
+				expectedErr = errors.New("route applications failed")
+				fakeV2Actor.GetRouteApplicationsStub = nil
+				fakeV2Actor.GetRouteApplicationsReturns(nil, v2action.Warnings{"get-route-apps-warning"}, nil)
+				fakeV2Actor.GetRouteApplicationsReturnsOnCall(1, nil, v2action.Warnings{"get-route-apps-warning"}, expectedErr)
+			})
+
+			It("returns the error and warnings without returning any routes", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(orphanedRoutes).To(BeEmpty())
+				Expect(warnings).To(Equal(Warnings{"get-space-routes-warning", "get-route-apps-warning", "get-route-apps-warning"}))
+				Expect(fakeV2Actor.GetRouteApplicationsCallCount()).To(Equal(2))
 			})
 		})
+	})
 
-		Context("when no routes are created", func() {
-			BeforeEach(func() {
-				config.DesiredRoutes = []v2action.Route{
-					{GUID: "some-route-guid-1", Host: "some-route-1"},
-					{GUID: "some-route-guid-2", Host: "some-route-2"},
-					{GUID: "some-route-guid-3", Host: "some-route-3"},
+	Describe("DeleteEphemeralRoutes", func() {
+		var (
+			olderThan     time.Duration
+			deletedRoutes []v2action.Route
+			warnings      Warnings
+			executeErr    error
+
+			ephemeral   v2action.Metadata
+			ephemeralV3 v3action.Metadata
+			oldTime     time.Time
+			newTime     time.Time
+		)
+
+		BeforeEach(func() {
+			olderThan = time.Hour
+			ephemeral = v2action.Metadata{Annotations: map[string]string{v2action.EphemeralRouteAnnotation: "true"}}
+			ephemeralV3 = v3action.Metadata{Annotations: map[string]types.FilteredString{v2action.EphemeralRouteAnnotation: {IsSet: true, Value: "true"}}}
+			oldTime = time.Now().Add(-2 * time.Hour)
+			newTime = time.Now().Add(-time.Minute)
+
+			fakeV2Actor.GetSpaceRoutesReturns([]v2action.Route{
+				{GUID: "old-ephemeral-route-guid", Host: "old-ephemeral", CreatedAt: oldTime},
+				{GUID: "new-ephemeral-route-guid", Host: "new-ephemeral", CreatedAt: newTime},
+				{GUID: "old-permanent-route-guid", Host: "old-permanent", CreatedAt: oldTime},
+				{GUID: "unknown-age-route-guid", Host: "unknown-age"},
+			}, v2action.Warnings{"get-space-routes-warning"}, nil)
+
+			fakeV3Actor.GetRouteMetadataStub = func(routeGUID string) (v3action.Metadata, v3action.Warnings, error) {
+				warnings := v3action.Warnings{"get-route-metadata-warning-" + routeGUID}
+				switch routeGUID {
+				case "old-permanent-route-guid":
+					return v3action.Metadata{Labels: map[string]types.FilteredString{"env": {IsSet: true, Value: "production"}}}, warnings, nil
+				default:
+					return ephemeralV3, warnings, nil
 				}
+			}
+			fakeV2Actor.DeleteRouteReturns(v2action.Warnings{"delete-route-warning"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			deletedRoutes, warnings, executeErr = actor.DeleteEphemeralRoutes("some-space-guid", olderThan)
+		})
+
+		It("only deletes the ephemeral routes that are older than the provided age", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(deletedRoutes).To(Equal([]v2action.Route{
+				{GUID: "old-ephemeral-route-guid", Host: "old-ephemeral", CreatedAt: oldTime, Metadata: ephemeral},
+			}))
+			Expect(warnings).To(Equal(Warnings{
+				"get-space-routes-warning",
+				"get-route-metadata-warning-old-ephemeral-route-guid",
+				"delete-route-warning",
+				"get-route-metadata-warning-old-permanent-route-guid",
+			}))
+
+			Expect(fakeV2Actor.GetSpaceRoutesArgsForCall(0)).To(Equal("some-space-guid"))
+			Expect(fakeV3Actor.GetRouteMetadataCallCount()).To(Equal(2))
+			Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(1))
+			Expect(fakeV2Actor.DeleteRouteArgsForCall(0)).To(Equal("old-ephemeral-route-guid"))
+		})
+
+		Context("when no routes are old enough", func() {
+			BeforeEach(func() {
+				olderThan = 3 * time.Hour
+			})
+
+			It("does not delete any routes", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(deletedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting the space routes fails", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetSpaceRoutesReturns(nil, v2action.Warnings{"get-space-routes-warning"}, errors.New("space routes failed"))
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError("space routes failed"))
+				Expect(warnings).To(ConsistOf("get-space-routes-warning"))
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting a route's metadata or deleting a route fails", func() {
+			BeforeEach(func() {
+				fakeV2Actor.GetSpaceRoutesReturns([]v2action.Route{
+					{GUID: "route-guid-1", CreatedAt: oldTime},
+					{GUID: "route-guid-2", CreatedAt: oldTime},
+					{GUID: "route-guid-3", CreatedAt: oldTime},
+				}, nil, nil)
+				fakeV3Actor.GetRouteMetadataStub = nil
+				fakeV3Actor.GetRouteMetadataReturns(ephemeralV3, nil, nil)
+				fakeV3Actor.GetRouteMetadataReturnsOnCall(0, v3action.Metadata{}, nil, errors.New("metadata failed"))
+				fakeV2Actor.DeleteRouteReturnsOnCall(0, nil, errors.New("delete failed"))
+			})
+
+			It("continues with the other routes and returns the first error", func() {
+				Expect(executeErr).To(MatchError("metadata failed"))
+				Expect(deletedRoutes).To(Equal([]v2action.Route{
+					{GUID: "route-guid-3", CreatedAt: oldTime, Metadata: ephemeral},
+				}))
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when there is no V3 actor", func() {
+			BeforeEach(func() {
+				actor.V3Actor = nil
 			})
 
-			It("returns false", func() {
-				Expect(createdRoutes).To(BeFalse())
+			It("does not delete any routes", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(deletedRoutes).To(BeEmpty())
+				Expect(fakeV2Actor.GetSpaceRoutesCallCount()).To(Equal(0))
+				Expect(fakeV2Actor.DeleteRouteCallCount()).To(Equal(0))
 			})
 		})
 	})
@@ -823,6 +5489,7 @@ var _ = Describe("Routes", func() {
 			knownRoutes      []v2action.Route
 
 			defaultRoute v2action.Route
+			status       GeneratedRouteStatus
 			warnings     Warnings
 			executeErr   error
 
@@ -844,7 +5511,7 @@ var _ = Describe("Routes", func() {
 		})
 
 		JustBeforeEach(func() {
-			defaultRoute, warnings, executeErr = actor.GetGeneratedRoute(providedManifest, orgGUID, spaceGUID, knownRoutes)
+			defaultRoute, status, warnings, executeErr = actor.GetGeneratedRoute(providedManifest, orgGUID, spaceGUID, knownRoutes)
 		})
 
 		Context("the domain is provided", func() {
@@ -882,6 +5549,7 @@ var _ = Describe("Routes", func() {
 						domainNamesArg, orgGUIDArg := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
 						Expect(domainNamesArg).To(Equal([]string{"shared-domain.com"}))
 						Expect(orgGUIDArg).To(Equal(orgGUID))
+						Expect(fakeV2Actor.GetDomainCallCount()).To(Equal(0))
 
 						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
 						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)).To(Equal(v2action.Route{
@@ -892,6 +5560,94 @@ var _ = Describe("Routes", func() {
 					})
 				})
 
+				Context("when the provided domain is an internal domain", func() {
+					BeforeEach(func() {
+						domain.Internal = true
+
+						fakeV2Actor.GetDomainsByNameAndOrganizationReturns(
+							[]v2action.Domain{domain},
+							v2action.Warnings{"some-organization-domain-warning"},
+							nil,
+						)
+					})
+
+					It("generates a route with the hostname on the internal domain", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings).To(ConsistOf("some-organization-domain-warning", "get-route-warnings"))
+						Expect(defaultRoute).To(Equal(v2action.Route{
+							Domain:    domain,
+							Host:      strings.ToLower(providedManifest.Name),
+							SpaceGUID: spaceGUID,
+						}))
+					})
+
+					Context("when a route path is provided", func() {
+						BeforeEach(func() {
+							providedManifest.RoutePath = "/some-path"
+						})
+
+						It("returns an InvalidInternalRouteSettings error", func() {
+							Expect(executeErr).To(MatchError(actionerror.InvalidInternalRouteSettings{Domain: domain.Name}))
+							Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+						})
+					})
+				})
+
+				Context("when an internal route is requested on an external domain", func() {
+					BeforeEach(func() {
+						providedManifest.InternalRoute = true
+
+						fakeV2Actor.GetDomainsByNameAndOrganizationReturns(
+							[]v2action.Domain{domain},
+							v2action.Warnings{"some-organization-domain-warning"},
+							nil,
+						)
+					})
+
+					It("returns an InternalRouteOnExternalDomainError", func() {
+						Expect(executeErr).To(MatchError(actionerror.InternalRouteOnExternalDomainError{Domain: domain.Name}))
+						Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the provided domain has the internal domain name", func() {
+					BeforeEach(func() {
+						domain.Name = DefaultInternalDomainName
+						providedManifest.Domain = DefaultInternalDomainName
+
+						fakeV2Actor.GetDomainsByNameAndOrganizationReturns(
+							[]v2action.Domain{domain},
+							v2action.Warnings{"some-organization-domain-warning"},
+							nil,
+						)
+					})
+
+					Context("when an internal route is requested", func() {
+						BeforeEach(func() {
+							providedManifest.InternalRoute = true
+						})
+
+						It("treats the domain as internal", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+							Expect(defaultRoute.Domain.IsInternal()).To(BeTrue())
+							Expect(defaultRoute.Host).To(Equal(strings.ToLower(providedManifest.Name)))
+						})
+					})
+
+					Context("when a route path is provided", func() {
+						BeforeEach(func() {
+							providedManifest.RoutePath = "/some-path"
+						})
+
+						It("returns an InvalidInternalRouteSettings error", func() {
+							Expect(executeErr).To(MatchError(actionerror.InvalidInternalRouteSettings{Domain: DefaultInternalDomainName}))
+							Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+							Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+						})
+					})
+				})
+
 				Context("when the provided domain is an TCP domain", func() {
 					BeforeEach(func() {
 						domain.RouterGroupType = constant.TCPRouterGroup
@@ -921,6 +5677,49 @@ var _ = Describe("Routes", func() {
 				})
 			})
 
+			Context("when several domains have the provided name", func() {
+				var (
+					sharedDomain  v2action.Domain
+					privateDomain v2action.Domain
+				)
+
+				BeforeEach(func() {
+					sharedDomain = v2action.Domain{Name: "shared-domain.com", GUID: "some-shared-domain-guid", Type: constant.SharedDomain}
+					privateDomain = v2action.Domain{Name: "shared-domain.com", GUID: "some-private-domain-guid", Type: constant.PrivateDomain}
+
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{sharedDomain, privateDomain}, nil, nil)
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+				})
+
+				It("uses the private domain by default", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(defaultRoute.Domain).To(Equal(privateDomain))
+				})
+
+				Context("when shared domains are preferred", func() {
+					BeforeEach(func() {
+						actor.DomainPreference = []constant.DomainType{constant.SharedDomain, constant.PrivateDomain}
+						fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{privateDomain, sharedDomain}, nil, nil)
+					})
+
+					It("uses the shared domain", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.Domain).To(Equal(sharedDomain))
+					})
+				})
+
+				Context("when none of the domains have a preferred type", func() {
+					BeforeEach(func() {
+						actor.DomainPreference = nil
+					})
+
+					It("uses the first domain", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.Domain).To(Equal(sharedDomain))
+					})
+				})
+			})
+
 			Context("when the provided domain does not exist", func() {
 				BeforeEach(func() {
 					fakeV2Actor.GetDomainsByNameAndOrganizationReturns(
@@ -934,6 +5733,265 @@ var _ = Describe("Routes", func() {
 					Expect(executeErr).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
 					Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
 				})
+
+				Context("when the organization has a domain with a close name", func() {
+					BeforeEach(func() {
+						fakeV2Actor.GetOrganizationDomainsReturns(
+							[]v2action.Domain{
+								{Name: "example.com", GUID: "example-domain-guid"},
+								{Name: "Shared-Domian.com", GUID: "close-domain-guid"},
+								{Name: "shared-domain.org", GUID: "closer-domain-guid"},
+							},
+							v2action.Warnings{"get-organization-domains-warning"},
+							nil,
+						)
+					})
+
+					It("suggests the closest domain without returning the lookup's warnings", func() {
+						Expect(executeErr).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com", Suggestion: "Shared-Domian.com"}))
+						Expect(executeErr.Error()).To(Equal("Domain shared-domain.com not found; did you mean Shared-Domian.com?"))
+						Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+
+						Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(1))
+						Expect(fakeV2Actor.GetOrganizationDomainsArgsForCall(0)).To(Equal(orgGUID))
+					})
+
+					Context("when the organization has too many domains", func() {
+						BeforeEach(func() {
+							domains := []v2action.Domain{{Name: "shared-domain.co"}}
+							for i := 0; i < 100; i++ {
+								domains = append(domains, v2action.Domain{Name: fmt.Sprintf("domain-%d.com", i)})
+							}
+							fakeV2Actor.GetOrganizationDomainsReturns(domains, nil, nil)
+						})
+
+						It("does not suggest a domain", func() {
+							Expect(executeErr).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+						})
+					})
+				})
+
+				Context("when the organization has no domain with a close name", func() {
+					BeforeEach(func() {
+						fakeV2Actor.GetOrganizationDomainsReturns(
+							[]v2action.Domain{
+								{Name: "example.com", GUID: "example-domain-guid"},
+								{Name: "private-domain.org", GUID: "private-domain-guid"},
+							},
+							nil,
+							nil,
+						)
+					})
+
+					It("does not suggest a domain", func() {
+						Expect(executeErr).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+						Expect(executeErr.Error()).To(Equal("Domain shared-domain.com not found"))
+					})
+				})
+
+				Context("when looking up the organization's domains fails", func() {
+					BeforeEach(func() {
+						fakeV2Actor.GetOrganizationDomainsReturns(nil, v2action.Warnings{"get-organization-domains-warning"}, errors.New("some-error"))
+					})
+
+					It("returns the DomainNotFoundError without a suggestion", func() {
+						Expect(executeErr).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+						Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+					})
+				})
+
+				Context("when the domain is referenced again in the same push", func() {
+					var pushActor Actor
+
+					BeforeEach(func() {
+						pushActor = actor.ForPush()
+					})
+
+					It("only looks the domain up once", func() {
+						for i := 0; i < 3; i++ {
+							_, _, pushWarnings, err := pushActor.GetGeneratedRoute(providedManifest, orgGUID, spaceGUID, knownRoutes)
+							Expect(err).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+							if i == 0 {
+								Expect(pushWarnings).To(ConsistOf("some-organization-domain-warning"))
+							} else {
+								Expect(pushWarnings).To(BeEmpty())
+							}
+						}
+
+						// One lookup from the JustBeforeEach, which is not part of the push.
+						Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+					})
+
+					It("looks the domain up again in the next push", func() {
+						_, _, _, err := pushActor.GetGeneratedRoute(providedManifest, orgGUID, spaceGUID, knownRoutes)
+						Expect(err).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+
+						_, _, _, err = actor.ForPush().GetGeneratedRoute(providedManifest, orgGUID, spaceGUID, knownRoutes)
+						Expect(err).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+
+						Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(3))
+					})
+				})
+
+				Context("when the domain is referenced again outside of a push", func() {
+					It("looks the domain up again", func() {
+						_, _, _, err := actor.GetGeneratedRoute(providedManifest, orgGUID, spaceGUID, knownRoutes)
+						Expect(err).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+						Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(2))
+					})
+				})
+			})
+		})
+
+		Context("when the manifest has a default domain", func() {
+			BeforeEach(func() {
+				providedManifest.DefaultDomain = "shared-domain.com"
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+				fakeV2Actor.GetDomainsByNameAndOrganizationReturns(
+					[]v2action.Domain{domain},
+					v2action.Warnings{"some-organization-domain-warning"},
+					nil,
+				)
+			})
+
+			It("uses the manifest's default domain instead of the organization's", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+				Expect(defaultRoute).To(Equal(v2action.Route{
+					Domain:    domain,
+					Host:      strings.ToLower(providedManifest.Name),
+					SpaceGUID: spaceGUID,
+				}))
+
+				Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				domainNamesArg, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+				Expect(domainNamesArg).To(Equal([]string{"shared-domain.com"}))
+				Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(0))
+			})
+
+			Context("when there is a DomainCache", func() {
+				BeforeEach(func() {
+					actor.DomainCache = NewDomainCache(DefaultDomainCacheTTL)
+				})
+
+				It("only looks the domain up once for several applications", func() {
+					otherApp := manifest.Application{Name: "other-app", DefaultDomain: "shared-domain.com"}
+					otherRoute, _, otherWarnings, err := actor.GetGeneratedRoute(otherApp, orgGUID, spaceGUID, knownRoutes)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(otherWarnings).To(BeEmpty())
+					Expect(otherRoute.Domain).To(Equal(domain))
+					Expect(otherRoute.Host).To(Equal("other-app"))
+
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the application names its own domain", func() {
+				var otherDomain v2action.Domain
+
+				BeforeEach(func() {
+					otherDomain = v2action.Domain{Name: "other-domain.com", GUID: "other-domain-guid"}
+					providedManifest.Domain = "other-domain.com"
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns([]v2action.Domain{otherDomain}, nil, nil)
+				})
+
+				It("uses the application's domain", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(defaultRoute.Domain).To(Equal(otherDomain))
+
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+					domainNamesArg, _ := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+					Expect(domainNamesArg).To(Equal([]string{"other-domain.com"}))
+				})
+			})
+
+			Context("when the default domain does not exist", func() {
+				BeforeEach(func() {
+					fakeV2Actor.GetDomainsByNameAndOrganizationReturns(nil, v2action.Warnings{"some-organization-domain-warning"}, nil)
+				})
+
+				It("returns a DomainNotFoundError", func() {
+					Expect(executeErr).To(MatchError(actionerror.DomainNotFoundError{Name: "shared-domain.com"}))
+					Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+				})
+			})
+		})
+
+		Context("when the domain GUID is provided", func() {
+			BeforeEach(func() {
+				providedManifest.DomainGUID = "some-shared-domain-guid"
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"get-route-warnings"}, actionerror.RouteNotFoundError{})
+			})
+
+			Context("when the domain is also named and the route settings do not depend on its type", func() {
+				BeforeEach(func() {
+					providedManifest.Domain = "shared-domain.com"
+				})
+
+				It("uses the domain without looking it up", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("get-route-warnings"))
+					Expect(defaultRoute).To(Equal(v2action.Route{
+						Domain:    v2action.Domain{Name: "shared-domain.com", GUID: "some-shared-domain-guid"},
+						Host:      "some-app",
+						SpaceGUID: spaceGUID,
+					}))
+
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(0))
+					Expect(fakeV2Actor.GetDomainCallCount()).To(Equal(0))
+				})
+
+				Context("when the route settings depend on the domain's type", func() {
+					BeforeEach(func() {
+						providedManifest.Hostname = "some-host"
+						domain.RouterGroupType = constant.TCPRouterGroup
+						fakeV2Actor.GetDomainReturns(domain, v2action.Warnings{"get-domain-warning"}, nil)
+					})
+
+					It("looks the domain up by its GUID", func() {
+						Expect(executeErr).To(MatchError(actionerror.HostnameWithTCPDomainError{}))
+						Expect(warnings).To(ConsistOf("get-domain-warning"))
+
+						Expect(fakeV2Actor.GetDomainCallCount()).To(Equal(1))
+						Expect(fakeV2Actor.GetDomainArgsForCall(0)).To(Equal("some-shared-domain-guid"))
+						Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the domain is not named", func() {
+				BeforeEach(func() {
+					domain.Type = constant.SharedDomain
+					fakeV2Actor.GetDomainReturns(domain, v2action.Warnings{"get-domain-warning"}, nil)
+				})
+
+				It("looks the domain up by its GUID", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("get-domain-warning", "get-route-warnings"))
+					Expect(defaultRoute).To(Equal(v2action.Route{
+						Domain:    domain,
+						Host:      "some-app",
+						SpaceGUID: spaceGUID,
+					}))
+
+					Expect(fakeV2Actor.GetDomainCallCount()).To(Equal(1))
+					Expect(fakeV2Actor.GetDomainArgsForCall(0)).To(Equal("some-shared-domain-guid"))
+					Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(0))
+				})
+
+				Context("when the domain does not exist", func() {
+					BeforeEach(func() {
+						fakeV2Actor.GetDomainReturns(v2action.Domain{}, v2action.Warnings{"get-domain-warning"}, actionerror.DomainNotFoundError{GUID: "some-shared-domain-guid"})
+					})
+
+					It("returns the DomainNotFoundError and warnings", func() {
+						Expect(executeErr).To(MatchError(actionerror.DomainNotFoundError{GUID: "some-shared-domain-guid"}))
+						Expect(warnings).To(ConsistOf("get-domain-warning"))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+					})
+				})
 			})
 		})
 
@@ -990,6 +6048,58 @@ var _ = Describe("Routes", func() {
 			})
 		})
 
+		Context("the wildcard hostname is provided", func() {
+			BeforeEach(func() {
+				providedManifest.Hostname = "*"
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"get-route-warnings"}, actionerror.RouteNotFoundError{})
+			})
+
+			Context("when the domain allows wildcard hosts", func() {
+				BeforeEach(func() {
+					domain.Type = constant.PrivateDomain
+					fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{domain}, nil, nil)
+				})
+
+				It("uses the wildcard host", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(defaultRoute).To(Equal(v2action.Route{
+						Domain:    domain,
+						Host:      "*",
+						SpaceGUID: spaceGUID,
+					}))
+					Expect(defaultRoute.Validate()).To(Succeed())
+
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0).Host).To(Equal("*"))
+				})
+			})
+
+			Context("when the domain does not allow wildcard hosts", func() {
+				BeforeEach(func() {
+					domain.Type = constant.SharedDomain
+					fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{domain}, v2action.Warnings{"some-organization-domain-warning"}, nil)
+				})
+
+				It("returns a WildcardHostNotAllowedError", func() {
+					Expect(executeErr).To(MatchError(actionerror.WildcardHostNotAllowedError{Domain: "shared-domain.com"}))
+					Expect(warnings).To(ConsistOf("some-organization-domain-warning"))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the domain is a TCP domain", func() {
+				BeforeEach(func() {
+					domain.Type = constant.PrivateDomain
+					domain.RouterGroupType = constant.TCPRouterGroup
+					fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{domain}, nil, nil)
+				})
+
+				It("returns a WildcardHostNotAllowedError", func() {
+					Expect(executeErr).To(MatchError(actionerror.WildcardHostNotAllowedError{Domain: "shared-domain.com"}))
+				})
+			})
+		})
+
 		Context("when no hostname is requested", func() {
 			BeforeEach(func() {
 				providedManifest.NoHostname = true
@@ -1107,6 +6217,250 @@ var _ = Describe("Routes", func() {
 			})
 		})
 
+		Context("when random-route is set", func() {
+			var fakeWordGenerator *generatorfakes.FakeWordGenerator
+
+			BeforeEach(func() {
+				providedManifest.RandomRoute = true
+
+				fakeWordGenerator = new(generatorfakes.FakeWordGenerator)
+				fakeWordGenerator.BabbleReturnsOnCall(0, "Brave-Lion")
+				fakeWordGenerator.BabbleReturnsOnCall(1, "quiet-owl")
+				actor.WordGenerator = fakeWordGenerator
+
+				domain.Type = constant.SharedDomain
+				fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{domain}, v2action.Warnings{"domain-warning"}, nil)
+				fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"get-route-warning"}, actionerror.RouteNotFoundError{})
+			})
+
+			Context("when the domain is an HTTP domain", func() {
+				It("adds a sanitized random suffix to the host", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("domain-warning", "get-route-warning"))
+					Expect(defaultRoute).To(Equal(v2action.Route{
+						Domain:    domain,
+						Host:      "some-app-brave-lion",
+						SpaceGUID: spaceGUID,
+					}))
+
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0).Host).To(Equal("some-app-brave-lion"))
+				})
+
+				Context("when the actor has no WordGenerator", func() {
+					BeforeEach(func() {
+						actor.WordGenerator = nil
+					})
+
+					It("uses words from the CLI's word lists", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.Host).To(MatchRegexp(`^some-app-[a-z]+-[a-z]+$`))
+					})
+				})
+
+				Context("when the random route is already taken", func() {
+					BeforeEach(func() {
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturnsOnCall(0, v2action.Route{GUID: "taken-route-guid"}, v2action.Warnings{"taken-warning"}, nil)
+					})
+
+					It("tries another random host", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(warnings).To(ConsistOf("domain-warning", "taken-warning", "get-route-warning"))
+						Expect(defaultRoute.Host).To(Equal("some-app-quiet-owl"))
+						Expect(defaultRoute.GUID).To(BeEmpty())
+						Expect(status).To(Equal(GeneratedRouteNew))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(2))
+					})
+				})
+
+				Context("when the random route is in another space", func() {
+					BeforeEach(func() {
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturnsOnCall(0, v2action.Route{}, nil, actionerror.RouteInDifferentSpaceError{})
+					})
+
+					It("tries another random host", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.Host).To(Equal("some-app-quiet-owl"))
+					})
+				})
+
+				Context("when the random route is already known", func() {
+					BeforeEach(func() {
+						knownRoutes = []v2action.Route{{
+							Domain:    domain,
+							GUID:      "known-route-guid",
+							Host:      "some-app-brave-lion",
+							SpaceGUID: spaceGUID,
+						}}
+					})
+
+					It("tries another random host without looking up the known one", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.Host).To(Equal("some-app-quiet-owl"))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when every random route is taken", func() {
+					BeforeEach(func() {
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{GUID: "taken-route-guid"}, nil, nil)
+					})
+
+					It("returns a RandomRouteUnavailableError", func() {
+						Expect(executeErr).To(MatchError(actionerror.RandomRouteUnavailableError{Attempts: MaxRandomRouteAttempts}))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(MaxRandomRouteAttempts))
+					})
+				})
+
+				Context("when looking up the random route errors", func() {
+					var expectedErr error
+
+					BeforeEach(func() {
+						expectedErr = errors.New("whoops")
+						fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"get-route-warning"}, expectedErr)
+					})
+
+					It("returns the error and warnings", func() {
+						Expect(executeErr).To(MatchError(expectedErr))
+						Expect(warnings).To(ConsistOf("domain-warning", "get-route-warning"))
+					})
+				})
+
+				Context("when the host and suffix are longer than the maximum hostname length", func() {
+					BeforeEach(func() {
+						providedManifest.Name = strings.Repeat("a", 50) + "-b"
+						actor.MaxHostnameLength = 20
+					})
+
+					It("shortens the host so the suffix still fits", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.Host).To(Equal(strings.Repeat("a", 9) + "-brave-lion"))
+						Expect(len(defaultRoute.Host)).To(BeNumerically("<=", 20))
+					})
+				})
+
+				Context("when a hostname is provided", func() {
+					BeforeEach(func() {
+						providedManifest.Hostname = "some-hostname"
+					})
+
+					It("uses the hostname without a random suffix", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.Host).To(Equal("some-hostname"))
+						Expect(fakeWordGenerator.BabbleCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the domain is a TCP domain", func() {
+				BeforeEach(func() {
+					domain.RouterGroupType = constant.TCPRouterGroup
+					fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{domain}, nil, nil)
+				})
+
+				It("returns a route with a random port and no host", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(defaultRoute).To(Equal(v2action.Route{
+						Domain:    domain,
+						SpaceGUID: spaceGUID,
+					}))
+					Expect(fakeWordGenerator.BabbleCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("the route protocol is provided", func() {
+			BeforeEach(func() {
+				providedManifest.RouteProtocol = "HTTP2"
+			})
+
+			Context("when the domain is an HTTP domain", func() {
+				BeforeEach(func() {
+					domain.Type = constant.SharedDomain
+					fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{domain}, nil, nil)
+
+					// Assumes new route
+					fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, nil, actionerror.RouteNotFoundError{})
+				})
+
+				It("uses the provided protocol for the route", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(defaultRoute).To(Equal(v2action.Route{
+						Domain:    domain,
+						Host:      "some-app",
+						SpaceGUID: spaceGUID,
+						Protocol:  constant.HTTP2RouteProtocol,
+					}))
+				})
+
+				Context("when a known route has the same settings and protocol", func() {
+					BeforeEach(func() {
+						knownRoutes = []v2action.Route{{
+							Domain:    domain,
+							GUID:      "some-route-guid",
+							Host:      "some-app",
+							SpaceGUID: spaceGUID,
+							Protocol:  constant.HTTP2RouteProtocol,
+						}}
+					})
+
+					It("returns the known route", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute).To(Equal(knownRoutes[0]))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when a known route has the same settings but a different protocol", func() {
+					BeforeEach(func() {
+						knownRoutes = []v2action.Route{{
+							Domain:    domain,
+							GUID:      "some-route-guid",
+							Host:      "some-app",
+							SpaceGUID: spaceGUID,
+							Protocol:  constant.HTTP1RouteProtocol,
+						}}
+					})
+
+					It("does not use the known route", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute.GUID).To(BeEmpty())
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the domain is a TCP domain", func() {
+				BeforeEach(func() {
+					providedManifest.Name = ""
+					domain.RouterGroupType = constant.TCPRouterGroup
+					fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{domain}, nil, nil)
+				})
+
+				It("returns a RouteProtocolNotSupportedByDomainError", func() {
+					Expect(executeErr).To(MatchError(actionerror.RouteProtocolNotSupportedByDomainError{
+						Protocol: "HTTP2",
+						Domain:   "shared-domain.com",
+					}))
+				})
+
+				Context("when the protocol is tcp", func() {
+					BeforeEach(func() {
+						providedManifest.RouteProtocol = "tcp"
+					})
+
+					It("uses the provided protocol for the route", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(defaultRoute).To(Equal(v2action.Route{
+							Domain:    domain,
+							SpaceGUID: spaceGUID,
+							Protocol:  constant.TCPRouteProtocol,
+						}))
+					})
+				})
+			})
+		})
+
 		Context("when no route settings are provided (default route)", func() {
 			Context("when retrieving the domains is successful", func() {
 				BeforeEach(func() {
@@ -1139,6 +6493,8 @@ var _ = Describe("Routes", func() {
 								SpaceGUID: spaceGUID,
 							}))
 
+							Expect(status).To(Equal(GeneratedRouteExistsInSpace))
+
 							Expect(fakeV2Actor.GetOrganizationDomainsCallCount()).To(Equal(1))
 							Expect(fakeV2Actor.GetOrganizationDomainsArgsForCall(0)).To(Equal(orgGUID))
 
@@ -1171,6 +6527,27 @@ var _ = Describe("Routes", func() {
 									SpaceGUID: spaceGUID,
 								}))
 
+								Expect(status).To(Equal(GeneratedRouteExistsInSpace))
+
+								Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when the route is in known routes with different capitalization", func() {
+							BeforeEach(func() {
+								providedManifest.RoutePath = "/Some-Path"
+								knownRoutes = []v2action.Route{{
+									Domain:    domain,
+									GUID:      "some-route-guid",
+									Host:      "SOME-APP",
+									Path:      "/some-path",
+									SpaceGUID: spaceGUID,
+								}}
+							})
+
+							It("should return the known route", func() {
+								Expect(executeErr).ToNot(HaveOccurred())
+								Expect(defaultRoute).To(Equal(knownRoutes[0]))
 								Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
 							})
 						})
@@ -1185,6 +6562,49 @@ var _ = Describe("Routes", func() {
 								Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings", "get-route-warnings"))
 
 								Expect(defaultRoute).To(Equal(v2action.Route{Domain: domain, Host: strings.ToLower(providedManifest.Name), SpaceGUID: spaceGUID}))
+								Expect(status).To(Equal(GeneratedRouteNew))
+
+								Expect(fakeV2Actor.GetOrganizationRoutesWithHostAndDomainCallCount()).To(Equal(1))
+								route, orgGUIDArg := fakeV2Actor.GetOrganizationRoutesWithHostAndDomainArgsForCall(0)
+								Expect(route).To(Equal(v2action.Route{Domain: domain, Host: strings.ToLower(providedManifest.Name), SpaceGUID: spaceGUID}))
+								Expect(orgGUIDArg).To(Equal(orgGUID))
+							})
+
+							Context("when a route with the same host and domain exists in another space", func() {
+								BeforeEach(func() {
+									fakeV2Actor.GetOrganizationRoutesWithHostAndDomainReturns([]v2action.Route{{
+										Domain:    domain,
+										GUID:      "other-route-guid",
+										Host:      strings.ToLower(providedManifest.Name),
+										Path:      "/other-path",
+										SpaceGUID: "other-space-guid",
+									}}, v2action.Warnings{"org-routes-warning"}, nil)
+								})
+
+								It("returns the partial route flagged as existing elsewhere", func() {
+									Expect(executeErr).ToNot(HaveOccurred())
+									Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings", "get-route-warnings", "org-routes-warning"))
+									Expect(defaultRoute).To(Equal(v2action.Route{
+										Domain:             domain,
+										Host:               strings.ToLower(providedManifest.Name),
+										SpaceGUID:          spaceGUID,
+										ExistsInOtherSpace: true,
+									}))
+									Expect(status).To(Equal(GeneratedRouteExistsElsewhere))
+								})
+							})
+						})
+
+						Context("when the route exists in a different space", func() {
+							BeforeEach(func() {
+								fakeV2Actor.FindRouteBoundToSpaceWithSettingsReturns(v2action.Route{}, v2action.Warnings{"get-route-warnings"}, actionerror.RouteInDifferentSpaceError{})
+							})
+
+							It("returns the error and a status of existing elsewhere", func() {
+								Expect(executeErr).To(MatchError(actionerror.RouteInDifferentSpaceError{}))
+								Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings", "get-route-warnings"))
+								Expect(status).To(Equal(GeneratedRouteExistsElsewhere))
+								Expect(fakeV2Actor.GetOrganizationRoutesWithHostAndDomainCallCount()).To(Equal(0))
 							})
 						})
 
@@ -1199,6 +6619,7 @@ var _ = Describe("Routes", func() {
 							It("returns errors and warnings", func() {
 								Expect(executeErr).To(MatchError(expectedErr))
 								Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings", "get-route-warnings"))
+								Expect(status).To(BeEmpty())
 							})
 						})
 					})
@@ -1219,19 +6640,115 @@ var _ = Describe("Routes", func() {
 					})
 				})
 
-				Context("when the app name is not a usable hostname", func() {
+				Context("when the app name has underscores", func() {
 					BeforeEach(func() {
-						providedManifest.Name = " %^ @# **(& "
+						providedManifest.Name = "Some_App"
 					})
 
-					It("provides the sanitized application name to FindRouteBoundToSpaceWithSettings", func() {
+					It("drops the underscores by default", func() {
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0).Host).To(Equal("someapp"))
+					})
+
+					Context("when underscores are allowed", func() {
+						BeforeEach(func() {
+							actor.AllowedHostnameCharacters = "_"
+						})
+
+						It("keeps the underscores in the hostname", func() {
+							Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+							Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0).Host).To(Equal("some_app"))
+						})
+					})
+				})
+
+				Context("when the app name is longer than the maximum hostname length", func() {
+					BeforeEach(func() {
+						providedManifest.Name = strings.Repeat("a", 60) + " b-c"
+					})
+
+					It("truncates the hostname to the maximum length", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
+						route := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
+						Expect(route.Host).To(Equal(strings.Repeat("a", 60) + "-b"))
+						Expect(len(route.Host)).To(BeNumerically("<=", DefaultMaxHostnameLength))
+					})
+
+					Context("when truncating would leave trailing hyphens", func() {
+						BeforeEach(func() {
+							providedManifest.Name = strings.Repeat("a", 61) + "  b"
+						})
+
+						It("removes the trailing hyphens", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+							route := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
+							Expect(route.Host).To(Equal(strings.Repeat("a", 61)))
+						})
+					})
+
+					Context("when the maximum hostname length is configured", func() {
+						BeforeEach(func() {
+							actor.MaxHostnameLength = 10
+						})
+
+						It("truncates the hostname to the configured length", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+							route := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
+							Expect(route.Host).To(Equal(strings.Repeat("a", 10)))
+						})
+					})
+
+					Context("when the maximum hostname length is zero", func() {
+						BeforeEach(func() {
+							actor.MaxHostnameLength = 0
+						})
+
+						It("does not truncate the hostname", func() {
+							Expect(executeErr).ToNot(HaveOccurred())
+							route := fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)
+							Expect(route.Host).To(Equal(strings.Repeat("a", 60) + "-b-c"))
+						})
+					})
+				})
+
+				Context("when the app name is partially a usable hostname", func() {
+					BeforeEach(func() {
+						providedManifest.Name = " %^ @# app **(& "
+					})
+
+					It("provides the usable part of the application name to FindRouteBoundToSpaceWithSettings", func() {
+						Expect(executeErr).ToNot(HaveOccurred())
 						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(1))
 						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsArgsForCall(0)).To(Equal(v2action.Route{
 							Domain:    domain,
+							Host:      "app",
 							SpaceGUID: spaceGUID,
 						}))
 					})
 				})
+
+				Context("when the app name is not a usable hostname", func() {
+					BeforeEach(func() {
+						providedManifest.Name = " %^ @# **(& "
+					})
+
+					It("returns an InvalidHostnameError", func() {
+						Expect(executeErr).To(MatchError(actionerror.InvalidHostnameError{Original: " %^ @# **(& "}))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the provided hostname is not a usable hostname", func() {
+					BeforeEach(func() {
+						providedManifest.Hostname = "🚀🚀"
+					})
+
+					It("returns an InvalidHostnameError", func() {
+						Expect(executeErr).To(MatchError(actionerror.InvalidHostnameError{Original: "🚀🚀"}))
+						Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+					})
+				})
 			})
 
 			Context("when retrieving the domains errors", func() {
@@ -1246,6 +6763,18 @@ var _ = Describe("Routes", func() {
 					Expect(executeErr).To(MatchError(expectedErr))
 					Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings"))
 				})
+
+				Context("when the organization has no domains", func() {
+					BeforeEach(func() {
+						fakeV2Actor.GetOrganizationDomainsReturns([]v2action.Domain{}, v2action.Warnings{"private-domain-warnings", "shared-domain-warnings"}, nil)
+					})
+
+					It("returns a NoDomainsFoundError rather than a DomainNotFoundError", func() {
+						Expect(executeErr).To(MatchError(actionerror.NoDomainsFoundError{OrganizationGUID: orgGUID}))
+						Expect(warnings).To(ConsistOf("private-domain-warnings", "shared-domain-warnings"))
+						Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+					})
+				})
 			})
 		})
 	})
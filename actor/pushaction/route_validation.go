@@ -0,0 +1,57 @@
+package pushaction
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/cli/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// RouteValidation is the result of validating a single route string. Host,
+// Domain, Path and Port are the parts of the route as far as they could be
+// worked out; Err is the reason the route is invalid, or nil when it is valid.
+type RouteValidation struct {
+	Route  string
+	Host   string
+	Domain string
+	Path   string
+	Port   types.NullInt
+	Err    error
+}
+
+// ValidateRouteString parses the route and checks it against the
+// organization's domains the same way CalculateRoutes does, without looking
+// the route up or creating it. A TCP route must specify a port. A route with a
+// port range is validated as a whole; the first port of the range is
+// returned.
+//
+// When the route is invalid, Host is the route's whole hostname, since its
+// domain may not be known. Failing to look up the organization's domains, or
+// ctx being cancelled before they are looked up, is also reported in Err,
+// since the route could not be validated.
+func (actor Actor) ValidateRouteString(ctx context.Context, route string, orgGUID string) (RouteValidation, Warnings) {
+	validation := RouteValidation{Route: route}
+
+	_, potentialRoutes, warnings, err := actor.validateRoutes(ctx, []string{route}, orgGUID, "", nil, false)
+	if err != nil {
+		log.Errorln("validating route string:", err)
+		validation.Err = err
+
+		hostname, port, path, parseErr := actor.parseURL(route)
+		if parseErr == nil {
+			validation.Host = hostname
+			validation.Port = port
+			validation.Path = path
+		}
+		return validation, warnings
+	}
+
+	if len(potentialRoutes) > 0 {
+		validated := potentialRoutes[0]
+		validation.Host = validated.Host
+		validation.Domain = validated.Domain.Name
+		validation.Path = validated.Path
+		validation.Port = validated.Port
+	}
+	return validation, warnings
+}
@@ -0,0 +1,161 @@
+package pushaction_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+	"code.cloudfoundry.org/cli/actor/pushaction/pushactionfakes"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
+	"code.cloudfoundry.org/cli/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateRouteString", func() {
+	var (
+		actor       *Actor
+		fakeV2Actor *pushactionfakes.FakeV2Actor
+
+		ctx   context.Context
+		route string
+
+		validation RouteValidation
+		warnings   Warnings
+	)
+
+	BeforeEach(func() {
+		fakeV2Actor = new(pushactionfakes.FakeV2Actor)
+		actor = NewActor(fakeV2Actor, nil, nil)
+		ctx = context.Background()
+
+		fakeV2Actor.GetDomainsByNameAndOrganizationReturns(
+			[]v2action.Domain{
+				{Name: "example.com", GUID: "some-http-domain-guid", Type: constant.SharedDomain},
+				{Name: "tcp.example.com", GUID: "some-tcp-domain-guid", Type: constant.SharedDomain, RouterGroupType: constant.TCPRouterGroup},
+			},
+			v2action.Warnings{"domain-warning"},
+			nil,
+		)
+	})
+
+	JustBeforeEach(func() {
+		validation, warnings = actor.ValidateRouteString(ctx, route, "some-org-guid")
+	})
+
+	Context("when the route is a valid HTTP route", func() {
+		BeforeEach(func() {
+			route = "app.example.com/some-path"
+		})
+
+		It("returns the parts of the route without looking it up", func() {
+			Expect(validation).To(Equal(RouteValidation{
+				Route:  route,
+				Host:   "app",
+				Domain: "example.com",
+				Path:   "/some-path",
+			}))
+			Expect(warnings).To(ConsistOf("domain-warning"))
+
+			Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(1))
+			_, orgGUID := fakeV2Actor.GetDomainsByNameAndOrganizationArgsForCall(0)
+			Expect(orgGUID).To(Equal("some-org-guid"))
+			Expect(fakeV2Actor.FindRouteBoundToSpaceWithSettingsCallCount()).To(Equal(0))
+			Expect(fakeV2Actor.CreateRouteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the route is a valid TCP route", func() {
+		BeforeEach(func() {
+			route = "tcp.example.com:1234"
+		})
+
+		It("returns the domain and port", func() {
+			Expect(validation).To(Equal(RouteValidation{
+				Route:  route,
+				Domain: "tcp.example.com",
+				Port:   types.NullInt{IsSet: true, Value: 1234},
+			}))
+		})
+	})
+
+	Context("when the route does not match any domain", func() {
+		BeforeEach(func() {
+			route = "app.unknown.com"
+		})
+
+		It("returns a NoMatchingDomainError and the route's hostname", func() {
+			Expect(validation.Err).To(MatchError(actionerror.NoMatchingDomainError{Route: route}))
+			Expect(validation.Host).To(Equal("app.unknown.com"))
+			Expect(validation.Domain).To(BeEmpty())
+		})
+	})
+
+	Context("when an HTTP route has a port", func() {
+		BeforeEach(func() {
+			route = "app.example.com:8080"
+		})
+
+		It("returns a PortWithHTTPDomainError and the parsed port", func() {
+			Expect(validation.Err).To(MatchError(actionerror.PortWithHTTPDomainError{Route: route, Domain: "example.com"}))
+			Expect(validation.Port).To(Equal(types.NullInt{IsSet: true, Value: 8080}))
+		})
+	})
+
+	Context("when a TCP route has no port", func() {
+		BeforeEach(func() {
+			route = "tcp.example.com"
+		})
+
+		It("returns a TCPRouteMissingPortError", func() {
+			Expect(validation.Err).To(MatchError(actionerror.TCPRouteMissingPortError{Route: route}))
+		})
+	})
+
+	Context("when the route cannot be parsed", func() {
+		BeforeEach(func() {
+			route = "app.example.com/some-path?query=true"
+		})
+
+		It("returns the parse error without looking up any domains", func() {
+			Expect(validation.Err).To(MatchError(actionerror.RouteQueryOrFragmentError{Route: route}))
+			Expect(validation.Host).To(BeEmpty())
+			Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when looking up the domains fails", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			route = "app.example.com"
+			expectedErr = errors.New("domains failed")
+			fakeV2Actor.GetDomainsByNameAndOrganizationReturns(nil, v2action.Warnings{"domain-warning"}, expectedErr)
+		})
+
+		It("returns the error and warnings", func() {
+			Expect(validation.Err).To(MatchError(expectedErr))
+			Expect(validation.Host).To(Equal("app.example.com"))
+			Expect(warnings).To(ConsistOf("domain-warning"))
+		})
+	})
+
+	Context("when the context is cancelled", func() {
+		BeforeEach(func() {
+			route = "app.example.com"
+
+			cancelledCtx, cancel := context.WithCancel(context.Background())
+			cancel()
+			ctx = cancelledCtx
+		})
+
+		It("returns the context's error without looking up the domains", func() {
+			Expect(validation.Err).To(MatchError(context.Canceled))
+			Expect(validation.Host).To(Equal("app.example.com"))
+			Expect(fakeV2Actor.GetDomainsByNameAndOrganizationCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,70 @@
+package pushaction
+
+import "code.cloudfoundry.org/cli/actor/actionerror"
+
+// WarningSeverity is how serious a RouteWarning is.
+type WarningSeverity int
+
+const (
+	// SeverityInfo is for notes that do not need the user's attention, such as
+	// a route that is already mapped.
+	SeverityInfo WarningSeverity = iota
+	// SeverityWarning is for the warnings returned by the Cloud Controller and
+	// for possible problems, such as a route that may conflict with one in
+	// another space.
+	SeverityWarning
+)
+
+// RouteWarning is a warning from a route action along with its severity.
+type RouteWarning struct {
+	Message  string
+	Severity WarningSeverity
+}
+
+// RouteWarnings is a list of warnings returned by the route actions.
+type RouteWarnings []RouteWarning
+
+// NewRouteWarnings returns a RouteWarning with the severity for each of the
+// messages.
+func NewRouteWarnings(severity WarningSeverity, messages ...string) RouteWarnings {
+	var warnings RouteWarnings
+	for _, message := range messages {
+		warnings = append(warnings, RouteWarning{Message: message, Severity: severity})
+	}
+	return warnings
+}
+
+// AtLeast returns the warnings that are at least as severe as severity.
+func (warnings RouteWarnings) AtLeast(severity WarningSeverity) RouteWarnings {
+	var filtered RouteWarnings
+	for _, warning := range warnings {
+		if warning.Severity >= severity {
+			filtered = append(filtered, warning)
+		}
+	}
+	return filtered
+}
+
+// Strings returns the messages of the warnings, regardless of severity.
+func (warnings RouteWarnings) Strings() Warnings {
+	var messages Warnings
+	for _, warning := range warnings {
+		messages = append(messages, warning.Message)
+	}
+	return messages
+}
+
+// warningsAsError returns err, or when WarningsAsErrors is set and there is no
+// err, a RouteWarningsError listing the warnings that are at least
+// SeverityWarning, if there are any.
+func (actor Actor) warningsAsError(warnings RouteWarnings, err error) error {
+	if err != nil || !actor.WarningsAsErrors {
+		return err
+	}
+
+	severe := warnings.AtLeast(SeverityWarning)
+	if len(severe) == 0 {
+		return nil
+	}
+	return actionerror.RouteWarningsError{Warnings: severe.Strings()}
+}
@@ -0,0 +1,48 @@
+package pushaction_test
+
+import (
+	. "code.cloudfoundry.org/cli/actor/pushaction"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouteWarnings", func() {
+	var warnings RouteWarnings
+
+	BeforeEach(func() {
+		warnings = append(
+			NewRouteWarnings(SeverityWarning, "warning-1", "warning-2"),
+			RouteWarning{Message: "info-1", Severity: SeverityInfo},
+		)
+	})
+
+	Describe("NewRouteWarnings", func() {
+		It("gives every message the severity", func() {
+			Expect(NewRouteWarnings(SeverityInfo, "info-1", "info-2")).To(Equal(RouteWarnings{
+				{Message: "info-1", Severity: SeverityInfo},
+				{Message: "info-2", Severity: SeverityInfo},
+			}))
+		})
+
+		It("returns nil when there are no messages", func() {
+			Expect(NewRouteWarnings(SeverityWarning)).To(BeNil())
+		})
+	})
+
+	Describe("AtLeast", func() {
+		It("returns the warnings at or above the severity", func() {
+			Expect(warnings.AtLeast(SeverityWarning)).To(Equal(RouteWarnings{
+				{Message: "warning-1", Severity: SeverityWarning},
+				{Message: "warning-2", Severity: SeverityWarning},
+			}))
+			Expect(warnings.AtLeast(SeverityInfo)).To(Equal(warnings))
+		})
+	})
+
+	Describe("Strings", func() {
+		It("returns every message in order", func() {
+			Expect(warnings.Strings()).To(Equal(Warnings{"warning-1", "warning-2", "info-1"}))
+		})
+	})
+})
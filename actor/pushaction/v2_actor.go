@@ -4,22 +4,32 @@ import (
 	"io"
 
 	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/types"
 )
 
 //go:generate counterfeiter . V2Actor
 
 type V2Actor interface {
 	MapRouteToApplication(routeGUID string, appGUID string) (v2action.Warnings, error)
+	MapRouteToApplicationWithPort(routeGUID string, appGUID string, appPort types.NullInt) (v2action.Warnings, error)
+	MapRouteToApplicationDeployment(routeGUID string, appGUID string, appPort types.NullInt, deploymentGUID string) (v2action.Warnings, error)
 	BindServiceByApplicationAndServiceInstance(appGUID string, serviceInstanceGUID string) (v2action.Warnings, error)
 	CreateApplication(application v2action.Application) (v2action.Application, v2action.Warnings, error)
 	CreateRoute(route v2action.Route, generatePort bool) (v2action.Route, v2action.Warnings, error)
+	DeleteRoute(routeGUID string) (v2action.Warnings, error)
 	FindRouteBoundToSpaceWithSettings(route v2action.Route) (v2action.Route, v2action.Warnings, error)
 	GetApplicationByNameAndSpace(name string, spaceGUID string) (v2action.Application, v2action.Warnings, error)
+	GetApplicationRouteMappings(appGUID string) ([]v2action.RouteMapping, v2action.Warnings, error)
 	GetApplicationRoutes(applicationGUID string) (v2action.Routes, v2action.Warnings, error)
+	GetDomain(domainGUID string) (v2action.Domain, v2action.Warnings, error)
 	GetDomainsByNameAndOrganization(domainNames []string, orgGUID string) ([]v2action.Domain, v2action.Warnings, error)
+	GetOrganization(guid string) (v2action.Organization, v2action.Warnings, error)
 	GetOrganizationDomains(orgGUID string) ([]v2action.Domain, v2action.Warnings, error)
+	GetOrganizationRoutesWithHostAndDomain(route v2action.Route, orgGUID string) ([]v2action.Route, v2action.Warnings, error)
+	GetRouteApplications(routeGUID string) ([]v2action.Application, v2action.Warnings, error)
 	GetServiceInstanceByNameAndSpace(name string, spaceGUID string) (v2action.ServiceInstance, v2action.Warnings, error)
 	GetServiceInstancesByApplication(appGUID string) ([]v2action.ServiceInstance, v2action.Warnings, error)
+	GetSpaceRoutes(spaceGUID string) ([]v2action.Route, v2action.Warnings, error)
 	GetStack(guid string) (v2action.Stack, v2action.Warnings, error)
 	GetStackByName(stackName string) (v2action.Stack, v2action.Warnings, error)
 	PollJob(job v2action.Job) (v2action.Warnings, error)
@@ -0,0 +1,11 @@
+package pushaction
+
+import "code.cloudfoundry.org/cli/actor/v3action"
+
+//go:generate counterfeiter . V3Actor
+
+type V3Actor interface {
+	GetRouteMetadata(routeGUID string) (v3action.Metadata, v3action.Warnings, error)
+	UpdateRouteMetadata(routeGUID string, metadata v3action.Metadata) (v3action.Warnings, error)
+	UpdateRouteOptions(routeGUID string, options map[string]string) (v3action.Warnings, error)
+}
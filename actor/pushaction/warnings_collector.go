@@ -0,0 +1,51 @@
+package pushaction
+
+import (
+	"sort"
+	"sync"
+)
+
+// warningsCollector gathers the warnings from route work that runs in
+// parallel. Warnings can be added from any goroutine. Each batch of warnings
+// is added with the index of the route it belongs to, so that drain can return
+// them in route order regardless of the order in which the work finished.
+type warningsCollector struct {
+	mutex    sync.Mutex
+	warnings map[int]RouteWarnings
+}
+
+// add records the warnings for the route at index, after any already recorded
+// for it.
+func (collector *warningsCollector) add(index int, warnings RouteWarnings) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	if collector.warnings == nil {
+		collector.warnings = map[int]RouteWarnings{}
+	}
+	collector.warnings[index] = append(collector.warnings[index], warnings...)
+}
+
+// drain returns all of the recorded warnings, ordered by route index, and
+// empties the collector.
+func (collector *warningsCollector) drain() RouteWarnings {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	indexes := make([]int, 0, len(collector.warnings))
+	for index := range collector.warnings {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	var allWarnings RouteWarnings
+	for _, index := range indexes {
+		allWarnings = append(allWarnings, collector.warnings[index]...)
+	}
+	collector.warnings = nil
+	return allWarnings
+}
@@ -19,6 +19,19 @@ func (domain Domain) IsHTTP() bool {
 	return !domain.IsTCP()
 }
 
+// AllowsWildcardHosts returns true when routes on the domain may use the
+// wildcard host '*'. Only private HTTP domains allow wildcard hosts.
+func (domain Domain) AllowsWildcardHosts() bool {
+	return domain.IsPrivate() && domain.IsHTTP()
+}
+
+// IsInternal returns true when the domain is an internal domain, used for
+// container-to-container routes that are not reachable from outside the
+// platform.
+func (domain Domain) IsInternal() bool {
+	return domain.Internal
+}
+
 // IsPrivate returns true when the domain is a private domain.
 func (domain Domain) IsPrivate() bool {
 	return domain.Type == constant.PrivateDomain
@@ -125,6 +125,61 @@ var _ = Describe("Domain Actions", func() {
 				})
 			})
 		})
+
+		Describe("IsInternal", func() {
+			Context("when the domain is internal", func() {
+				BeforeEach(func() {
+					domain.Internal = true
+				})
+
+				It("returns true", func() {
+					Expect(domain.IsInternal()).To(BeTrue())
+				})
+			})
+
+			Context("when the domain is not internal", func() {
+				BeforeEach(func() {
+					domain.Internal = false
+				})
+
+				It("returns false", func() {
+					Expect(domain.IsInternal()).To(BeFalse())
+				})
+			})
+		})
+
+		Describe("AllowsWildcardHosts", func() {
+			Context("when the domain is a private HTTP domain", func() {
+				BeforeEach(func() {
+					domain.Type = constant.PrivateDomain
+				})
+
+				It("returns true", func() {
+					Expect(domain.AllowsWildcardHosts()).To(BeTrue())
+				})
+			})
+
+			Context("when the domain is a private TCP domain", func() {
+				BeforeEach(func() {
+					domain.Type = constant.PrivateDomain
+					domain.RouterGroupType = constant.TCPRouterGroup
+				})
+
+				It("returns false", func() {
+					Expect(domain.AllowsWildcardHosts()).To(BeFalse())
+				})
+			})
+
+			Context("when the domain is a shared domain", func() {
+				BeforeEach(func() {
+					domain.Type = constant.SharedDomain
+				})
+
+				It("returns false", func() {
+					Expect(domain.AllowsWildcardHosts()).To(BeFalse())
+				})
+			})
+		})
 	})
 
 	Describe("DomainNotFoundError", func() {
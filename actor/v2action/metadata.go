@@ -0,0 +1,17 @@
+package v2action
+
+// Metadata represents the labels and annotations of a resource.
+type Metadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// IsEmpty returns true if there are no labels or annotations.
+func (metadata Metadata) IsEmpty() bool {
+	return len(metadata.Labels) == 0 && len(metadata.Annotations) == 0
+}
+
+// IsEphemeral returns true if the EphemeralRouteAnnotation is set to "true".
+func (metadata Metadata) IsEphemeral() bool {
+	return metadata.Annotations[EphemeralRouteAnnotation] == "true"
+}
@@ -2,16 +2,25 @@ package v2action
 
 import (
 	"fmt"
-	"path"
+	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/actor/actionerror"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 	"code.cloudfoundry.org/cli/types"
 	log "github.com/sirupsen/logrus"
 )
 
+// routeOptionValues are the route options the Cloud Controller supports and
+// the values each of them allows.
+var routeOptionValues = map[string][]string{
+	"loadbalancing": {"round-robin", "least-connection"},
+}
+
 type Routes []Route
 
 // Summary converts routes into a comma separated string.
@@ -23,6 +32,11 @@ func (rs Routes) Summary() string {
 	return strings.Join(formattedRoutes, ", ")
 }
 
+// EphemeralRouteAnnotation is the annotation that marks a route as ephemeral,
+// that is temporary and safe to delete once it is no longer needed. A route
+// is ephemeral when the annotation's value is "true".
+const EphemeralRouteAnnotation = "cli.cloudfoundry.org/ephemeral-route"
+
 // Route represents a CLI Route.
 type Route struct {
 	Domain    Domain
@@ -31,15 +45,69 @@ type Route struct {
 	Path      string
 	Port      types.NullInt
 	SpaceGUID string
+
+	// Protocol is the protocol the router uses to talk to the route's
+	// applications. When empty the Cloud Controller picks the default.
+	Protocol constant.RouteProtocol
+
+	// RequestedProtocol is the protocol the route was asked to have, kept when
+	// the route is replaced with the Cloud Controller's, whose Protocol is the
+	// one the route ended up with. It is not sent to the Cloud Controller.
+	RequestedProtocol constant.RouteProtocol
+
+	// AppPort is the port of the application that the route's traffic is sent
+	// to. When unset the application's default port is used.
+	AppPort types.NullInt
+
+	// Metadata is the labels and annotations of the route.
+	Metadata Metadata
+
+	// Ephemeral marks a temporary route, such as one for previewing a change.
+	// It is stored on the route as the EphemeralRouteAnnotation.
+	Ephemeral bool
+
+	// SessionAffinity enables or disables sticky sessions for the route. When
+	// unset the route's current setting is left as it is.
+	SessionAffinity types.NullBool
+
+	// Options are the route's router options, such as "loadbalancing". Options
+	// that are not provided are left as they are.
+	Options map[string]string
+
+	// Primary is set on the route that is mapped to its application before
+	// the application's other routes.
+	Primary bool
+
+	// ExistsInOtherSpace is set on a route that does not exist yet when a
+	// route with the same host and domain exists in another space of the
+	// organization.
+	ExistsInOtherSpace bool
+
+	// CreatedAt is when the route was created. It is zero for a route that
+	// does not exist yet.
+	CreatedAt time.Time
+	// UpdatedAt is when the route was last updated. It is nil when the route
+	// does not exist yet or has never been updated.
+	UpdatedAt *time.Time
 }
 
 func (r Route) RandomTCPPort() bool {
 	return r.Domain.IsTCP() && !r.Port.IsSet
 }
 
+// IsEphemeral returns true when the route is marked as ephemeral, either by
+// Ephemeral or by the EphemeralRouteAnnotation in its metadata.
+func (r Route) IsEphemeral() bool {
+	return r.Ephemeral || r.Metadata.IsEphemeral()
+}
+
 // Validate will return an error if there are invalid HTTP or TCP settings for
 // it's given domain.
 func (r Route) Validate() error {
+	if r.Domain.IsInternal() && (r.Path != "" || r.Port.IsSet) {
+		return actionerror.InvalidInternalRouteSettings{Domain: r.Domain.Name}
+	}
+
 	if r.Domain.IsHTTP() {
 		if r.Port.IsSet {
 			return actionerror.InvalidHTTPRouteSettings{Domain: r.Domain.Name}
@@ -52,7 +120,47 @@ func (r Route) Validate() error {
 	return nil
 }
 
-// String formats the route in a human readable format.
+// ValidateOptions returns an UnknownRouteOptionError when the route sets an
+// option the Cloud Controller does not support, and an
+// InvalidRouteOptionValueError when it sets an option to a value the option
+// does not allow.
+func (r Route) ValidateOptions() error {
+	names := make([]string, 0, len(r.Options))
+	for name := range r.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		allowed, ok := routeOptionValues[name]
+		if !ok {
+			return actionerror.UnknownRouteOptionError{Route: r.String(), Option: name}
+		}
+
+		valid := false
+		for _, value := range allowed {
+			if r.Options[name] == value {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return actionerror.InvalidRouteOptionValueError{
+				Route:   r.String(),
+				Option:  name,
+				Value:   r.Options[name],
+				Allowed: allowed,
+			}
+		}
+	}
+	return nil
+}
+
+// String formats the route in a human readable format. It is also the
+// canonical form of the route: the host, domain, port and path are always
+// rendered as host.domain:port/path, leaving out the parts that are not set.
+// The path is rendered as it is, only gaining a leading slash when it lacks
+// one, so that routes with different paths never render the same.
 func (r Route) String() string {
 	routeString := r.Domain.Name
 
@@ -67,7 +175,10 @@ func (r Route) String() string {
 	}
 
 	if r.Path != "" {
-		routeString = path.Join(routeString, r.Path)
+		if !strings.HasPrefix(r.Path, "/") {
+			routeString += "/"
+		}
+		routeString += r.Path
 	}
 
 	return routeString
@@ -81,16 +192,45 @@ func (actor Actor) MapRouteToApplication(routeGUID string, appGUID string) (Warn
 	return Warnings(warnings), err
 }
 
+// MapRouteToApplicationWithPort maps the route to the provided port of the
+// application. When appPort is not set, the application's default port is
+// used.
+func (actor Actor) MapRouteToApplicationWithPort(routeGUID string, appGUID string, appPort types.NullInt) (Warnings, error) {
+	warnings, err := actor.CloudControllerClient.CreateRouteMapping(appGUID, routeGUID, appPort)
+	if _, ok := err.(ccerror.InvalidRelationError); ok {
+		return Warnings(warnings), actionerror.RouteInDifferentSpaceError{}
+	}
+	return Warnings(warnings), err
+}
+
+// MapRouteToApplicationDeployment maps the route to the provided port of the
+// application as MapRouteToApplicationWithPort does, but only for the
+// instances of the provided deployment, so that the route's traffic can be
+// steered to a single revision of the application.
+func (actor Actor) MapRouteToApplicationDeployment(routeGUID string, appGUID string, appPort types.NullInt, deploymentGUID string) (Warnings, error) {
+	warnings, err := actor.CloudControllerClient.CreateDeploymentRouteMapping(appGUID, routeGUID, appPort, deploymentGUID)
+	if _, ok := err.(ccerror.InvalidRelationError); ok {
+		return Warnings(warnings), actionerror.RouteInDifferentSpaceError{}
+	}
+	return Warnings(warnings), err
+}
+
 func (actor Actor) UnmapRouteFromApplication(routeGUID string, appGUID string) (Warnings, error) {
 	warnings, err := actor.CloudControllerClient.DeleteRouteApplication(routeGUID, appGUID)
 	return Warnings(warnings), err
 }
 
+// CreateRoute creates the route. When the Cloud Controller reports that the
+// route already exists, for example because it was created concurrently, a
+// RouteAlreadyExistsError is returned.
 func (actor Actor) CreateRoute(route Route, generatePort bool) (Route, Warnings, error) {
 	if route.Path != "" && !strings.HasPrefix(route.Path, "/") {
 		route.Path = fmt.Sprintf("/%s", route.Path)
 	}
 	returnedRoute, warnings, err := actor.CloudControllerClient.CreateRoute(ActorToCCRoute(route), generatePort)
+	if e, ok := err.(ccerror.V2UnexpectedResponseError); ok && e.ResponseCode == http.StatusConflict {
+		return Route{}, Warnings(warnings), actionerror.RouteAlreadyExistsError{Route: route.String()}
+	}
 	return CCToActorRoute(returnedRoute, route.Domain), Warnings(warnings), err
 }
 
@@ -304,6 +444,40 @@ func (actor Actor) GetRouteByComponents(route Route) (Route, Warnings, error) {
 	return CCToActorRoute(ccv2Routes[0], route.Domain), Warnings(warnings), err
 }
 
+// GetOrganizationRoutesWithHostAndDomain returns the routes in the
+// organization with the same host and domain as the provided route,
+// regardless of their path, port or space.
+func (actor Actor) GetOrganizationRoutesWithHostAndDomain(route Route, orgGUID string) ([]Route, Warnings, error) {
+	queries := []ccv2.Query{
+		{
+			Filter:   ccv2.DomainGUIDFilter,
+			Operator: ccv2.EqualOperator,
+			Values:   []string{route.Domain.GUID},
+		},
+		{
+			Filter:   ccv2.HostFilter,
+			Operator: ccv2.EqualOperator,
+			Values:   []string{route.Host},
+		},
+		{
+			Filter:   ccv2.OrganizationGUIDFilter,
+			Operator: ccv2.EqualOperator,
+			Values:   []string{orgGUID},
+		},
+	}
+
+	ccv2Routes, warnings, err := actor.CloudControllerClient.GetRoutes(queries...)
+	if err != nil {
+		return nil, Warnings(warnings), err
+	}
+
+	var routes []Route
+	for _, ccv2Route := range ccv2Routes {
+		routes = append(routes, CCToActorRoute(ccv2Route, route.Domain))
+	}
+	return routes, Warnings(warnings), nil
+}
+
 func ActorToCCRoute(route Route) ccv2.Route {
 	return ccv2.Route{
 		DomainGUID: route.Domain.GUID,
@@ -312,6 +486,7 @@ func ActorToCCRoute(route Route) ccv2.Route {
 		Path:       route.Path,
 		Port:       route.Port,
 		SpaceGUID:  route.SpaceGUID,
+		Protocol:   route.Protocol,
 	}
 }
 
@@ -323,6 +498,9 @@ func CCToActorRoute(ccv2Route ccv2.Route, domain Domain) Route {
 		Path:      ccv2Route.Path,
 		Port:      ccv2Route.Port,
 		SpaceGUID: ccv2Route.SpaceGUID,
+		Protocol:  ccv2Route.Protocol,
+		CreatedAt: ccv2Route.CreatedAt,
+		UpdatedAt: ccv2Route.UpdatedAt,
 	}
 }
 
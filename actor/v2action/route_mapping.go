@@ -0,0 +1,21 @@
+package v2action
+
+import "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+
+// RouteMapping represents a mapping of a route to one port of an application.
+type RouteMapping ccv2.RouteMapping
+
+// GetApplicationRouteMappings returns the route mappings of the application
+// with the provided GUID.
+func (actor Actor) GetApplicationRouteMappings(appGUID string) ([]RouteMapping, Warnings, error) {
+	ccv2RouteMappings, warnings, err := actor.CloudControllerClient.GetApplicationRouteMappings(appGUID)
+	if err != nil {
+		return nil, Warnings(warnings), err
+	}
+
+	var routeMappings []RouteMapping
+	for _, routeMapping := range ccv2RouteMappings {
+		routeMappings = append(routeMappings, RouteMapping(routeMapping))
+	}
+	return routeMappings, Warnings(warnings), nil
+}
@@ -0,0 +1,67 @@
+package v2action_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v2action/v2actionfakes"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Route Mapping Actions", func() {
+	var (
+		actor                     *Actor
+		fakeCloudControllerClient *v2actionfakes.FakeCloudControllerClient
+	)
+
+	BeforeEach(func() {
+		fakeCloudControllerClient = new(v2actionfakes.FakeCloudControllerClient)
+		actor = NewActor(fakeCloudControllerClient, nil, nil)
+	})
+
+	Describe("GetApplicationRouteMappings", func() {
+		Context("when the route mappings can be retrieved", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetApplicationRouteMappingsReturns(
+					[]ccv2.RouteMapping{
+						{GUID: "route-mapping-guid-1", AppGUID: "some-app-guid", RouteGUID: "route-guid-1", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+						{GUID: "route-mapping-guid-2", AppGUID: "some-app-guid", RouteGUID: "route-guid-2"},
+					},
+					ccv2.Warnings{"route-mapping-warning"},
+					nil,
+				)
+			})
+
+			It("returns the route mappings and warnings", func() {
+				routeMappings, warnings, err := actor.GetApplicationRouteMappings("some-app-guid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("route-mapping-warning"))
+				Expect(routeMappings).To(Equal([]RouteMapping{
+					{GUID: "route-mapping-guid-1", AppGUID: "some-app-guid", RouteGUID: "route-guid-1", AppPort: types.NullInt{IsSet: true, Value: 8080}},
+					{GUID: "route-mapping-guid-2", AppGUID: "some-app-guid", RouteGUID: "route-guid-2"},
+				}))
+
+				Expect(fakeCloudControllerClient.GetApplicationRouteMappingsCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.GetApplicationRouteMappingsArgsForCall(0)).To(Equal("some-app-guid"))
+			})
+		})
+
+		Context("when retrieving the route mappings fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("get route mappings error")
+				fakeCloudControllerClient.GetApplicationRouteMappingsReturns(nil, ccv2.Warnings{"route-mapping-warning"}, expectedErr)
+			})
+
+			It("returns the error and warnings", func() {
+				_, warnings, err := actor.GetApplicationRouteMappings("some-app-guid")
+				Expect(err).To(MatchError(expectedErr))
+				Expect(warnings).To(ConsistOf("route-mapping-warning"))
+			})
+		})
+	})
+})
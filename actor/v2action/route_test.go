@@ -3,6 +3,8 @@ package v2action_test
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/cli/actor/actionerror"
 	. "code.cloudfoundry.org/cli/actor/v2action"
@@ -48,6 +50,9 @@ var _ = Describe("Route Actions", func() {
 			Entry("has host, domain, path", "host", "domain.com", "/path", types.NullInt{IsSet: false}, "host.domain.com/path"),
 			Entry("has domain, port", "", "domain.com", "", types.NullInt{IsSet: true, Value: 3333}, "domain.com:3333"),
 			Entry("has host, domain, path, port", "host", "domain.com", "/path", types.NullInt{IsSet: true, Value: 3333}, "host.domain.com:3333/path"),
+			Entry("has a multi-segment path", "host", "domain.com", "/foo/bar", types.NullInt{IsSet: false}, "host.domain.com/foo/bar"),
+			Entry("has a path with a trailing slash", "host", "domain.com", "/foo/", types.NullInt{IsSet: false}, "host.domain.com/foo/"),
+			Entry("has a path with dot segments", "host", "domain.com", "/foo/..", types.NullInt{IsSet: false}, "host.domain.com/foo/.."),
 		)
 
 		Describe("RandomTCPPort", func() {
@@ -102,6 +107,17 @@ var _ = Describe("Route Actions", func() {
 			})
 		})
 
+		DescribeTable("IsEphemeral",
+			func(route Route, expected bool) {
+				Expect(route.IsEphemeral()).To(Equal(expected))
+			},
+
+			Entry("is not marked", Route{}, false),
+			Entry("is marked by Ephemeral", Route{Ephemeral: true}, true),
+			Entry("is marked by the annotation", Route{Metadata: Metadata{Annotations: map[string]string{EphemeralRouteAnnotation: "true"}}}, true),
+			Entry("has the annotation set to false", Route{Metadata: Metadata{Annotations: map[string]string{EphemeralRouteAnnotation: "false"}}}, false),
+		)
+
 		DescribeTable("Validate",
 			func(route Route, expectedErr error) {
 				err := route.Validate()
@@ -165,6 +181,72 @@ var _ = Describe("Route Actions", func() {
 				},
 				actionerror.InvalidTCPRouteSettings{Domain: "some-domain"},
 			),
+
+			Entry("valid - host on internal domain",
+				Route{
+					Host: "some-host",
+					Domain: Domain{
+						Name:     "some-domain",
+						Internal: true,
+					},
+				},
+				nil,
+			),
+
+			Entry("error - path on internal domain",
+				Route{
+					Host: "some-host",
+					Path: "some-path",
+					Domain: Domain{
+						Name:     "some-domain",
+						Internal: true,
+					},
+				},
+				actionerror.InvalidInternalRouteSettings{Domain: "some-domain"},
+			),
+
+			Entry("error - port on internal domain",
+				Route{
+					Host: "some-host",
+					Port: types.NullInt{IsSet: true, Value: 1234},
+					Domain: Domain{
+						Name:     "some-domain",
+						Internal: true,
+					},
+				},
+				actionerror.InvalidInternalRouteSettings{Domain: "some-domain"},
+			),
+		)
+
+		DescribeTable("ValidateOptions",
+			func(options map[string]string, expectedErr error) {
+				route := Route{Host: "some-host", Domain: Domain{Name: "some-domain"}, Options: options}
+				err := route.ValidateOptions()
+				if expectedErr == nil {
+					Expect(err).To(BeNil())
+				} else {
+					Expect(err).To(Equal(expectedErr))
+				}
+			},
+
+			Entry("valid - no options", nil, nil),
+			Entry("valid - round-robin load balancing", map[string]string{"loadbalancing": "round-robin"}, nil),
+			Entry("valid - least-connection load balancing", map[string]string{"loadbalancing": "least-connection"}, nil),
+
+			Entry("error - unknown option",
+				map[string]string{"loadbalancing": "round-robin", "retries": "3"},
+				actionerror.UnknownRouteOptionError{Route: "some-host.some-domain", Option: "retries"},
+			),
+
+			Entry("error - invalid load balancing algorithm",
+				map[string]string{"loadbalancing": "random"},
+				actionerror.InvalidRouteOptionValueError{
+					Route:   "some-host.some-domain",
+					Option:  "loadbalancing",
+					Value:   "random",
+					Allowed: []string{"round-robin", "least-connection"},
+				},
+			),
 		)
 	})
 
@@ -225,6 +307,88 @@ var _ = Describe("Route Actions", func() {
 		})
 	})
 
+	Describe("MapRouteToApplicationWithPort", func() {
+		Context("when no errors are encountered", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.CreateRouteMappingReturns(ccv2.Warnings{"map warning"}, nil)
+			})
+
+			It("maps the route to the application port and returns all warnings", func() {
+				warnings, err := actor.MapRouteToApplicationWithPort("some-route-guid", "some-app-guid", types.NullInt{IsSet: true, Value: 8080})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("map warning"))
+
+				Expect(fakeCloudControllerClient.CreateRouteMappingCallCount()).To(Equal(1))
+				appGUID, routeGUID, appPort := fakeCloudControllerClient.CreateRouteMappingArgsForCall(0)
+				Expect(appGUID).To(Equal("some-app-guid"))
+				Expect(routeGUID).To(Equal("some-route-guid"))
+				Expect(appPort).To(Equal(types.NullInt{IsSet: true, Value: 8080}))
+			})
+		})
+
+		Context("when an error is encountered", func() {
+			Context("InvalidRelationError", func() {
+				BeforeEach(func() {
+					fakeCloudControllerClient.CreateRouteMappingReturns(ccv2.Warnings{"map warning"}, ccerror.InvalidRelationError{})
+				})
+
+				It("returns a RouteInDifferentSpaceError", func() {
+					warnings, err := actor.MapRouteToApplicationWithPort("some-route-guid", "some-app-guid", types.NullInt{IsSet: true, Value: 8080})
+					Expect(err).To(MatchError(actionerror.RouteInDifferentSpaceError{}))
+					Expect(warnings).To(ConsistOf("map warning"))
+				})
+			})
+
+			Context("generic error", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					expectedErr = errors.New("map route failed")
+					fakeCloudControllerClient.CreateRouteMappingReturns(ccv2.Warnings{"map warning"}, expectedErr)
+				})
+
+				It("returns the error", func() {
+					warnings, err := actor.MapRouteToApplicationWithPort("some-route-guid", "some-app-guid", types.NullInt{IsSet: true, Value: 8080})
+					Expect(err).To(MatchError(expectedErr))
+					Expect(warnings).To(ConsistOf("map warning"))
+				})
+			})
+		})
+	})
+
+	Describe("MapRouteToApplicationDeployment", func() {
+		Context("when no errors are encountered", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.CreateDeploymentRouteMappingReturns(ccv2.Warnings{"map warning"}, nil)
+			})
+
+			It("maps the route to the deployment and returns all warnings", func() {
+				warnings, err := actor.MapRouteToApplicationDeployment("some-route-guid", "some-app-guid", types.NullInt{IsSet: true, Value: 8080}, "some-deployment-guid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("map warning"))
+
+				Expect(fakeCloudControllerClient.CreateDeploymentRouteMappingCallCount()).To(Equal(1))
+				appGUID, routeGUID, appPort, deploymentGUID := fakeCloudControllerClient.CreateDeploymentRouteMappingArgsForCall(0)
+				Expect(appGUID).To(Equal("some-app-guid"))
+				Expect(routeGUID).To(Equal("some-route-guid"))
+				Expect(appPort).To(Equal(types.NullInt{IsSet: true, Value: 8080}))
+				Expect(deploymentGUID).To(Equal("some-deployment-guid"))
+			})
+		})
+
+		Context("when the route is in a different space", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.CreateDeploymentRouteMappingReturns(ccv2.Warnings{"map warning"}, ccerror.InvalidRelationError{})
+			})
+
+			It("returns a RouteInDifferentSpaceError", func() {
+				warnings, err := actor.MapRouteToApplicationDeployment("some-route-guid", "some-app-guid", types.NullInt{}, "some-deployment-guid")
+				Expect(err).To(MatchError(actionerror.RouteInDifferentSpaceError{}))
+				Expect(warnings).To(ConsistOf("map warning"))
+			})
+		})
+	})
+
 	Describe("UnmapRouteFromApplication", func() {
 		Context("when no errors are encountered", func() {
 			BeforeEach(func() {
@@ -389,6 +553,24 @@ var _ = Describe("Route Actions", func() {
 				Expect(warnings).To(ConsistOf("create route warning"))
 			})
 		})
+
+		Context("when the route already exists", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.CreateRouteReturns(
+					ccv2.Route{},
+					ccv2.Warnings{"create route warning"},
+					ccerror.V2UnexpectedResponseError{ResponseCode: http.StatusConflict})
+			})
+
+			It("returns a RouteAlreadyExistsError", func() {
+				_, warnings, err := actor.CreateRoute(Route{
+					Domain: Domain{Name: "some-domain", GUID: "some-domain-guid"},
+					Host:   "some-host",
+				}, false)
+				Expect(err).To(MatchError(actionerror.RouteAlreadyExistsError{Route: "some-host.some-domain"}))
+				Expect(warnings).To(ConsistOf("create route warning"))
+			})
+		})
 	})
 
 	Describe("CreateRouteWithExistenceCheck", func() {
@@ -1137,6 +1319,33 @@ var _ = Describe("Route Actions", func() {
 			route, warnings, executeErr = actor.GetRouteByComponents(inputRoute)
 		})
 
+		Context("when the route has timestamps", func() {
+			var (
+				createdAt time.Time
+				updatedAt time.Time
+			)
+
+			BeforeEach(func() {
+				createdAt = time.Date(2016, 6, 8, 16, 41, 22, 0, time.UTC)
+				updatedAt = time.Date(2016, 6, 8, 16, 41, 26, 0, time.UTC)
+				fakeCloudControllerClient.GetRoutesReturns([]ccv2.Route{
+					{
+						GUID:       "route-guid-1",
+						SpaceGUID:  "some-space-guid",
+						DomainGUID: domain.GUID,
+						CreatedAt:  createdAt,
+						UpdatedAt:  &updatedAt,
+					},
+				}, nil, nil)
+			})
+
+			It("returns the route with its timestamps", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(route.CreatedAt).To(Equal(createdAt))
+				Expect(route.UpdatedAt).To(Equal(&updatedAt))
+			})
+		})
+
 		Context("when finding the route is successful and returns one route", func() {
 			Context("when the hostname is provided", func() {
 				BeforeEach(func() {
@@ -1347,6 +1556,78 @@ var _ = Describe("Route Actions", func() {
 		})
 	})
 
+	Describe("GetOrganizationRoutesWithHostAndDomain", func() {
+		var (
+			domain Domain
+
+			routes     []Route
+			warnings   Warnings
+			executeErr error
+		)
+
+		BeforeEach(func() {
+			domain = Domain{
+				GUID: "some-domain-guid",
+				Name: "domain.com",
+			}
+		})
+
+		JustBeforeEach(func() {
+			routes, warnings, executeErr = actor.GetOrganizationRoutesWithHostAndDomain(Route{Host: "some-host", Domain: domain, Path: "/some-path"}, "some-org-guid")
+		})
+
+		Context("when getting the routes is successful", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetRoutesReturns([]ccv2.Route{
+					{GUID: "route-guid-1", SpaceGUID: "some-space-guid", Host: "some-host", DomainGUID: domain.GUID},
+					{GUID: "route-guid-2", SpaceGUID: "other-space-guid", Host: "some-host", DomainGUID: domain.GUID, Path: "/other-path"},
+				}, ccv2.Warnings{"get-routes-warning"}, nil)
+			})
+
+			It("returns the routes with the host and domain in the organization and any warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("get-routes-warning"))
+				Expect(routes).To(Equal([]Route{
+					{Domain: domain, GUID: "route-guid-1", Host: "some-host", SpaceGUID: "some-space-guid"},
+					{Domain: domain, GUID: "route-guid-2", Host: "some-host", Path: "/other-path", SpaceGUID: "other-space-guid"},
+				}))
+
+				Expect(fakeCloudControllerClient.GetRoutesCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.GetRoutesArgsForCall(0)).To(Equal([]ccv2.Query{
+					{
+						Filter:   ccv2.DomainGUIDFilter,
+						Operator: ccv2.EqualOperator,
+						Values:   []string{domain.GUID},
+					},
+					{
+						Filter:   ccv2.HostFilter,
+						Operator: ccv2.EqualOperator,
+						Values:   []string{"some-host"},
+					},
+					{
+						Filter:   ccv2.OrganizationGUIDFilter,
+						Operator: ccv2.EqualOperator,
+						Values:   []string{"some-org-guid"},
+					},
+				}))
+			})
+		})
+
+		Context("when getting the routes errors", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("get-routes-err")
+				fakeCloudControllerClient.GetRoutesReturns(nil, ccv2.Warnings{"get-routes-warning"}, expectedErr)
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+				Expect(warnings).To(ConsistOf("get-routes-warning"))
+			})
+		})
+	})
+
 	Describe("CheckRoute", func() {
 		Context("when the API calls succeed", func() {
 			BeforeEach(func() {
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/cli/actor/v2action"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/types"
 )
 
 type FakeCloudControllerClient struct {
@@ -884,6 +885,52 @@ type FakeCloudControllerClient struct {
 	tokenEndpointReturnsOnCall map[int]struct {
 		result1 string
 	}
+	CreateRouteMappingStub        func(appGUID string, routeGUID string, appPort types.NullInt) (ccv2.Warnings, error)
+	createRouteMappingMutex       sync.RWMutex
+	createRouteMappingArgsForCall []struct {
+		appGUID   string
+		routeGUID string
+		appPort   types.NullInt
+	}
+	createRouteMappingReturns struct {
+		result1 ccv2.Warnings
+		result2 error
+	}
+	createRouteMappingReturnsOnCall map[int]struct {
+		result1 ccv2.Warnings
+		result2 error
+	}
+	CreateDeploymentRouteMappingStub        func(appGUID string, routeGUID string, appPort types.NullInt, deploymentGUID string) (ccv2.Warnings, error)
+	createDeploymentRouteMappingMutex       sync.RWMutex
+	createDeploymentRouteMappingArgsForCall []struct {
+		appGUID        string
+		routeGUID      string
+		appPort        types.NullInt
+		deploymentGUID string
+	}
+	createDeploymentRouteMappingReturns struct {
+		result1 ccv2.Warnings
+		result2 error
+	}
+	createDeploymentRouteMappingReturnsOnCall map[int]struct {
+		result1 ccv2.Warnings
+		result2 error
+	}
+	GetApplicationRouteMappingsStub        func(appGUID string) ([]ccv2.RouteMapping, ccv2.Warnings, error)
+	getApplicationRouteMappingsMutex       sync.RWMutex
+	getApplicationRouteMappingsArgsForCall []struct {
+		appGUID string
+	}
+	getApplicationRouteMappingsReturns struct {
+		result1 []ccv2.RouteMapping
+		result2 ccv2.Warnings
+		result3 error
+	}
+	getApplicationRouteMappingsReturnsOnCall map[int]struct {
+		result1 []ccv2.RouteMapping
+		result2 ccv2.Warnings
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -4087,6 +4134,167 @@ func (fake *FakeCloudControllerClient) TokenEndpointReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCloudControllerClient) CreateRouteMapping(appGUID string, routeGUID string, appPort types.NullInt) (ccv2.Warnings, error) {
+	fake.createRouteMappingMutex.Lock()
+	ret, specificReturn := fake.createRouteMappingReturnsOnCall[len(fake.createRouteMappingArgsForCall)]
+	fake.createRouteMappingArgsForCall = append(fake.createRouteMappingArgsForCall, struct {
+		appGUID   string
+		routeGUID string
+		appPort   types.NullInt
+	}{appGUID, routeGUID, appPort})
+	fake.recordInvocation("CreateRouteMapping", []interface{}{appGUID, routeGUID, appPort})
+	fake.createRouteMappingMutex.Unlock()
+	if fake.CreateRouteMappingStub != nil {
+		return fake.CreateRouteMappingStub(appGUID, routeGUID, appPort)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createRouteMappingReturns.result1, fake.createRouteMappingReturns.result2
+}
+
+func (fake *FakeCloudControllerClient) CreateRouteMappingCallCount() int {
+	fake.createRouteMappingMutex.RLock()
+	defer fake.createRouteMappingMutex.RUnlock()
+	return len(fake.createRouteMappingArgsForCall)
+}
+
+func (fake *FakeCloudControllerClient) CreateRouteMappingArgsForCall(i int) (string, string, types.NullInt) {
+	fake.createRouteMappingMutex.RLock()
+	defer fake.createRouteMappingMutex.RUnlock()
+	return fake.createRouteMappingArgsForCall[i].appGUID, fake.createRouteMappingArgsForCall[i].routeGUID, fake.createRouteMappingArgsForCall[i].appPort
+}
+
+func (fake *FakeCloudControllerClient) CreateRouteMappingReturns(result1 ccv2.Warnings, result2 error) {
+	fake.CreateRouteMappingStub = nil
+	fake.createRouteMappingReturns = struct {
+		result1 ccv2.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloudControllerClient) CreateRouteMappingReturnsOnCall(i int, result1 ccv2.Warnings, result2 error) {
+	fake.CreateRouteMappingStub = nil
+	if fake.createRouteMappingReturnsOnCall == nil {
+		fake.createRouteMappingReturnsOnCall = make(map[int]struct {
+			result1 ccv2.Warnings
+			result2 error
+		})
+	}
+	fake.createRouteMappingReturnsOnCall[i] = struct {
+		result1 ccv2.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloudControllerClient) CreateDeploymentRouteMapping(appGUID string, routeGUID string, appPort types.NullInt, deploymentGUID string) (ccv2.Warnings, error) {
+	fake.createDeploymentRouteMappingMutex.Lock()
+	ret, specificReturn := fake.createDeploymentRouteMappingReturnsOnCall[len(fake.createDeploymentRouteMappingArgsForCall)]
+	fake.createDeploymentRouteMappingArgsForCall = append(fake.createDeploymentRouteMappingArgsForCall, struct {
+		appGUID        string
+		routeGUID      string
+		appPort        types.NullInt
+		deploymentGUID string
+	}{appGUID, routeGUID, appPort, deploymentGUID})
+	fake.recordInvocation("CreateDeploymentRouteMapping", []interface{}{appGUID, routeGUID, appPort, deploymentGUID})
+	fake.createDeploymentRouteMappingMutex.Unlock()
+	if fake.CreateDeploymentRouteMappingStub != nil {
+		return fake.CreateDeploymentRouteMappingStub(appGUID, routeGUID, appPort, deploymentGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createDeploymentRouteMappingReturns.result1, fake.createDeploymentRouteMappingReturns.result2
+}
+
+func (fake *FakeCloudControllerClient) CreateDeploymentRouteMappingCallCount() int {
+	fake.createDeploymentRouteMappingMutex.RLock()
+	defer fake.createDeploymentRouteMappingMutex.RUnlock()
+	return len(fake.createDeploymentRouteMappingArgsForCall)
+}
+
+func (fake *FakeCloudControllerClient) CreateDeploymentRouteMappingArgsForCall(i int) (string, string, types.NullInt, string) {
+	fake.createDeploymentRouteMappingMutex.RLock()
+	defer fake.createDeploymentRouteMappingMutex.RUnlock()
+	return fake.createDeploymentRouteMappingArgsForCall[i].appGUID, fake.createDeploymentRouteMappingArgsForCall[i].routeGUID, fake.createDeploymentRouteMappingArgsForCall[i].appPort, fake.createDeploymentRouteMappingArgsForCall[i].deploymentGUID
+}
+
+func (fake *FakeCloudControllerClient) CreateDeploymentRouteMappingReturns(result1 ccv2.Warnings, result2 error) {
+	fake.CreateDeploymentRouteMappingStub = nil
+	fake.createDeploymentRouteMappingReturns = struct {
+		result1 ccv2.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloudControllerClient) CreateDeploymentRouteMappingReturnsOnCall(i int, result1 ccv2.Warnings, result2 error) {
+	fake.CreateDeploymentRouteMappingStub = nil
+	if fake.createDeploymentRouteMappingReturnsOnCall == nil {
+		fake.createDeploymentRouteMappingReturnsOnCall = make(map[int]struct {
+			result1 ccv2.Warnings
+			result2 error
+		})
+	}
+	fake.createDeploymentRouteMappingReturnsOnCall[i] = struct {
+		result1 ccv2.Warnings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCloudControllerClient) GetApplicationRouteMappings(appGUID string) ([]ccv2.RouteMapping, ccv2.Warnings, error) {
+	fake.getApplicationRouteMappingsMutex.Lock()
+	ret, specificReturn := fake.getApplicationRouteMappingsReturnsOnCall[len(fake.getApplicationRouteMappingsArgsForCall)]
+	fake.getApplicationRouteMappingsArgsForCall = append(fake.getApplicationRouteMappingsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("GetApplicationRouteMappings", []interface{}{appGUID})
+	fake.getApplicationRouteMappingsMutex.Unlock()
+	if fake.GetApplicationRouteMappingsStub != nil {
+		return fake.GetApplicationRouteMappingsStub(appGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getApplicationRouteMappingsReturns.result1, fake.getApplicationRouteMappingsReturns.result2, fake.getApplicationRouteMappingsReturns.result3
+}
+
+func (fake *FakeCloudControllerClient) GetApplicationRouteMappingsCallCount() int {
+	fake.getApplicationRouteMappingsMutex.RLock()
+	defer fake.getApplicationRouteMappingsMutex.RUnlock()
+	return len(fake.getApplicationRouteMappingsArgsForCall)
+}
+
+func (fake *FakeCloudControllerClient) GetApplicationRouteMappingsArgsForCall(i int) string {
+	fake.getApplicationRouteMappingsMutex.RLock()
+	defer fake.getApplicationRouteMappingsMutex.RUnlock()
+	return fake.getApplicationRouteMappingsArgsForCall[i].appGUID
+}
+
+func (fake *FakeCloudControllerClient) GetApplicationRouteMappingsReturns(result1 []ccv2.RouteMapping, result2 ccv2.Warnings, result3 error) {
+	fake.GetApplicationRouteMappingsStub = nil
+	fake.getApplicationRouteMappingsReturns = struct {
+		result1 []ccv2.RouteMapping
+		result2 ccv2.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCloudControllerClient) GetApplicationRouteMappingsReturnsOnCall(i int, result1 []ccv2.RouteMapping, result2 ccv2.Warnings, result3 error) {
+	fake.GetApplicationRouteMappingsStub = nil
+	if fake.getApplicationRouteMappingsReturnsOnCall == nil {
+		fake.getApplicationRouteMappingsReturnsOnCall = make(map[int]struct {
+			result1 []ccv2.RouteMapping
+			result2 ccv2.Warnings
+			result3 error
+		})
+	}
+	fake.getApplicationRouteMappingsReturnsOnCall[i] = struct {
+		result1 []ccv2.RouteMapping
+		result2 ccv2.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCloudControllerClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -4212,6 +4420,12 @@ func (fake *FakeCloudControllerClient) Invocations() map[string][][]interface{}
 	defer fake.routingEndpointMutex.RUnlock()
 	fake.tokenEndpointMutex.RLock()
 	defer fake.tokenEndpointMutex.RUnlock()
+	fake.createRouteMappingMutex.RLock()
+	defer fake.createRouteMappingMutex.RUnlock()
+	fake.createDeploymentRouteMappingMutex.RLock()
+	defer fake.createDeploymentRouteMappingMutex.RUnlock()
+	fake.getApplicationRouteMappingsMutex.RLock()
+	defer fake.getApplicationRouteMappingsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,57 @@
+package v3action
+
+import (
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccversion"
+)
+
+// Metadata represents the labels and annotations of a V3 resource. When the
+// metadata is updated, a label or annotation whose value is not set is
+// removed.
+type Metadata ccv3.Metadata
+
+// GetRouteMetadata returns the labels and annotations of the route.
+func (actor Actor) GetRouteMetadata(routeGUID string) (Metadata, Warnings, error) {
+	route, warnings, err := actor.CloudControllerClient.GetRoute(routeGUID)
+	if err != nil || route.Metadata == nil {
+		return Metadata{}, Warnings(warnings), err
+	}
+	return Metadata(*route.Metadata), Warnings(warnings), nil
+}
+
+// UpdateRouteMetadata sets the provided labels and annotations on the route.
+// Labels and annotations that are not provided are left as they are.
+func (actor Actor) UpdateRouteMetadata(routeGUID string, metadata Metadata) (Warnings, error) {
+	ccMetadata := ccv3.Metadata(metadata)
+	_, warnings, err := actor.CloudControllerClient.UpdateRoute(ccv3.Route{
+		GUID:     routeGUID,
+		Metadata: &ccMetadata,
+	})
+	return Warnings(warnings), err
+}
+
+// UpdateRouteOptions sets the provided options, such as "loadbalancing", on
+// the route. Options that are not provided are left as they are. It returns a
+// RouteOptionsNotSupportedError when the targeted Cloud Controller is older
+// than ccversion.MinVersionRouteOptionsV3.
+func (actor Actor) UpdateRouteOptions(routeGUID string, options map[string]string) (Warnings, error) {
+	currentVersion := actor.CloudControllerClient.CloudControllerAPIVersion()
+	err := cloudcontroller.MinimumAPIVersionCheck(currentVersion, ccversion.MinVersionRouteOptionsV3)
+	if _, ok := err.(ccerror.MinimumAPIVersionNotMetError); ok {
+		return nil, actionerror.RouteOptionsNotSupportedError{
+			CurrentVersion: currentVersion,
+			MinimumVersion: ccversion.MinVersionRouteOptionsV3,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	_, warnings, err := actor.CloudControllerClient.UpdateRoute(ccv3.Route{
+		GUID:    routeGUID,
+		Options: options,
+	})
+	return Warnings(warnings), err
+}
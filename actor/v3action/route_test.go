@@ -0,0 +1,186 @@
+package v3action_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/actor/actionerror"
+	. "code.cloudfoundry.org/cli/actor/v3action"
+	"code.cloudfoundry.org/cli/actor/v3action/v3actionfakes"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccversion"
+	"code.cloudfoundry.org/cli/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Route Actions", func() {
+	var (
+		actor                     *Actor
+		fakeCloudControllerClient *v3actionfakes.FakeCloudControllerClient
+	)
+
+	BeforeEach(func() {
+		fakeCloudControllerClient = new(v3actionfakes.FakeCloudControllerClient)
+		actor = NewActor(fakeCloudControllerClient, nil, nil, nil)
+	})
+
+	Describe("GetRouteMetadata", func() {
+		var (
+			metadata   Metadata
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			metadata, warnings, executeErr = actor.GetRouteMetadata("some-route-guid")
+		})
+
+		Context("when the route has metadata", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetRouteReturns(ccv3.Route{
+					GUID: "some-route-guid",
+					Metadata: &ccv3.Metadata{
+						Labels: map[string]types.FilteredString{"env": {IsSet: true, Value: "production"}},
+					},
+				}, ccv3.Warnings{"get-route-warning"}, nil)
+			})
+
+			It("returns the metadata and warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("get-route-warning"))
+				Expect(metadata).To(Equal(Metadata{
+					Labels: map[string]types.FilteredString{"env": {IsSet: true, Value: "production"}},
+				}))
+
+				Expect(fakeCloudControllerClient.GetRouteCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.GetRouteArgsForCall(0)).To(Equal("some-route-guid"))
+			})
+		})
+
+		Context("when the route has no metadata", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetRouteReturns(ccv3.Route{GUID: "some-route-guid"}, ccv3.Warnings{"get-route-warning"}, nil)
+			})
+
+			It("returns empty metadata", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("get-route-warning"))
+				Expect(metadata).To(Equal(Metadata{}))
+			})
+		})
+
+		Context("when getting the route fails", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetRouteReturns(ccv3.Route{}, ccv3.Warnings{"get-route-warning"}, errors.New("get-route-error"))
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError("get-route-error"))
+				Expect(warnings).To(ConsistOf("get-route-warning"))
+			})
+		})
+	})
+
+	Describe("UpdateRouteMetadata", func() {
+		var (
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			warnings, executeErr = actor.UpdateRouteMetadata("some-route-guid", Metadata{
+				Labels: map[string]types.FilteredString{
+					"env":  {IsSet: true, Value: "production"},
+					"tier": {},
+				},
+			})
+		})
+
+		Context("when the update succeeds", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.UpdateRouteReturns(ccv3.Route{}, ccv3.Warnings{"update-route-warning"}, nil)
+			})
+
+			It("updates the route's metadata and returns warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("update-route-warning"))
+
+				Expect(fakeCloudControllerClient.UpdateRouteCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.UpdateRouteArgsForCall(0)).To(Equal(ccv3.Route{
+					GUID: "some-route-guid",
+					Metadata: &ccv3.Metadata{
+						Labels: map[string]types.FilteredString{
+							"env":  {IsSet: true, Value: "production"},
+							"tier": {},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the update fails", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.UpdateRouteReturns(ccv3.Route{}, ccv3.Warnings{"update-route-warning"}, errors.New("update-route-error"))
+			})
+
+			It("returns the error and warnings", func() {
+				Expect(executeErr).To(MatchError("update-route-error"))
+				Expect(warnings).To(ConsistOf("update-route-warning"))
+			})
+		})
+	})
+
+	Describe("UpdateRouteOptions", func() {
+		var (
+			warnings   Warnings
+			executeErr error
+		)
+
+		JustBeforeEach(func() {
+			warnings, executeErr = actor.UpdateRouteOptions("some-route-guid", map[string]string{"loadbalancing": "least-connection"})
+		})
+
+		Context("when the API supports route options", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.CloudControllerAPIVersionReturns(ccversion.MinVersionRouteOptionsV3)
+				fakeCloudControllerClient.UpdateRouteReturns(ccv3.Route{}, ccv3.Warnings{"update-route-warning"}, nil)
+			})
+
+			It("updates the route's options and returns warnings", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("update-route-warning"))
+
+				Expect(fakeCloudControllerClient.UpdateRouteCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.UpdateRouteArgsForCall(0)).To(Equal(ccv3.Route{
+					GUID:    "some-route-guid",
+					Options: map[string]string{"loadbalancing": "least-connection"},
+				}))
+			})
+
+			Context("when the update fails", func() {
+				BeforeEach(func() {
+					fakeCloudControllerClient.UpdateRouteReturns(ccv3.Route{}, ccv3.Warnings{"update-route-warning"}, errors.New("update-route-error"))
+				})
+
+				It("returns the error and warnings", func() {
+					Expect(executeErr).To(MatchError("update-route-error"))
+					Expect(warnings).To(ConsistOf("update-route-warning"))
+				})
+			})
+		})
+
+		Context("when the API is older than the minimum version", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.CloudControllerAPIVersionReturns("3.100.0")
+			})
+
+			It("returns a RouteOptionsNotSupportedError without updating the route", func() {
+				Expect(executeErr).To(MatchError(actionerror.RouteOptionsNotSupportedError{
+					CurrentVersion: "3.100.0",
+					MinimumVersion: ccversion.MinVersionRouteOptionsV3,
+				}))
+				Expect(fakeCloudControllerClient.UpdateRouteCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -445,6 +445,21 @@ type FakeCloudControllerClient struct {
 		result2 ccv3.Warnings
 		result3 error
 	}
+	GetRouteStub        func(guid string) (ccv3.Route, ccv3.Warnings, error)
+	getRouteMutex       sync.RWMutex
+	getRouteArgsForCall []struct {
+		guid string
+	}
+	getRouteReturns struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}
+	getRouteReturnsOnCall map[int]struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}
 	GetSpaceIsolationSegmentStub        func(spaceGUID string) (ccv3.Relationship, ccv3.Warnings, error)
 	getSpaceIsolationSegmentMutex       sync.RWMutex
 	getSpaceIsolationSegmentArgsForCall []struct {
@@ -575,6 +590,21 @@ type FakeCloudControllerClient struct {
 		result2 ccv3.Warnings
 		result3 error
 	}
+	UpdateRouteStub        func(route ccv3.Route) (ccv3.Route, ccv3.Warnings, error)
+	updateRouteMutex       sync.RWMutex
+	updateRouteArgsForCall []struct {
+		route ccv3.Route
+	}
+	updateRouteReturns struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}
+	updateRouteReturnsOnCall map[int]struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}
 	PatchApplicationUserProvidedEnvironmentVariablesStub        func(appGUID string, envVars ccv3.EnvironmentVariables) (ccv3.EnvironmentVariables, ccv3.Warnings, error)
 	patchApplicationUserProvidedEnvironmentVariablesMutex       sync.RWMutex
 	patchApplicationUserProvidedEnvironmentVariablesArgsForCall []struct {
@@ -2209,6 +2239,60 @@ func (fake *FakeCloudControllerClient) GetProcessInstancesReturnsOnCall(i int, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeCloudControllerClient) GetRoute(guid string) (ccv3.Route, ccv3.Warnings, error) {
+	fake.getRouteMutex.Lock()
+	ret, specificReturn := fake.getRouteReturnsOnCall[len(fake.getRouteArgsForCall)]
+	fake.getRouteArgsForCall = append(fake.getRouteArgsForCall, struct {
+		guid string
+	}{guid})
+	fake.recordInvocation("GetRoute", []interface{}{guid})
+	fake.getRouteMutex.Unlock()
+	if fake.GetRouteStub != nil {
+		return fake.GetRouteStub(guid)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getRouteReturns.result1, fake.getRouteReturns.result2, fake.getRouteReturns.result3
+}
+
+func (fake *FakeCloudControllerClient) GetRouteCallCount() int {
+	fake.getRouteMutex.RLock()
+	defer fake.getRouteMutex.RUnlock()
+	return len(fake.getRouteArgsForCall)
+}
+
+func (fake *FakeCloudControllerClient) GetRouteArgsForCall(i int) string {
+	fake.getRouteMutex.RLock()
+	defer fake.getRouteMutex.RUnlock()
+	return fake.getRouteArgsForCall[i].guid
+}
+
+func (fake *FakeCloudControllerClient) GetRouteReturns(result1 ccv3.Route, result2 ccv3.Warnings, result3 error) {
+	fake.GetRouteStub = nil
+	fake.getRouteReturns = struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCloudControllerClient) GetRouteReturnsOnCall(i int, result1 ccv3.Route, result2 ccv3.Warnings, result3 error) {
+	fake.GetRouteStub = nil
+	if fake.getRouteReturnsOnCall == nil {
+		fake.getRouteReturnsOnCall = make(map[int]struct {
+			result1 ccv3.Route
+			result2 ccv3.Warnings
+			result3 error
+		})
+	}
+	fake.getRouteReturnsOnCall[i] = struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCloudControllerClient) GetSpaceIsolationSegment(spaceGUID string) (ccv3.Relationship, ccv3.Warnings, error) {
 	fake.getSpaceIsolationSegmentMutex.Lock()
 	ret, specificReturn := fake.getSpaceIsolationSegmentReturnsOnCall[len(fake.getSpaceIsolationSegmentArgsForCall)]
@@ -2685,6 +2769,60 @@ func (fake *FakeCloudControllerClient) UpdateApplicationReturnsOnCall(i int, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeCloudControllerClient) UpdateRoute(route ccv3.Route) (ccv3.Route, ccv3.Warnings, error) {
+	fake.updateRouteMutex.Lock()
+	ret, specificReturn := fake.updateRouteReturnsOnCall[len(fake.updateRouteArgsForCall)]
+	fake.updateRouteArgsForCall = append(fake.updateRouteArgsForCall, struct {
+		route ccv3.Route
+	}{route})
+	fake.recordInvocation("UpdateRoute", []interface{}{route})
+	fake.updateRouteMutex.Unlock()
+	if fake.UpdateRouteStub != nil {
+		return fake.UpdateRouteStub(route)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.updateRouteReturns.result1, fake.updateRouteReturns.result2, fake.updateRouteReturns.result3
+}
+
+func (fake *FakeCloudControllerClient) UpdateRouteCallCount() int {
+	fake.updateRouteMutex.RLock()
+	defer fake.updateRouteMutex.RUnlock()
+	return len(fake.updateRouteArgsForCall)
+}
+
+func (fake *FakeCloudControllerClient) UpdateRouteArgsForCall(i int) ccv3.Route {
+	fake.updateRouteMutex.RLock()
+	defer fake.updateRouteMutex.RUnlock()
+	return fake.updateRouteArgsForCall[i].route
+}
+
+func (fake *FakeCloudControllerClient) UpdateRouteReturns(result1 ccv3.Route, result2 ccv3.Warnings, result3 error) {
+	fake.UpdateRouteStub = nil
+	fake.updateRouteReturns = struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCloudControllerClient) UpdateRouteReturnsOnCall(i int, result1 ccv3.Route, result2 ccv3.Warnings, result3 error) {
+	fake.UpdateRouteStub = nil
+	if fake.updateRouteReturnsOnCall == nil {
+		fake.updateRouteReturnsOnCall = make(map[int]struct {
+			result1 ccv3.Route
+			result2 ccv3.Warnings
+			result3 error
+		})
+	}
+	fake.updateRouteReturnsOnCall[i] = struct {
+		result1 ccv3.Route
+		result2 ccv3.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCloudControllerClient) PatchApplicationUserProvidedEnvironmentVariables(appGUID string, envVars ccv3.EnvironmentVariables) (ccv3.EnvironmentVariables, ccv3.Warnings, error) {
 	fake.patchApplicationUserProvidedEnvironmentVariablesMutex.Lock()
 	ret, specificReturn := fake.patchApplicationUserProvidedEnvironmentVariablesReturnsOnCall[len(fake.patchApplicationUserProvidedEnvironmentVariablesArgsForCall)]
@@ -2912,6 +3050,8 @@ func (fake *FakeCloudControllerClient) Invocations() map[string][][]interface{}
 	defer fake.getPackageMutex.RUnlock()
 	fake.getProcessInstancesMutex.RLock()
 	defer fake.getProcessInstancesMutex.RUnlock()
+	fake.getRouteMutex.RLock()
+	defer fake.getRouteMutex.RUnlock()
 	fake.getSpaceIsolationSegmentMutex.RLock()
 	defer fake.getSpaceIsolationSegmentMutex.RUnlock()
 	fake.patchApplicationProcessHealthCheckMutex.RLock()
@@ -2930,6 +3070,8 @@ func (fake *FakeCloudControllerClient) Invocations() map[string][][]interface{}
 	defer fake.stopApplicationMutex.RUnlock()
 	fake.updateApplicationMutex.RLock()
 	defer fake.updateApplicationMutex.RUnlock()
+	fake.updateRouteMutex.RLock()
+	defer fake.updateRouteMutex.RUnlock()
 	fake.patchApplicationUserProvidedEnvironmentVariablesMutex.RLock()
 	defer fake.patchApplicationUserProvidedEnvironmentVariablesMutex.RUnlock()
 	fake.updateTaskMutex.RLock()
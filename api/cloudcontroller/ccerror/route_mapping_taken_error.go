@@ -0,0 +1,12 @@
+package ccerror
+
+// RouteMappingTakenError is returned when a route cannot be mapped to an
+// application because the route, or a route with the same host, domain and
+// path, is already mapped to another application.
+type RouteMappingTakenError struct {
+	Message string
+}
+
+func (e RouteMappingTakenError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,12 @@
+package ccerror
+
+// RouteQuotaExceededError is returned when a route cannot be created because
+// the space or organization has reached the number of routes, or reserved
+// route ports, allowed by its quota.
+type RouteQuotaExceededError struct {
+	Message string
+}
+
+func (e RouteQuotaExceededError) Error() string {
+	return e.Message
+}
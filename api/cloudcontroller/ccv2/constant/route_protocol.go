@@ -0,0 +1,13 @@
+package constant
+
+// RouteProtocol is an enumeration of all possible route protocols.
+type RouteProtocol string
+
+const (
+	// HTTP1RouteProtocol represents a HTTP/1.1 route.
+	HTTP1RouteProtocol RouteProtocol = "http1"
+	// HTTP2RouteProtocol represents a HTTP/2 route.
+	HTTP2RouteProtocol RouteProtocol = "http2"
+	// TCPRouteProtocol represents a TCP route.
+	TCPRouteProtocol RouteProtocol = "tcp"
+)
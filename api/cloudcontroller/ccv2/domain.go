@@ -16,6 +16,14 @@ type Domain struct {
 	RouterGroupGUID string
 	RouterGroupType constant.RouterGroupType
 	Type            constant.DomainType
+
+	// OwningOrganizationGUID is the GUID of the organization that owns a
+	// private domain. It is empty for shared domains.
+	OwningOrganizationGUID string
+
+	// Internal is true for a shared domain that is only reachable through
+	// container-to-container networking.
+	Internal bool
 }
 
 // UnmarshalJSON helps unmarshal a Cloud Controller Domain response.
@@ -23,9 +31,11 @@ func (domain *Domain) UnmarshalJSON(data []byte) error {
 	var ccDomain struct {
 		Metadata internal.Metadata `json:"metadata"`
 		Entity   struct {
-			Name            string `json:"name"`
-			RouterGroupGUID string `json:"router_group_guid"`
-			RouterGroupType string `json:"router_group_type"`
+			Name                   string `json:"name"`
+			RouterGroupGUID        string `json:"router_group_guid"`
+			RouterGroupType        string `json:"router_group_type"`
+			OwningOrganizationGUID string `json:"owning_organization_guid"`
+			Internal               bool   `json:"internal"`
 		} `json:"entity"`
 	}
 	if err := json.Unmarshal(data, &ccDomain); err != nil {
@@ -36,6 +46,8 @@ func (domain *Domain) UnmarshalJSON(data []byte) error {
 	domain.Name = ccDomain.Entity.Name
 	domain.RouterGroupGUID = ccDomain.Entity.RouterGroupGUID
 	domain.RouterGroupType = constant.RouterGroupType(ccDomain.Entity.RouterGroupType)
+	domain.OwningOrganizationGUID = ccDomain.Entity.OwningOrganizationGUID
+	domain.Internal = ccDomain.Entity.Internal
 	return nil
 }
 
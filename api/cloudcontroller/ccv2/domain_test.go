@@ -29,7 +29,8 @@ var _ = Describe("Domain", func() {
 						"entity": {
 							"name": "shared-domain-1.com",
 							"router_group_guid": "some-router-group-guid",
-							"router_group_type": "http"
+							"router_group_type": "http",
+							"internal": true
 						}
 				}`
 				server.AppendHandlers(
@@ -49,6 +50,7 @@ var _ = Describe("Domain", func() {
 					RouterGroupGUID: "some-router-group-guid",
 					RouterGroupType: constant.HTTPRouterGroup,
 					Type:            constant.SharedDomain,
+					Internal:        true,
 				}))
 				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
 			})
@@ -88,7 +90,8 @@ var _ = Describe("Domain", func() {
 							"updated_at": null
 						},
 						"entity": {
-							"name": "private-domain-1.com"
+							"name": "private-domain-1.com",
+							"owning_organization_guid": "some-org-guid"
 						}
 				}`
 				server.AppendHandlers(
@@ -103,9 +106,10 @@ var _ = Describe("Domain", func() {
 				domain, warnings, err := client.GetPrivateDomain("private-domain-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(domain).To(Equal(Domain{
-					Name: "private-domain-1.com",
-					GUID: "private-domain-guid",
-					Type: constant.PrivateDomain,
+					Name:                   "private-domain-1.com",
+					GUID:                   "private-domain-guid",
+					Type:                   constant.PrivateDomain,
+					OwningOrganizationGUID: "some-org-guid",
 				}))
 				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
 			})
@@ -75,6 +75,13 @@ func handleBadRequest(errorResponse ccerror.V2ErrorResponse) error {
 		return ccerror.InvalidRelationError{Message: errorResponse.Description}
 	case "CF-NotStaged":
 		return ccerror.NotStagedError{Message: errorResponse.Description}
+	case "CF-OrgQuotaTotalReservedRoutePortsExceeded",
+		"CF-OrgQuotaTotalRoutesExceeded",
+		"CF-SpaceQuotaTotalReservedRoutePortsExceeded",
+		"CF-SpaceQuotaTotalRoutesExceeded":
+		return ccerror.RouteQuotaExceededError{Message: errorResponse.Description}
+	case "CF-RouteMappingTaken":
+		return ccerror.RouteMappingTakenError{Message: errorResponse.Description}
 	case "CF-ServiceBindingAppServiceTaken":
 		return ccerror.ServiceBindingTakenError{Message: errorResponse.Description}
 	default:
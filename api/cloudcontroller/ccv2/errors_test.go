@@ -128,6 +128,57 @@ var _ = Describe("Error Wrapper", func() {
 					})
 				})
 
+				Context("when a route mapping taken error is encountered", func() {
+					BeforeEach(func() {
+						serverResponse = `{
+							"code": 210006,
+							"description": "The route mapping is invalid: the path /foo is already mapped to another app",
+							"error_code": "CF-RouteMappingTaken"
+						}`
+					})
+
+					It("returns a RouteMappingTakenError", func() {
+						_, _, err := client.GetApplications()
+						Expect(err).To(MatchError(ccerror.RouteMappingTakenError{
+							Message: "The route mapping is invalid: the path /foo is already mapped to another app",
+						}))
+					})
+				})
+
+				Context("when the space route quota is exceeded", func() {
+					BeforeEach(func() {
+						serverResponse = `{
+							"code": 310005,
+							"description": "You have exceeded the total routes for your space's quota.",
+							"error_code": "CF-SpaceQuotaTotalRoutesExceeded"
+						}`
+					})
+
+					It("returns a RouteQuotaExceededError", func() {
+						_, _, err := client.GetApplications()
+						Expect(err).To(MatchError(ccerror.RouteQuotaExceededError{
+							Message: "You have exceeded the total routes for your space's quota.",
+						}))
+					})
+				})
+
+				Context("when the organization route quota is exceeded", func() {
+					BeforeEach(func() {
+						serverResponse = `{
+							"code": 310006,
+							"description": "You have exceeded the total routes for your organization's quota.",
+							"error_code": "CF-OrgQuotaTotalRoutesExceeded"
+						}`
+					})
+
+					It("returns a RouteQuotaExceededError", func() {
+						_, _, err := client.GetApplications()
+						Expect(err).To(MatchError(ccerror.RouteQuotaExceededError{
+							Message: "You have exceeded the total routes for your organization's quota.",
+						}))
+					})
+				})
+
 				Context("getting stats for a stopped app", func() {
 					BeforeEach(func() {
 						serverResponse = `{
@@ -25,6 +25,7 @@ const (
 	DeleteStagingSecurityGroupSpaceRequest               = "DeleteStagingSecurityGroupSpace"
 	GetAppInstancesRequest                               = "GetAppInstances"
 	GetAppRequest                                        = "GetApp"
+	GetAppRouteMappingsRequest                           = "GetAppRouteMappings"
 	GetAppRoutesRequest                                  = "GetAppRoutes"
 	GetAppStatsRequest                                   = "GetAppStats"
 	GetAppsRequest                                       = "GetApps"
@@ -64,6 +65,7 @@ const (
 	PostAppRequest                                       = "PostApp"
 	PostAppRestageRequest                                = "PostAppRestage"
 	PostRouteRequest                                     = "PostRoute"
+	PostRouteMappingRequest                              = "PostRouteMapping"
 	PostServiceBindingRequest                            = "PostServiceBinding"
 	PostUserRequest                                      = "PostUser"
 	PutAppBitsRequest                                    = "PutAppBits"
@@ -84,6 +86,7 @@ var APIRoutes = rata.Routes{
 	{Path: "/v2/apps/:app_guid/bits", Method: http.MethodPut, Name: PutAppBitsRequest},
 	{Path: "/v2/apps/:app_guid/instances", Method: http.MethodGet, Name: GetAppInstancesRequest},
 	{Path: "/v2/apps/:app_guid/restage", Method: http.MethodPost, Name: PostAppRestageRequest},
+	{Path: "/v2/apps/:app_guid/route_mappings", Method: http.MethodGet, Name: GetAppRouteMappingsRequest},
 	{Path: "/v2/apps/:app_guid/routes", Method: http.MethodGet, Name: GetAppRoutesRequest},
 	{Path: "/v2/apps/:app_guid/stats", Method: http.MethodGet, Name: GetAppStatsRequest},
 	{Path: "/v2/info", Method: http.MethodGet, Name: GetInfoRequest},
@@ -95,6 +98,7 @@ var APIRoutes = rata.Routes{
 	{Path: "/v2/private_domains/:private_domain_guid", Method: http.MethodGet, Name: GetPrivateDomainRequest},
 	{Path: "/v2/quota_definitions/:organization_quota_guid", Method: http.MethodGet, Name: GetOrganizationQuotaDefinitionRequest},
 	{Path: "/v2/resource_match", Method: http.MethodPut, Name: PutResourceMatch},
+	{Path: "/v2/route_mappings", Method: http.MethodPost, Name: PostRouteMappingRequest},
 	{Path: "/v2/routes", Method: http.MethodGet, Name: GetRoutesRequest},
 	{Path: "/v2/routes", Method: http.MethodPost, Name: PostRouteRequest},
 	{Path: "/v2/routes/:route_guid", Method: http.MethodDelete, Name: DeleteRouteRequest},
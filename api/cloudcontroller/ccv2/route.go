@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccversion"
 	"code.cloudfoundry.org/cli/types"
@@ -22,6 +24,16 @@ type Route struct {
 	Port       types.NullInt `json:"port,omitempty"`
 	DomainGUID string        `json:"domain_guid"`
 	SpaceGUID  string        `json:"space_guid"`
+
+	// Protocol is the protocol the router uses to talk to the route's
+	// applications. When empty the Cloud Controller picks the default.
+	Protocol constant.RouteProtocol `json:"protocol,omitempty"`
+
+	// CreatedAt is when the route was created.
+	CreatedAt time.Time `json:"-"`
+	// UpdatedAt is when the route was last updated. It is nil when the route
+	// has never been updated.
+	UpdatedAt *time.Time `json:"-"`
 }
 
 // UnmarshalJSON helps unmarshal a Cloud Controller Route response.
@@ -29,11 +41,12 @@ func (route *Route) UnmarshalJSON(data []byte) error {
 	var ccRoute struct {
 		Metadata internal.Metadata `json:"metadata"`
 		Entity   struct {
-			Host       string        `json:"host"`
-			Path       string        `json:"path"`
-			Port       types.NullInt `json:"port"`
-			DomainGUID string        `json:"domain_guid"`
-			SpaceGUID  string        `json:"space_guid"`
+			Host       string                 `json:"host"`
+			Path       string                 `json:"path"`
+			Port       types.NullInt          `json:"port"`
+			DomainGUID string                 `json:"domain_guid"`
+			SpaceGUID  string                 `json:"space_guid"`
+			Protocol   constant.RouteProtocol `json:"protocol"`
 		} `json:"entity"`
 	}
 	if err := json.Unmarshal(data, &ccRoute); err != nil {
@@ -46,6 +59,9 @@ func (route *Route) UnmarshalJSON(data []byte) error {
 	route.Port = ccRoute.Entity.Port
 	route.DomainGUID = ccRoute.Entity.DomainGUID
 	route.SpaceGUID = ccRoute.Entity.SpaceGUID
+	route.Protocol = ccRoute.Entity.Protocol
+	route.CreatedAt = ccRoute.Metadata.CreatedAt
+	route.UpdatedAt = ccRoute.Metadata.UpdatedAt
 	return nil
 }
 
@@ -0,0 +1,121 @@
+package ccv2
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/internal"
+	"code.cloudfoundry.org/cli/types"
+)
+
+// RouteMapping represents a Cloud Controller Route Mapping, which maps a
+// route to one port of an application.
+type RouteMapping struct {
+	GUID      string
+	AppGUID   string
+	RouteGUID string
+
+	// AppPort is the port of the application that the route's traffic is sent
+	// to. It is not set when the application's default port is used.
+	AppPort types.NullInt
+}
+
+// UnmarshalJSON helps unmarshal a Cloud Controller Route Mapping response.
+func (routeMapping *RouteMapping) UnmarshalJSON(data []byte) error {
+	var ccRouteMapping struct {
+		Metadata internal.Metadata
+		Entity   struct {
+			AppGUID   string `json:"app_guid"`
+			RouteGUID string `json:"route_guid"`
+			AppPort   *int   `json:"app_port"`
+		} `json:"entity"`
+	}
+	err := json.Unmarshal(data, &ccRouteMapping)
+	if err != nil {
+		return err
+	}
+
+	routeMapping.GUID = ccRouteMapping.Metadata.GUID
+	routeMapping.AppGUID = ccRouteMapping.Entity.AppGUID
+	routeMapping.RouteGUID = ccRouteMapping.Entity.RouteGUID
+	if ccRouteMapping.Entity.AppPort != nil {
+		routeMapping.AppPort = types.NullInt{IsSet: true, Value: *ccRouteMapping.Entity.AppPort}
+	}
+	return nil
+}
+
+// routeMappingRequestBody represents the body of a route mapping request.
+type routeMappingRequestBody struct {
+	AppGUID   string `json:"app_guid"`
+	RouteGUID string `json:"route_guid"`
+	AppPort   *int   `json:"app_port,omitempty"`
+
+	DeploymentGUID string `json:"deployment_guid,omitempty"`
+}
+
+// CreateRouteMapping maps the route to the application. When appPort is set,
+// traffic for the route is sent to that port of the application; otherwise
+// the application's default port is used.
+func (client *Client) CreateRouteMapping(appGUID string, routeGUID string, appPort types.NullInt) (Warnings, error) {
+	return client.CreateDeploymentRouteMapping(appGUID, routeGUID, appPort, "")
+}
+
+// CreateDeploymentRouteMapping maps the route to the application as
+// CreateRouteMapping does, but only sends the route's traffic to the
+// instances of the provided deployment. When deploymentGUID is empty, the
+// mapping is not scoped to a deployment.
+func (client *Client) CreateDeploymentRouteMapping(appGUID string, routeGUID string, appPort types.NullInt, deploymentGUID string) (Warnings, error) {
+	requestBody := routeMappingRequestBody{
+		AppGUID:        appGUID,
+		RouteGUID:      routeGUID,
+		DeploymentGUID: deploymentGUID,
+	}
+	if appPort.IsSet {
+		requestBody.AppPort = &appPort.Value
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.PostRouteMappingRequest,
+		Body:        bytes.NewReader(bodyBytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := cloudcontroller.Response{}
+	err = client.connection.Make(request, &response)
+	return response.Warnings, err
+}
+
+// GetApplicationRouteMappings returns the route mappings of the application.
+func (client *Client) GetApplicationRouteMappings(appGUID string) ([]RouteMapping, Warnings, error) {
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.GetAppRouteMappingsRequest,
+		URIParams:   map[string]string{"app_guid": appGUID},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fullRouteMappingsList []RouteMapping
+	warnings, err := client.paginate(request, RouteMapping{}, func(item interface{}) error {
+		if routeMapping, ok := item.(RouteMapping); ok {
+			fullRouteMappingsList = append(fullRouteMappingsList, routeMapping)
+		} else {
+			return ccerror.UnknownObjectInListError{
+				Expected:   RouteMapping{},
+				Unexpected: item,
+			}
+		}
+		return nil
+	})
+
+	return fullRouteMappingsList, warnings, err
+}
@@ -0,0 +1,200 @@
+package ccv2_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Route Mapping", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("CreateRouteMapping", func() {
+		Context("when the app port is set", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/route_mappings"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"app_guid":   "some-app-guid",
+							"route_guid": "some-route-guid",
+							"app_port":   8080,
+						}),
+						RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("maps the route to the app port and returns warnings", func() {
+				warnings, err := client.CreateRouteMapping("some-app-guid", "some-route-guid", types.NullInt{IsSet: true, Value: 8080})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+			})
+		})
+
+		Context("when the app port is not set", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/route_mappings"),
+						VerifyJSONRepresenting(map[string]interface{}{
+							"app_guid":   "some-app-guid",
+							"route_guid": "some-route-guid",
+						}),
+						RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("omits the app port", func() {
+				warnings, err := client.CreateRouteMapping("some-app-guid", "some-route-guid", types.NullInt{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+			})
+		})
+
+		Context("when the cloud controller returns an error", func() {
+			BeforeEach(func() {
+				response := `{
+					"code": 1002,
+					"description": "The request is invalid",
+					"error_code": "CF-InvalidRelation"
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/v2/route_mappings"),
+						RespondWith(http.StatusBadRequest, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				warnings, err := client.CreateRouteMapping("some-app-guid", "some-route-guid", types.NullInt{IsSet: true, Value: 8080})
+				Expect(err).To(MatchError(ccerror.InvalidRelationError{Message: "The request is invalid"}))
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+			})
+		})
+	})
+
+	Describe("CreateDeploymentRouteMapping", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/v2/route_mappings"),
+					VerifyJSONRepresenting(map[string]interface{}{
+						"app_guid":        "some-app-guid",
+						"route_guid":      "some-route-guid",
+						"app_port":        8080,
+						"deployment_guid": "some-deployment-guid",
+					}),
+					RespondWith(http.StatusCreated, `{}`, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+				),
+			)
+		})
+
+		It("maps the route to the deployment and returns warnings", func() {
+			warnings, err := client.CreateDeploymentRouteMapping("some-app-guid", "some-route-guid", types.NullInt{IsSet: true, Value: 8080}, "some-deployment-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+		})
+	})
+
+	Describe("GetApplicationRouteMappings", func() {
+		Context("when the app has route mappings", func() {
+			BeforeEach(func() {
+				response1 := `{
+					"next_url": "/v2/apps/some-app-guid/route_mappings?page=2",
+					"resources": [
+						{
+							"metadata": {
+								"guid": "route-mapping-guid-1"
+							},
+							"entity": {
+								"app_port": 8080,
+								"app_guid": "some-app-guid",
+								"route_guid": "route-guid-1"
+							}
+						}
+					]
+				}`
+				response2 := `{
+					"next_url": null,
+					"resources": [
+						{
+							"metadata": {
+								"guid": "route-mapping-guid-2"
+							},
+							"entity": {
+								"app_port": null,
+								"app_guid": "some-app-guid",
+								"route_guid": "route-guid-2"
+							}
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/apps/some-app-guid/route_mappings"),
+						RespondWith(http.StatusOK, response1, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/apps/some-app-guid/route_mappings", "page=2"),
+						RespondWith(http.StatusOK, response2, http.Header{"X-Cf-Warnings": {"this is another warning"}}),
+					),
+				)
+			})
+
+			It("returns the route mappings and all warnings", func() {
+				routeMappings, warnings, err := client.GetApplicationRouteMappings("some-app-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routeMappings).To(Equal([]RouteMapping{
+					{
+						GUID:      "route-mapping-guid-1",
+						AppGUID:   "some-app-guid",
+						RouteGUID: "route-guid-1",
+						AppPort:   types.NullInt{IsSet: true, Value: 8080},
+					},
+					{
+						GUID:      "route-mapping-guid-2",
+						AppGUID:   "some-app-guid",
+						RouteGUID: "route-guid-2",
+					},
+				}))
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning", "this is another warning"}))
+			})
+		})
+
+		Context("when the cloud controller returns an error", func() {
+			BeforeEach(func() {
+				response := `{
+					"code": 100004,
+					"description": "The app could not be found: some-app-guid",
+					"error_code": "CF-AppNotFound"
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v2/apps/some-app-guid/route_mappings"),
+						RespondWith(http.StatusNotFound, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				_, warnings, err := client.GetApplicationRouteMappings("some-app-guid")
+				Expect(err).To(MatchError(ccerror.ResourceNotFoundError{Message: "The app could not be found: some-app-guid"}))
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+			})
+		})
+	})
+})
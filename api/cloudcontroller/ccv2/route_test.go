@@ -2,9 +2,11 @@ package ccv2_test
 
 import (
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2/constant"
 	"code.cloudfoundry.org/cli/types"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -285,6 +287,56 @@ var _ = Describe("Route", func() {
 					}))
 				})
 			})
+
+			Context("when sending a route with a protocol", func() {
+				BeforeEach(func() {
+					response := `
+						{
+							"metadata": {
+								"guid": "some-route-guid"
+							},
+							"entity": {
+								"domain_guid": "some-domain-guid",
+								"host": "some-host",
+								"space_guid": "some-space-guid",
+								"protocol": "http2"
+							}
+						}`
+					requestBody := map[string]interface{}{
+						"host":        "some-host",
+						"port":        nil,
+						"domain_guid": "some-domain-guid",
+						"space_guid":  "some-space-guid",
+						"protocol":    "http2",
+					}
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodPost, "/v2/routes"),
+							VerifyJSONRepresenting(requestBody),
+							RespondWith(http.StatusCreated, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+						),
+					)
+				})
+
+				It("creates the route with the protocol", func() {
+					route, warnings, err := client.CreateRoute(Route{
+						DomainGUID: "some-domain-guid",
+						Host:       "some-host",
+						SpaceGUID:  "some-space-guid",
+						Protocol:   constant.HTTP2RouteProtocol,
+					}, false)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(warnings).To(ConsistOf("this is a warning"))
+					Expect(route).To(Equal(Route{
+						DomainGUID: "some-domain-guid",
+						GUID:       "some-route-guid",
+						Host:       "some-host",
+						SpaceGUID:  "some-space-guid",
+						Protocol:   constant.HTTP2RouteProtocol,
+					}))
+				})
+			})
 		})
 
 		Context("when the cc returns an error", func() {
@@ -326,7 +378,8 @@ var _ = Describe("Route", func() {
 					{
 						"metadata": {
 							"guid": "route-guid-1",
-							"updated_at": null
+							"created_at": "2016-06-08T16:41:22Z",
+							"updated_at": "2016-06-08T16:41:26Z"
 						},
 						"entity": {
 							"host": "host-1",
@@ -397,6 +450,7 @@ var _ = Describe("Route", func() {
 			})
 
 			It("returns all the routes and all warnings", func() {
+				updatedAt := time.Date(2016, 6, 8, 16, 41, 26, 0, time.UTC)
 				routes, warnings, err := client.GetRoutes(Query{
 					Filter:   OrganizationGUIDFilter,
 					Operator: EqualOperator,
@@ -411,6 +465,8 @@ var _ = Describe("Route", func() {
 						Port:       types.NullInt{IsSet: false},
 						DomainGUID: "some-http-domain",
 						SpaceGUID:  "some-space-guid-1",
+						CreatedAt:  time.Date(2016, 6, 8, 16, 41, 22, 0, time.UTC),
+						UpdatedAt:  &updatedAt,
 					},
 					{
 						GUID:       "route-guid-2",
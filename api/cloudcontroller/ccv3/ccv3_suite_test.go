@@ -118,6 +118,9 @@ func SetupV3Response() {
 			"processes": {
 				"href": "SERVER_URL/v3/processes"
 			},
+			"routes": {
+				"href": "SERVER_URL/v3/routes"
+			},
 			"droplets": {
 				"href": "SERVER_URL/v3/droplets"
 			}
@@ -31,6 +31,7 @@ const (
 	GetPackageRequest                                       = "GetPackage"
 	GetPackagesRequest                                      = "GetPackages"
 	GetProcessInstancesRequest                              = "GetProcessInstances"
+	GetRouteRequest                                         = "GetRoute"
 	GetSpaceRelationshipIsolationSegmentRequest             = "GetSpaceRelationshipIsolationSegmentRequest"
 	GetSpacesRequest                                        = "GetSpaces"
 	PatchApplicationCurrentDropletRequest                   = "PatchApplicationCurrentDroplet"
@@ -38,6 +39,7 @@ const (
 	PatchApplicationRequest                                 = "PatchApplicationRequest"
 	PatchApplicationUserProvidedEnvironmentVariablesRequest = "PatchApplicationUserProvidedEnvironmentVariablesRequest"
 	PatchOrganizationDefaultIsolationSegmentRequest         = "PatchOrganizationDefaultIsolationSegmentRequest"
+	PatchRouteRequest                                       = "PatchRoute"
 	PatchSpaceRelationshipIsolationSegmentRequest           = "PatchSpaceRelationshipIsolationSegmentRequest"
 	PostApplicationProcessScaleRequest                      = "PostApplicationProcessScale"
 	PostApplicationRequest                                  = "PostApplicationRequest"
@@ -59,6 +61,7 @@ const (
 	OrgsResource              = "organizations"
 	PackagesResource          = "packages"
 	ProcessesResource         = "processes"
+	RoutesResource            = "routes"
 	SpacesResource            = "spaces"
 	TasksResource             = "tasks"
 )
@@ -100,6 +103,8 @@ var APIRoutes = []Route{
 	{Path: "/:package_guid", Method: http.MethodGet, Name: GetPackageRequest, Resource: PackagesResource},
 	{Path: "/:process_guid", Method: http.MethodPatch, Name: PatchApplicationProcessHealthCheckRequest, Resource: ProcessesResource},
 	{Path: "/:process_guid/stats", Method: http.MethodGet, Name: GetProcessInstancesRequest, Resource: ProcessesResource},
+	{Path: "/:route_guid", Method: http.MethodGet, Name: GetRouteRequest, Resource: RoutesResource},
+	{Path: "/:route_guid", Method: http.MethodPatch, Name: PatchRouteRequest, Resource: RoutesResource},
 	{Path: "/:space_guid/relationships/isolation_segment", Method: http.MethodGet, Name: GetSpaceRelationshipIsolationSegmentRequest, Resource: SpacesResource},
 	{Path: "/:space_guid/relationships/isolation_segment", Method: http.MethodPatch, Name: PatchSpaceRelationshipIsolationSegmentRequest, Resource: SpacesResource},
 	{Path: "/:task_guid/cancel", Method: http.MethodPut, Name: PutTaskCancelRequest, Resource: TasksResource},
@@ -0,0 +1,85 @@
+package ccv3
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/internal"
+	"code.cloudfoundry.org/cli/types"
+)
+
+// Metadata represents the labels and annotations of a Cloud Controller V3
+// resource. When the metadata is updated, a label or annotation whose value
+// is not set is removed from the resource.
+type Metadata struct {
+	Labels      map[string]types.FilteredString `json:"labels,omitempty"`
+	Annotations map[string]types.FilteredString `json:"annotations,omitempty"`
+}
+
+// Route represents a Cloud Controller V3 Route. Only the fields the CLI
+// updates through the V3 API are included.
+type Route struct {
+	GUID     string            `json:"guid,omitempty"`
+	Metadata *Metadata         `json:"metadata,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+}
+
+// MarshalJSON converts a Route into a Cloud Controller V3 Route update. The
+// GUID is part of the request URL, so it is not included.
+func (r Route) MarshalJSON() ([]byte, error) {
+	var ccRoute struct {
+		Metadata *Metadata         `json:"metadata,omitempty"`
+		Options  map[string]string `json:"options,omitempty"`
+	}
+
+	ccRoute.Metadata = r.Metadata
+	ccRoute.Options = r.Options
+
+	return json.Marshal(ccRoute)
+}
+
+// GetRoute returns the route with the provided GUID.
+func (client *Client) GetRoute(guid string) (Route, Warnings, error) {
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.GetRouteRequest,
+		URIParams:   map[string]string{"route_guid": guid},
+	})
+	if err != nil {
+		return Route{}, nil, err
+	}
+
+	var responseRoute Route
+	response := cloudcontroller.Response{
+		Result: &responseRoute,
+	}
+	err = client.connection.Make(request, &response)
+
+	return responseRoute, response.Warnings, err
+}
+
+// UpdateRoute updates the route with the GUID of the provided route. Fields
+// that are not provided are left as they are.
+func (client *Client) UpdateRoute(route Route) (Route, Warnings, error) {
+	bodyBytes, err := json.Marshal(route)
+	if err != nil {
+		return Route{}, nil, err
+	}
+
+	request, err := client.newHTTPRequest(requestOptions{
+		RequestName: internal.PatchRouteRequest,
+		Body:        bytes.NewReader(bodyBytes),
+		URIParams:   map[string]string{"route_guid": route.GUID},
+	})
+	if err != nil {
+		return Route{}, nil, err
+	}
+
+	var responseRoute Route
+	response := cloudcontroller.Response{
+		Result: &responseRoute,
+	}
+	err = client.connection.Make(request, &response)
+
+	return responseRoute, response.Warnings, err
+}
@@ -0,0 +1,189 @@
+package ccv3_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Route", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewTestClient()
+	})
+
+	Describe("MarshalJSON", func() {
+		It("omits the GUID and sends unset metadata values as null", func() {
+			routeBytes, err := Route{
+				GUID: "some-route-guid",
+				Metadata: &Metadata{
+					Labels: map[string]types.FilteredString{
+						"env":  {IsSet: true, Value: "production"},
+						"tier": {},
+					},
+				},
+			}.MarshalJSON()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(routeBytes).To(MatchJSON(`{"metadata": {"labels": {"env": "production", "tier": null}}}`))
+		})
+
+		Context("when options are provided", func() {
+			It("includes the options", func() {
+				routeBytes, err := Route{
+					GUID:    "some-route-guid",
+					Options: map[string]string{"loadbalancing": "least-connection"},
+				}.MarshalJSON()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(routeBytes).To(MatchJSON(`{"options": {"loadbalancing": "least-connection"}}`))
+			})
+		})
+
+		Context("when no metadata or options are provided", func() {
+			It("omits them from the JSON", func() {
+				routeBytes, err := Route{GUID: "some-route-guid"}.MarshalJSON()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(routeBytes)).To(Equal("{}"))
+			})
+		})
+	})
+
+	Describe("GetRoute", func() {
+		Context("when the route exists", func() {
+			BeforeEach(func() {
+				response := `{
+					"guid": "some-route-guid",
+					"metadata": {
+						"labels": {"env": "production"},
+						"annotations": {"owner": "some-team"}
+					}
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v3/routes/some-route-guid"),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the route and warnings", func() {
+				route, warnings, err := client.GetRoute("some-route-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(route).To(Equal(Route{
+					GUID: "some-route-guid",
+					Metadata: &Metadata{
+						Labels:      map[string]types.FilteredString{"env": {IsSet: true, Value: "production"}},
+						Annotations: map[string]types.FilteredString{"owner": {IsSet: true, Value: "some-team"}},
+					},
+				}))
+			})
+		})
+
+		Context("when the route does not exist", func() {
+			BeforeEach(func() {
+				response := `{
+					"errors": [
+						{
+							"code": 10010,
+							"detail": "Route not found",
+							"title": "CF-ResourceNotFound"
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodGet, "/v3/routes/some-route-guid"),
+						RespondWith(http.StatusNotFound, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				_, warnings, err := client.GetRoute("some-route-guid")
+				Expect(err).To(MatchError(ccerror.ResourceNotFoundError{Message: "Route not found"}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+
+	Describe("UpdateRoute", func() {
+		Context("when the route is updated", func() {
+			BeforeEach(func() {
+				response := `{
+					"guid": "some-route-guid",
+					"metadata": {
+						"labels": {"env": "production"},
+						"annotations": {}
+					}
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, "/v3/routes/some-route-guid"),
+						VerifyJSON(`{"metadata": {"labels": {"env": "production", "tier": null}}}`),
+						RespondWith(http.StatusOK, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the updated route and warnings", func() {
+				route, warnings, err := client.UpdateRoute(Route{
+					GUID: "some-route-guid",
+					Metadata: &Metadata{
+						Labels: map[string]types.FilteredString{
+							"env":  {IsSet: true, Value: "production"},
+							"tier": {},
+						},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ConsistOf("this is a warning"))
+				Expect(route).To(Equal(Route{
+					GUID: "some-route-guid",
+					Metadata: &Metadata{
+						Labels:      map[string]types.FilteredString{"env": {IsSet: true, Value: "production"}},
+						Annotations: map[string]types.FilteredString{},
+					},
+				}))
+			})
+		})
+
+		Context("when the cloud controller rejects the update", func() {
+			BeforeEach(func() {
+				response := `{
+					"errors": [
+						{
+							"code": 10008,
+							"detail": "Metadata label key error: 'bad key' contains invalid characters",
+							"title": "CF-UnprocessableEntity"
+						}
+					]
+				}`
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPatch, "/v3/routes/some-route-guid"),
+						RespondWith(http.StatusUnprocessableEntity, response, http.Header{"X-Cf-Warnings": {"this is a warning"}}),
+					),
+				)
+			})
+
+			It("returns the error with the Cloud Controller's message and warnings", func() {
+				_, warnings, err := client.UpdateRoute(Route{
+					GUID: "some-route-guid",
+					Metadata: &Metadata{
+						Labels: map[string]types.FilteredString{"bad key": {IsSet: true, Value: "value"}},
+					},
+				})
+				Expect(err).To(MatchError(ccerror.UnprocessableEntityError{
+					Message: "Metadata label key error: 'bad key' contains invalid characters",
+				}))
+				Expect(warnings).To(ConsistOf("this is a warning"))
+			})
+		})
+	})
+})
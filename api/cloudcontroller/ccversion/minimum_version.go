@@ -12,4 +12,5 @@ const (
 	MinVersionV3                 = "3.27.0"
 	MinVersionRunTaskV3          = "3.0.0"
 	MinVersionIsolationSegmentV3 = "3.11.0"
+	MinVersionRouteOptionsV3     = "3.183.0"
 )
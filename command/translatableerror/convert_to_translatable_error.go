@@ -44,14 +44,36 @@ func ConvertToTranslatableError(err error) error {
 		return HostnameWithTCPDomainError(e)
 	case actionerror.HTTPHealthCheckInvalidError:
 		return HTTPHealthCheckInvalidError{}
+	case actionerror.InternalRouteOnExternalDomainError:
+		return InternalRouteOnExternalDomainError(e)
+	case actionerror.InvalidHostnameError:
+		return InvalidHostnameError(e)
 	case actionerror.InvalidHTTPRouteSettings:
 		return PortNotAllowedWithHTTPDomainError(e)
+	case actionerror.InvalidInternalRouteSettings:
+		return InvalidInternalRouteSettings(e)
+	case actionerror.InvalidRouteHostnameError:
+		return InvalidRouteHostnameError(e)
+	case actionerror.InvalidRouteOptionValueError:
+		return InvalidRouteOptionValueError(e)
+	case actionerror.InvalidRoutePathError:
+		return InvalidRoutePathError(e)
 	case actionerror.InvalidTCPRouteSettings:
 		return HostAndPathNotAllowedWithTCPDomainError(e)
+	case actionerror.InvalidRoutesError:
+		var routes []InvalidRoute
+		for _, route := range e.Routes {
+			routes = append(routes, InvalidRoute{Route: route.Route, Err: ConvertToTranslatableError(route.Err)})
+		}
+		return InvalidRoutesError{Routes: routes}
+	case actionerror.InvertedRoutePortRangeError:
+		return InvertedRoutePortRangeError(e)
 	case actionerror.IsolationSegmentNotFoundError:
 		return IsolationSegmentNotFoundError(e)
 	case actionerror.MissingNameError:
 		return RequiredNameForPushError{}
+	case actionerror.MultiplePrimaryRoutesError:
+		return MultiplePrimaryRoutesError(e)
 	case actionerror.NoCompatibleBinaryError:
 		return NoCompatibleBinaryError{}
 	case actionerror.NoDomainsFoundError:
@@ -76,6 +98,8 @@ func ConvertToTranslatableError(err error) error {
 		return PluginInvalidError(e)
 	case actionerror.PluginNotFoundError:
 		return PluginNotFoundError(e)
+	case actionerror.PortWithHTTPDomainError:
+		return PortWithHTTPDomainError(e)
 	case actionerror.ProcessInstanceNotFoundError:
 		return ProcessInstanceNotFoundError(e)
 	case actionerror.ProcessInstanceNotRunningError:
@@ -84,14 +108,45 @@ func ConvertToTranslatableError(err error) error {
 		return ProcessNotFoundError(e)
 	case actionerror.PropertyCombinationError:
 		return PropertyCombinationError(e)
+	case actionerror.RandomRouteUnavailableError:
+		return RandomRouteUnavailableError(e)
 	case actionerror.RepositoryNameTakenError:
 		return RepositoryNameTakenError(e)
 	case actionerror.RepositoryNotRegisteredError:
 		return RepositoryNotRegisteredError(e)
 	case actionerror.RouteInDifferentSpaceError:
 		return RouteInDifferentSpaceError(e)
+	case actionerror.RouteMappedToBothApplicationsError:
+		return RouteMappedToBothApplicationsError{
+			Route:       e.Route,
+			FromAppGUID: e.FromAppGUID,
+			ToAppGUID:   e.ToAppGUID,
+			Err:         ConvertToTranslatableError(e.Err),
+		}
+	case actionerror.RouteOptionsNotSupportedError:
+		return RouteOptionsNotSupportedError(e)
+	case actionerror.RouteOwnedByOtherOrganizationError:
+		return RouteOwnedByOtherOrganizationError(e)
+	case actionerror.RoutePathConflictError:
+		return RoutePathConflictError(e)
 	case actionerror.RoutePathWithTCPDomainError:
 		return RoutePathWithTCPDomainError(e)
+	case actionerror.RoutePortRangeTooLargeError:
+		return RoutePortRangeTooLargeError(e)
+	case actionerror.RouteProtocolNotSupportedByDomainError:
+		return RouteProtocolNotSupportedByDomainError(e)
+	case actionerror.RouteQueryOrFragmentError:
+		return RouteQueryOrFragmentError(e)
+	case actionerror.RouteQuotaExceededError:
+		return RouteQuotaExceededError(e)
+	case actionerror.RouteRequiresV3APIError:
+		return RouteRequiresV3APIError(e)
+	case actionerror.RouteSessionAffinityNotSupportedError:
+		return RouteSessionAffinityNotSupportedError(e)
+	case actionerror.RouteTooManyLabelsError:
+		return RouteTooManyLabelsError(e)
+	case actionerror.RouteWarningsError:
+		return RouteWarningsError(e)
 	case actionerror.SecurityGroupNotFoundError:
 		return SecurityGroupNotFoundError(e)
 	case actionerror.ServiceInstanceNotFoundError:
@@ -104,8 +159,18 @@ func ConvertToTranslatableError(err error) error {
 		return StagingTimeoutError(e)
 	case actionerror.TaskWorkersUnavailableError:
 		return RunTaskError{Message: "Task workers are unavailable."}
+	case actionerror.TCPRouteMissingPortError:
+		return TCPRouteMissingPortError(e)
+	case actionerror.TooManyRoutesError:
+		return TooManyRoutesError(e)
+	case actionerror.UnknownRouteOptionError:
+		return UnknownRouteOptionError(e)
+	case actionerror.UnsupportedRouteSchemeError:
+		return UnsupportedRouteSchemeError(e)
 	case actionerror.UploadFailedError:
 		return UploadFailedError{Err: ConvertToTranslatableError(e.Err)}
+	case actionerror.WildcardHostNotAllowedError:
+		return WildcardHostNotAllowedError(e)
 
 	// Generic CC Errors
 	case ccerror.APINotFoundError:
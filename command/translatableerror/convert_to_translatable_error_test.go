@@ -58,8 +58,8 @@ var _ = Describe("ConvertToTranslatableError", func() {
 			DockerPasswordNotSetError{}),
 
 		Entry("actionerror.DomainNotFoundError -> DomainNotFoundError",
-			actionerror.DomainNotFoundError{Name: "some-domain-name", GUID: "some-domain-guid"},
-			DomainNotFoundError{Name: "some-domain-name", GUID: "some-domain-guid"}),
+			actionerror.DomainNotFoundError{Name: "some-domain-name", GUID: "some-domain-guid", Suggestion: "some-suggested-domain"},
+			DomainNotFoundError{Name: "some-domain-name", GUID: "some-domain-guid", Suggestion: "some-suggested-domain"}),
 
 		Entry("actionerror.EmptyDirectoryError -> EmptyDirectoryError",
 			actionerror.EmptyDirectoryError{Path: "some-filename"},
@@ -85,18 +85,60 @@ var _ = Describe("ConvertToTranslatableError", func() {
 			actionerror.HTTPHealthCheckInvalidError{},
 			HTTPHealthCheckInvalidError{}),
 
+		Entry("actionerror.InternalRouteOnExternalDomainError -> InternalRouteOnExternalDomainError",
+			actionerror.InternalRouteOnExternalDomainError{Domain: "example.com"},
+			InternalRouteOnExternalDomainError{Domain: "example.com"}),
+
+		Entry("actionerror.InvalidHostnameError -> InvalidHostnameError",
+			actionerror.InvalidHostnameError{Original: "%^"},
+			InvalidHostnameError{Original: "%^"}),
+
 		Entry("actionerror.InvalidHTTPRouteSettings -> PortNotAllowedWithHTTPDomainError",
 			actionerror.InvalidHTTPRouteSettings{Domain: "some-domain"},
 			PortNotAllowedWithHTTPDomainError{Domain: "some-domain"}),
 
+		Entry("actionerror.InvalidInternalRouteSettings -> InvalidInternalRouteSettings",
+			actionerror.InvalidInternalRouteSettings{Domain: "apps.internal"},
+			InvalidInternalRouteSettings{Domain: "apps.internal"}),
+
+		Entry("actionerror.InvalidRouteHostnameError -> InvalidRouteHostnameError",
+			actionerror.InvalidRouteHostnameError{Hostname: "some-hostname", Err: errors.New("idna: invalid label")},
+			InvalidRouteHostnameError{Hostname: "some-hostname", Err: errors.New("idna: invalid label")}),
+
+		Entry("actionerror.InvalidRouteOptionValueError -> InvalidRouteOptionValueError",
+			actionerror.InvalidRouteOptionValueError{Route: "some-route", Option: "loadbalancing", Value: "random", Allowed: []string{"round-robin", "least-connection"}},
+			InvalidRouteOptionValueError{Route: "some-route", Option: "loadbalancing", Value: "random", Allowed: []string{"round-robin", "least-connection"}}),
+
+		Entry("actionerror.InvalidRoutePathError -> InvalidRoutePathError",
+			actionerror.InvalidRoutePathError{Route: "some-route/a b", Path: "/a b", Character: ' ', Position: 3},
+			InvalidRoutePathError{Route: "some-route/a b", Path: "/a b", Character: ' ', Position: 3}),
+
 		Entry("actionerror.InvalidTCPRouteSettings -> HostAndPathNotAllowedWithTCPDomainError",
 			actionerror.InvalidTCPRouteSettings{Domain: "some-domain"},
 			HostAndPathNotAllowedWithTCPDomainError{Domain: "some-domain"}),
 
+		Entry("actionerror.InvalidRoutesError -> InvalidRoutesError",
+			actionerror.InvalidRoutesError{Routes: []actionerror.InvalidRoute{
+				{Route: "a.example.com:1234", Err: actionerror.InvalidHTTPRouteSettings{Domain: "example.com"}},
+				{Route: "b.example.com", Err: actionerror.NoMatchingDomainError{Route: "b.example.com"}},
+			}},
+			InvalidRoutesError{Routes: []InvalidRoute{
+				{Route: "a.example.com:1234", Err: PortNotAllowedWithHTTPDomainError{Domain: "example.com"}},
+				{Route: "b.example.com", Err: NoMatchingDomainError{Route: "b.example.com"}},
+			}}),
+
+		Entry("actionerror.InvertedRoutePortRangeError -> InvertedRoutePortRangeError",
+			actionerror.InvertedRoutePortRangeError{Route: "tcp.example.com:6005-6000"},
+			InvertedRoutePortRangeError{Route: "tcp.example.com:6005-6000"}),
+
 		Entry("actionerror.MissingNameError -> RequiredNameForPushError",
 			actionerror.MissingNameError{},
 			RequiredNameForPushError{}),
 
+		Entry("actionerror.MultiplePrimaryRoutesError -> MultiplePrimaryRoutesError",
+			actionerror.MultiplePrimaryRoutesError{AppName: "some-app", Routes: []string{"a.com", "b.com"}},
+			MultiplePrimaryRoutesError{AppName: "some-app", Routes: []string{"a.com", "b.com"}}),
+
 		Entry("actionerror.NoCompatibleBinaryError -> NoCompatibleBinaryError",
 			actionerror.NoCompatibleBinaryError{},
 			NoCompatibleBinaryError{}),
@@ -159,6 +201,10 @@ var _ = Describe("ConvertToTranslatableError", func() {
 			actionerror.PluginNotFoundError{PluginName: "some-plugin"},
 			PluginNotFoundError{PluginName: "some-plugin"}),
 
+		Entry("actionerror.PortWithHTTPDomainError -> PortWithHTTPDomainError",
+			actionerror.PortWithHTTPDomainError{Route: "some-route", Domain: "some-domain"},
+			PortWithHTTPDomainError{Route: "some-route", Domain: "some-domain"}),
+
 		Entry("actionerror.ProcessInstanceNotFoundError -> ProcessInstanceNotFoundError",
 			actionerror.ProcessInstanceNotFoundError{ProcessType: "some-process-type", InstanceIndex: 42},
 			ProcessInstanceNotFoundError{ProcessType: "some-process-type", InstanceIndex: 42}),
@@ -175,6 +221,10 @@ var _ = Describe("ConvertToTranslatableError", func() {
 			actionerror.PropertyCombinationError{Properties: []string{"property-1", "property-2"}},
 			PropertyCombinationError{Properties: []string{"property-1", "property-2"}}),
 
+		Entry("actionerror.RandomRouteUnavailableError -> RandomRouteUnavailableError",
+			actionerror.RandomRouteUnavailableError{Attempts: 5},
+			RandomRouteUnavailableError{Attempts: 5}),
+
 		Entry("actionerror.RepositoryNameTakenError -> RepositoryNameTakenError",
 			actionerror.RepositoryNameTakenError{Name: "some-repo"},
 			RepositoryNameTakenError{Name: "some-repo"}),
@@ -187,10 +237,58 @@ var _ = Describe("ConvertToTranslatableError", func() {
 			actionerror.RouteInDifferentSpaceError{Route: "some-route"},
 			RouteInDifferentSpaceError{Route: "some-route"}),
 
+		Entry("actionerror.RouteMappedToBothApplicationsError -> RouteMappedToBothApplicationsError",
+			actionerror.RouteMappedToBothApplicationsError{Route: "some-route", FromAppGUID: "blue-guid", ToAppGUID: "green-guid", Err: actionerror.RouteInDifferentSpaceError{Route: "some-route"}},
+			RouteMappedToBothApplicationsError{Route: "some-route", FromAppGUID: "blue-guid", ToAppGUID: "green-guid", Err: RouteInDifferentSpaceError{Route: "some-route"}}),
+
+		Entry("actionerror.RouteOptionsNotSupportedError -> RouteOptionsNotSupportedError",
+			actionerror.RouteOptionsNotSupportedError{Route: "some-route", CurrentVersion: "3.100.0", MinimumVersion: "3.183.0"},
+			RouteOptionsNotSupportedError{Route: "some-route", CurrentVersion: "3.100.0", MinimumVersion: "3.183.0"}),
+
+		Entry("actionerror.RouteOwnedByOtherOrganizationError -> RouteOwnedByOtherOrganizationError",
+			actionerror.RouteOwnedByOtherOrganizationError{Route: "some-route.com", Organization: "some-org"},
+			RouteOwnedByOtherOrganizationError{Route: "some-route.com", Organization: "some-org"}),
+
+		Entry("actionerror.RoutePathConflictError -> RoutePathConflictError",
+			actionerror.RoutePathConflictError{Route: "example.com/foo", Path: "/foo", App: "some-app"},
+			RoutePathConflictError{Route: "example.com/foo", Path: "/foo", App: "some-app"}),
+
 		Entry("actionerror.RoutePathWithTCPDomainError -> RoutePathWithTCPDomainError",
 			actionerror.RoutePathWithTCPDomainError{},
 			RoutePathWithTCPDomainError{}),
 
+		Entry("actionerror.RoutePortRangeTooLargeError -> RoutePortRangeTooLargeError",
+			actionerror.RoutePortRangeTooLargeError{Route: "tcp.example.com:6000-7000", MaxPorts: 100},
+			RoutePortRangeTooLargeError{Route: "tcp.example.com:6000-7000", MaxPorts: 100}),
+
+		Entry("actionerror.RouteProtocolNotSupportedByDomainError -> RouteProtocolNotSupportedByDomainError",
+			actionerror.RouteProtocolNotSupportedByDomainError{Protocol: "http2", Domain: "some-tcp-domain"},
+			RouteProtocolNotSupportedByDomainError{Protocol: "http2", Domain: "some-tcp-domain"}),
+
+		Entry("actionerror.RouteQueryOrFragmentError -> RouteQueryOrFragmentError",
+			actionerror.RouteQueryOrFragmentError{Route: "some-route.com/path?query"},
+			RouteQueryOrFragmentError{Route: "some-route.com/path?query"}),
+
+		Entry("actionerror.RouteQuotaExceededError -> RouteQuotaExceededError",
+			actionerror.RouteQuotaExceededError{Route: "some-route", Message: "some-message"},
+			RouteQuotaExceededError{Route: "some-route", Message: "some-message"}),
+
+		Entry("actionerror.RouteRequiresV3APIError -> RouteRequiresV3APIError",
+			actionerror.RouteRequiresV3APIError{Route: "some-route"},
+			RouteRequiresV3APIError{Route: "some-route"}),
+
+		Entry("actionerror.RouteSessionAffinityNotSupportedError -> RouteSessionAffinityNotSupportedError",
+			actionerror.RouteSessionAffinityNotSupportedError{Route: "some-route"},
+			RouteSessionAffinityNotSupportedError{Route: "some-route"}),
+
+		Entry("actionerror.RouteTooManyLabelsError -> RouteTooManyLabelsError",
+			actionerror.RouteTooManyLabelsError{Route: "a.b.c", MaxLabels: 2},
+			RouteTooManyLabelsError{Route: "a.b.c", MaxLabels: 2}),
+
+		Entry("actionerror.RouteWarningsError -> RouteWarningsError",
+			actionerror.RouteWarningsError{Warnings: []string{"warning-1", "warning-2"}},
+			RouteWarningsError{Warnings: []string{"warning-1", "warning-2"}}),
+
 		Entry("actionerror.SecurityGroupNotFoundError -> SecurityGroupNotFoundError",
 			actionerror.SecurityGroupNotFoundError{Name: "some-security-group"},
 			SecurityGroupNotFoundError{Name: "some-security-group"}),
@@ -211,6 +309,22 @@ var _ = Describe("ConvertToTranslatableError", func() {
 			actionerror.TaskWorkersUnavailableError{Message: "fooo: Banana Pants"},
 			RunTaskError{Message: "Task workers are unavailable."}),
 
+		Entry("actionerror.TCPRouteMissingPortError -> TCPRouteMissingPortError",
+			actionerror.TCPRouteMissingPortError{Route: "some-route"},
+			TCPRouteMissingPortError{Route: "some-route"}),
+
+		Entry("actionerror.TooManyRoutesError -> TooManyRoutesError",
+			actionerror.TooManyRoutesError{Routes: 3, MaxRoutes: 2},
+			TooManyRoutesError{Routes: 3, MaxRoutes: 2}),
+
+		Entry("actionerror.UnknownRouteOptionError -> UnknownRouteOptionError",
+			actionerror.UnknownRouteOptionError{Route: "some-route", Option: "some-option"},
+			UnknownRouteOptionError{Route: "some-route", Option: "some-option"}),
+
+		Entry("actionerror.UnsupportedRouteSchemeError -> UnsupportedRouteSchemeError",
+			actionerror.UnsupportedRouteSchemeError{Route: "ws://some-route", Scheme: "ws"},
+			UnsupportedRouteSchemeError{Route: "ws://some-route", Scheme: "ws"}),
+
 		Entry("actionerror.UploadFailedError -> UploadFailedError",
 			actionerror.UploadFailedError{Err: actionerror.NoDomainsFoundError{}},
 			UploadFailedError{Err: NoDomainsFoundError{}}),
@@ -219,6 +333,10 @@ var _ = Describe("ConvertToTranslatableError", func() {
 			actionerror.StagingTimeoutError{AppName: "some-app", Timeout: time.Nanosecond},
 			StagingTimeoutError{AppName: "some-app", Timeout: time.Nanosecond}),
 
+		Entry("actionerror.WildcardHostNotAllowedError -> WildcardHostNotAllowedError",
+			actionerror.WildcardHostNotAllowedError{Domain: "some-domain"},
+			WildcardHostNotAllowedError{Domain: "some-domain"}),
+
 		// CC Errors
 		Entry("ccerror.APINotFoundError -> APINotFoundError",
 			ccerror.APINotFoundError{URL: "some-url"},
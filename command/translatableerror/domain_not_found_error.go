@@ -1,12 +1,15 @@
 package translatableerror
 
 type DomainNotFoundError struct {
-	Name string
-	GUID string
+	Name       string
+	GUID       string
+	Suggestion string
 }
 
 func (e DomainNotFoundError) Error() string {
 	switch {
+	case e.Name != "" && e.Suggestion != "":
+		return "Domain {{.DomainName}} not found. Did you mean {{.Suggestion}}?"
 	case e.Name != "":
 		return "Domain {{.DomainName}} not found"
 	case e.GUID != "":
@@ -20,5 +23,6 @@ func (e DomainNotFoundError) Translate(translate func(string, ...interface{}) st
 	return translate(e.Error(), map[string]interface{}{
 		"DomainName": e.Name,
 		"DomainGUID": e.GUID,
+		"Suggestion": e.Suggestion,
 	})
 }
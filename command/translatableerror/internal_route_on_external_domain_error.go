@@ -0,0 +1,15 @@
+package translatableerror
+
+type InternalRouteOnExternalDomainError struct {
+	Domain string
+}
+
+func (InternalRouteOnExternalDomainError) Error() string {
+	return "The domain {{.Domain}} is not an internal domain.\nTIP: Use an internal domain, such as apps.internal, for internal routes."
+}
+
+func (e InternalRouteOnExternalDomainError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Domain": e.Domain,
+	})
+}
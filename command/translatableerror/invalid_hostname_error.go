@@ -0,0 +1,15 @@
+package translatableerror
+
+type InvalidHostnameError struct {
+	Original string
+}
+
+func (InvalidHostnameError) Error() string {
+	return "Hostname '{{.Original}}' does not contain any valid characters. Hostnames must contain at least one letter or number; specify a hostname with the 'hostname' manifest property or the --hostname flag."
+}
+
+func (e InvalidHostnameError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Original": e.Original,
+	})
+}
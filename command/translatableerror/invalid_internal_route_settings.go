@@ -0,0 +1,15 @@
+package translatableerror
+
+type InvalidInternalRouteSettings struct {
+	Domain string
+}
+
+func (InvalidInternalRouteSettings) Error() string {
+	return "The route is invalid: routes on internal domain {{.Domain}} cannot have a path or port."
+}
+
+func (e InvalidInternalRouteSettings) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Domain": e.Domain,
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+type InvalidRouteHostnameError struct {
+	Hostname string
+	Err      error
+}
+
+func (InvalidRouteHostnameError) Error() string {
+	return "Hostname '{{.Hostname}}' is not valid: {{.Error}}"
+}
+
+func (e InvalidRouteHostnameError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Hostname": e.Hostname,
+		"Error":    e.Err.Error(),
+	})
+}
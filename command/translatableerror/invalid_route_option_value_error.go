@@ -0,0 +1,23 @@
+package translatableerror
+
+import "strings"
+
+type InvalidRouteOptionValueError struct {
+	Route   string
+	Option  string
+	Value   string
+	Allowed []string
+}
+
+func (InvalidRouteOptionValueError) Error() string {
+	return "Route {{.Route}} has an invalid value for option {{.Option}}: {{.Value}}\nValid values are: {{.Allowed}}"
+}
+
+func (e InvalidRouteOptionValueError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":   e.Route,
+		"Option":  e.Option,
+		"Value":   e.Value,
+		"Allowed": strings.Join(e.Allowed, ", "),
+	})
+}
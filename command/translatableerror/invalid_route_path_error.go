@@ -0,0 +1,23 @@
+package translatableerror
+
+import "strconv"
+
+type InvalidRoutePathError struct {
+	Route     string
+	Path      string
+	Character rune
+	Position  int
+}
+
+func (InvalidRoutePathError) Error() string {
+	return "The path {{.Path}} of route {{.Route}} contains the invalid character {{.Character}} at position {{.Position}}. Paths cannot contain spaces, control characters or any of \"<>\\^`{|}."
+}
+
+func (e InvalidRoutePathError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":     e.Route,
+		"Path":      e.Path,
+		"Character": strconv.QuoteRune(e.Character),
+		"Position":  e.Position,
+	})
+}
@@ -0,0 +1,34 @@
+package translatableerror
+
+import "strings"
+
+type InvalidRoute struct {
+	Route string
+	Err   error
+}
+
+type InvalidRoutesError struct {
+	Routes []InvalidRoute
+}
+
+func (InvalidRoutesError) Error() string {
+	return "The following routes are invalid:"
+}
+
+func (e InvalidRoutesError) Translate(translate func(string, ...interface{}) string) string {
+	lines := []string{translate(e.Error())}
+	for _, route := range e.Routes {
+		var message string
+		if err, ok := route.Err.(TranslatableError); ok {
+			message = err.Translate(translate)
+		} else {
+			message = route.Err.Error()
+		}
+
+		lines = append(lines, translate("{{.Route}}: {{.Error}}", map[string]interface{}{
+			"Route": route.Route,
+			"Error": message,
+		}))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,45 @@
+package translatableerror_test
+
+import (
+	"bytes"
+	"errors"
+	"text/template"
+
+	. "code.cloudfoundry.org/cli/command/translatableerror"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InvalidRoutesError", func() {
+	Describe("Translate()", func() {
+		var translateFunc func(string, ...interface{}) string
+
+		BeforeEach(func() {
+			translateFunc = func(templateStr string, subs ...interface{}) string {
+				t := template.Must(template.New("some-text-template").Parse(templateStr))
+				buffer := bytes.NewBuffer([]byte{})
+				var data interface{}
+				if len(subs) > 0 {
+					data = subs[0]
+				}
+				err := t.Execute(buffer, data)
+				Expect(err).NotTo(HaveOccurred())
+				return buffer.String()
+			}
+		})
+
+		It("lists each invalid route with its translated reason", func() {
+			err := InvalidRoutesError{
+				Routes: []InvalidRoute{
+					{Route: "a.example.com:1234", Err: PortNotAllowedWithHTTPDomainError{Domain: "example.com"}},
+					{Route: "b.example.com", Err: errors.New("some-error")},
+				},
+			}
+
+			Expect(err.Translate(translateFunc)).To(Equal(`The following routes are invalid:
+a.example.com:1234: Port not allowed in HTTP domain example.com
+b.example.com: some-error`))
+		})
+	})
+})
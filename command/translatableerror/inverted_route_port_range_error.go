@@ -0,0 +1,15 @@
+package translatableerror
+
+type InvertedRoutePortRangeError struct {
+	Route string
+}
+
+func (InvertedRoutePortRangeError) Error() string {
+	return "The route {{.Route}} is invalid: the start of a port range must not be greater than its end."
+}
+
+func (e InvertedRoutePortRangeError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route": e.Route,
+	})
+}
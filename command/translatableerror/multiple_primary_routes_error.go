@@ -0,0 +1,19 @@
+package translatableerror
+
+import "strings"
+
+type MultiplePrimaryRoutesError struct {
+	AppName string
+	Routes  []string
+}
+
+func (MultiplePrimaryRoutesError) Error() string {
+	return "Application {{.AppName}} has more than one primary route: {{.Routes}}. Mark only one of the application's routes as primary."
+}
+
+func (e MultiplePrimaryRoutesError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"AppName": e.AppName,
+		"Routes":  strings.Join(e.Routes, ", "),
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+type PortWithHTTPDomainError struct {
+	Route  string
+	Domain string
+}
+
+func (PortWithHTTPDomainError) Error() string {
+	return "The route {{.Route}} is invalid: a port cannot be used with the HTTP domain {{.Domain}}."
+}
+
+func (e PortWithHTTPDomainError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":  e.Route,
+		"Domain": e.Domain,
+	})
+}
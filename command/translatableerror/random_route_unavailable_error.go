@@ -0,0 +1,15 @@
+package translatableerror
+
+type RandomRouteUnavailableError struct {
+	Attempts int
+}
+
+func (RandomRouteUnavailableError) Error() string {
+	return "Could not find an available random route after {{.Attempts}} attempts. Try pushing again or specify a hostname."
+}
+
+func (e RandomRouteUnavailableError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Attempts": e.Attempts,
+	})
+}
@@ -0,0 +1,28 @@
+package translatableerror
+
+type RouteMappedToBothApplicationsError struct {
+	Route       string
+	FromAppGUID string
+	ToAppGUID   string
+	Err         error
+}
+
+func (RouteMappedToBothApplicationsError) Error() string {
+	return "Route {{.Route}} was mapped to app {{.ToAppGUID}} but could not be unmapped from app {{.FromAppGUID}}, so it is now mapped to both: {{.Error}}"
+}
+
+func (e RouteMappedToBothApplicationsError) Translate(translate func(string, ...interface{}) string) string {
+	var message string
+	if err, ok := e.Err.(TranslatableError); ok {
+		message = err.Translate(translate)
+	} else {
+		message = e.Err.Error()
+	}
+
+	return translate(e.Error(), map[string]interface{}{
+		"Route":       e.Route,
+		"FromAppGUID": e.FromAppGUID,
+		"ToAppGUID":   e.ToAppGUID,
+		"Error":       message,
+	})
+}
@@ -0,0 +1,19 @@
+package translatableerror
+
+type RouteOptionsNotSupportedError struct {
+	Route          string
+	CurrentVersion string
+	MinimumVersion string
+}
+
+func (RouteOptionsNotSupportedError) Error() string {
+	return "Options for route {{.Route}} require CC API version {{.MinimumVersion}} or higher. Your target is {{.CurrentVersion}}."
+}
+
+func (e RouteOptionsNotSupportedError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":          e.Route,
+		"CurrentVersion": e.CurrentVersion,
+		"MinimumVersion": e.MinimumVersion,
+	})
+}
@@ -0,0 +1,20 @@
+package translatableerror
+
+type RouteOwnedByOtherOrganizationError struct {
+	Route        string
+	Organization string
+}
+
+func (e RouteOwnedByOtherOrganizationError) Error() string {
+	if e.Organization != "" {
+		return "The route {{.Route}} is already in use by organization {{.Organization}}.\nTIP: Change the hostname with -n HOSTNAME or use --random-route to generate a new route and then push again."
+	}
+	return "The route {{.Route}} is already in use by another organization or space.\nTIP: Change the hostname with -n HOSTNAME or use --random-route to generate a new route and then push again."
+}
+
+func (e RouteOwnedByOtherOrganizationError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":        e.Route,
+		"Organization": e.Organization,
+	})
+}
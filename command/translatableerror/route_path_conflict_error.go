@@ -0,0 +1,22 @@
+package translatableerror
+
+type RoutePathConflictError struct {
+	Route string
+	Path  string
+	App   string
+}
+
+func (e RoutePathConflictError) Error() string {
+	if e.App != "" {
+		return "The path {{.Path}} of route {{.Route}} is already mapped to app {{.App}}."
+	}
+	return "The path {{.Path}} of route {{.Route}} is already mapped to another app."
+}
+
+func (e RoutePathConflictError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route": e.Route,
+		"Path":  e.Path,
+		"App":   e.App,
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+type RoutePortRangeTooLargeError struct {
+	Route    string
+	MaxPorts int
+}
+
+func (RoutePortRangeTooLargeError) Error() string {
+	return "The route {{.Route}} is invalid: a port range cannot contain more than {{.MaxPorts}} ports."
+}
+
+func (e RoutePortRangeTooLargeError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":    e.Route,
+		"MaxPorts": e.MaxPorts,
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+type RouteProtocolNotSupportedByDomainError struct {
+	Protocol string
+	Domain   string
+}
+
+func (RouteProtocolNotSupportedByDomainError) Error() string {
+	return "The route protocol {{.Protocol}} cannot be used with the domain {{.Domain}}."
+}
+
+func (e RouteProtocolNotSupportedByDomainError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Protocol": e.Protocol,
+		"Domain":   e.Domain,
+	})
+}
@@ -0,0 +1,15 @@
+package translatableerror
+
+type RouteQueryOrFragmentError struct {
+	Route string
+}
+
+func (RouteQueryOrFragmentError) Error() string {
+	return "The route {{.Route}} is invalid: a route cannot contain a query string or fragment."
+}
+
+func (e RouteQueryOrFragmentError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route": e.Route,
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+type RouteQuotaExceededError struct {
+	Route   string
+	Message string
+}
+
+func (RouteQuotaExceededError) Error() string {
+	return "Route {{.Route}} could not be created: {{.Message}}\nTIP: Ask your administrator to increase the route quota, or delete routes you no longer use with 'cf delete-route' and 'cf delete-orphaned-routes'."
+}
+
+func (e RouteQuotaExceededError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":   e.Route,
+		"Message": e.Message,
+	})
+}
@@ -0,0 +1,15 @@
+package translatableerror
+
+type RouteRequiresV3APIError struct {
+	Route string
+}
+
+func (RouteRequiresV3APIError) Error() string {
+	return "Labels, annotations and options for route {{.Route}} require CF API version 3.0.0 or higher."
+}
+
+func (e RouteRequiresV3APIError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route": e.Route,
+	})
+}
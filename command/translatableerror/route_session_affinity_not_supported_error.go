@@ -0,0 +1,15 @@
+package translatableerror
+
+type RouteSessionAffinityNotSupportedError struct {
+	Route string
+}
+
+func (RouteSessionAffinityNotSupportedError) Error() string {
+	return "Session affinity for route {{.Route}} is not supported by the Cloud Controller API."
+}
+
+func (e RouteSessionAffinityNotSupportedError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route": e.Route,
+	})
+}
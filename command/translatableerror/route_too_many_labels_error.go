@@ -0,0 +1,17 @@
+package translatableerror
+
+type RouteTooManyLabelsError struct {
+	Route     string
+	MaxLabels int
+}
+
+func (RouteTooManyLabelsError) Error() string {
+	return "The route {{.Route}} has more than {{.MaxLabels}} dot-separated labels."
+}
+
+func (e RouteTooManyLabelsError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":     e.Route,
+		"MaxLabels": e.MaxLabels,
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+import "strings"
+
+type RouteWarningsError struct {
+	Warnings []string
+}
+
+func (RouteWarningsError) Error() string {
+	return "Route operations produced warnings:\n{{.Warnings}}"
+}
+
+func (e RouteWarningsError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Warnings": strings.Join(e.Warnings, "\n"),
+	})
+}
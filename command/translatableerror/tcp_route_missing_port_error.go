@@ -0,0 +1,15 @@
+package translatableerror
+
+type TCPRouteMissingPortError struct {
+	Route string
+}
+
+func (TCPRouteMissingPortError) Error() string {
+	return "The TCP route {{.Route}} does not specify a port. Add a port to the route, such as {{.Route}}:1234, or set random-route to have one assigned."
+}
+
+func (e TCPRouteMissingPortError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route": e.Route,
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+type TooManyRoutesError struct {
+	Routes    int
+	MaxRoutes int
+}
+
+func (TooManyRoutesError) Error() string {
+	return "The application has {{.Routes}} routes, which exceeds the limit of {{.MaxRoutes}} routes per application."
+}
+
+func (e TooManyRoutesError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Routes":    e.Routes,
+		"MaxRoutes": e.MaxRoutes,
+	})
+}
@@ -58,6 +58,7 @@ var _ = Describe("Translatable Errors", func() {
 		Entry("HostAndPathNotAllowedWithTCPDomainError", HostAndPathNotAllowedWithTCPDomainError{}),
 		Entry("HTTPHealthCheckInvalidError", HTTPHealthCheckInvalidError{}),
 		Entry("InvalidChecksumError", InvalidChecksumError{}),
+		Entry("InvalidRouteHostnameError", InvalidRouteHostnameError{Err: errors.New("some-error")}),
 		Entry("InvalidSSLCertError", InvalidSSLCertError{}),
 		Entry("IsolationSegmentNotFoundError", IsolationSegmentNotFoundError{}),
 		Entry("JobFailedError", JobFailedError{}),
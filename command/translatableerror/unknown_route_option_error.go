@@ -0,0 +1,17 @@
+package translatableerror
+
+type UnknownRouteOptionError struct {
+	Route  string
+	Option string
+}
+
+func (UnknownRouteOptionError) Error() string {
+	return "Route {{.Route}} has an unknown option: {{.Option}}"
+}
+
+func (e UnknownRouteOptionError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":  e.Route,
+		"Option": e.Option,
+	})
+}
@@ -0,0 +1,17 @@
+package translatableerror
+
+type UnsupportedRouteSchemeError struct {
+	Route  string
+	Scheme string
+}
+
+func (UnsupportedRouteSchemeError) Error() string {
+	return "The route {{.Route}} starts with the unsupported scheme {{.Scheme}}://. Remove the scheme or use http://, https:// or tcp://."
+}
+
+func (e UnsupportedRouteSchemeError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Route":  e.Route,
+		"Scheme": e.Scheme,
+	})
+}
@@ -0,0 +1,15 @@
+package translatableerror
+
+type WildcardHostNotAllowedError struct {
+	Domain string
+}
+
+func (WildcardHostNotAllowedError) Error() string {
+	return "The domain {{.Domain}} does not allow wildcard hosts. Wildcard hosts can only be used with private HTTP domains."
+}
+
+func (e WildcardHostNotAllowedError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Domain": e.Domain,
+	})
+}
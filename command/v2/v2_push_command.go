@@ -1,17 +1,21 @@
 package v2
 
 import (
+	"net/http"
 	"os"
 	"path/filepath"
 
 	"code.cloudfoundry.org/cli/actor/pushaction"
 	"code.cloudfoundry.org/cli/actor/sharedaction"
 	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v3action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccerror"
 	oldcmd "code.cloudfoundry.org/cli/cf/cmd"
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/flag"
 	"code.cloudfoundry.org/cli/command/translatableerror"
 	"code.cloudfoundry.org/cli/command/v2/shared"
+	sharedV3 "code.cloudfoundry.org/cli/command/v3/shared"
 	"code.cloudfoundry.org/cli/util/configv3"
 	"code.cloudfoundry.org/cli/util/manifest"
 	"code.cloudfoundry.org/cli/util/progressbar"
@@ -86,7 +90,18 @@ func (cmd *V2PushCommand) Setup(config command.Config, ui command.UI) error {
 	}
 	v2Actor := v2action.NewActor(ccClient, uaaClient, config)
 	cmd.RestartActor = v2Actor
-	cmd.Actor = pushaction.NewActor(v2Actor, sharedActor)
+
+	var v3Actor pushaction.V3Actor
+	ccClientV3, _, err := sharedV3.NewClients(config, ui, true)
+	if err != nil {
+		if v3Err, ok := err.(ccerror.V3UnexpectedResponseError); !ok || v3Err.ResponseCode != http.StatusNotFound {
+			return err
+		}
+	} else {
+		v3Actor = v3action.NewActor(ccClientV3, config, sharedActor, nil)
+	}
+
+	cmd.Actor = pushaction.NewActor(v2Actor, v3Actor, sharedActor)
 	cmd.SharedActor = sharedActor
 	cmd.NOAAClient = shared.NewNOAAClient(ccClient.DopplerEndpoint(), config, uaaClient, ui)
 
@@ -77,7 +77,8 @@ func (cmd *V3PushCommand) Setup(config command.Config, ui command.UI) error {
 
 		return err
 	}
-	cmd.Actor = v3action.NewActor(ccClient, config, sharedActor, nil)
+	v3Actor := v3action.NewActor(ccClient, config, sharedActor, nil)
+	cmd.Actor = v3Actor
 
 	ccClientV2, uaaClientV2, err := sharedV2.NewClients(config, ui, true)
 	if err != nil {
@@ -87,7 +88,7 @@ func (cmd *V3PushCommand) Setup(config command.Config, ui command.UI) error {
 	v2Actor := v2action.NewActor(ccClientV2, uaaClientV2, config)
 
 	cmd.SharedActor = sharedActor
-	cmd.V2PushActor = pushaction.NewActor(v2Actor, sharedActor)
+	cmd.V2PushActor = pushaction.NewActor(v2Actor, v3Actor, sharedActor)
 
 	v2AppActor := v2action.NewActor(ccClientV2, uaaClientV2, config)
 	cmd.NOAAClient = shared.NewNOAAClient(ccClient.APIInfo.Logging(), config, uaaClient, ui)
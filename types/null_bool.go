@@ -0,0 +1,21 @@
+package types
+
+// NullBool is a wrapper around boolean values that can be null or a boolean.
+// Use IsSet to check if the value is provided, instead of checking against
+// false.
+type NullBool struct {
+	IsSet bool
+	Value bool
+}
+
+// ParseBoolValue is used to parse a user provided *bool argument.
+func (n *NullBool) ParseBoolValue(val *bool) {
+	if val == nil {
+		n.IsSet = false
+		n.Value = false
+		return
+	}
+
+	n.Value = *val
+	n.IsSet = true
+}
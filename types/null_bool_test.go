@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	. "code.cloudfoundry.org/cli/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NullBool", func() {
+	var nullBool NullBool
+
+	BeforeEach(func() {
+		nullBool = NullBool{}
+	})
+
+	Describe("ParseBoolValue", func() {
+		Context("when nil is provided", func() {
+			It("sets IsSet to false", func() {
+				nullBool.ParseBoolValue(nil)
+				Expect(nullBool).To(Equal(NullBool{Value: false, IsSet: false}))
+			})
+		})
+
+		Context("when non-nil pointer is provided", func() {
+			It("sets IsSet to true and Value to provided value", func() {
+				b := true
+				nullBool.ParseBoolValue(&b)
+				Expect(nullBool).To(Equal(NullBool{Value: true, IsSet: true}))
+			})
+		})
+	})
+})
@@ -15,6 +15,13 @@ type Application struct {
 	DockerPassword string
 	DockerUsername string
 	Domain         string
+	// DefaultDomain is the manifest's default-domain. It is used instead of
+	// the organization's default domain when Domain is not set. It is not
+	// written back out with the application.
+	DefaultDomain string
+	// DomainGUID is the GUID of the domain given to the application's
+	// generated route. When it is set, the domain is not looked up by name.
+	DomainGUID string
 	// EnvironmentVariables can be any valid json type (ie, strings not
 	// guaranteed, although CLI only ships strings).
 	EnvironmentVariables    map[string]string
@@ -25,15 +32,43 @@ type Application struct {
 	HealthCheckType string
 	Hostname        string
 	Instances       types.NullInt
-	Memory          types.NullByteSizeInMb
-	Name            string
-	NoHostname      bool
-	NoRoute         bool
-	Path            string
-	Routes          []string
+	// InternalRoute requires the application's generated route to be on an
+	// internal domain, reachable only through container-to-container
+	// networking.
+	InternalRoute bool
+	Memory        types.NullByteSizeInMb
+	Name          string
+	NoHostname    bool
+	NoRoute       bool
+	Path          string
+	// RandomRoute adds a random suffix to the host of the application's
+	// generated route.
+	RandomRoute bool
+	Routes      []string
+	// RouteAppPorts maps routes in Routes to the application port they are
+	// mapped to. Routes mapped to the default port are not present.
+	RouteAppPorts map[string]int
+	// RouteMetadata maps routes in Routes to their labels and annotations.
+	// Routes without metadata are not present.
+	RouteMetadata map[string]Metadata
+	// RouteSessionAffinity maps routes in Routes to whether session affinity
+	// is enabled for them. Routes that leave it unchanged are not present.
+	RouteSessionAffinity map[string]bool
+	// RouteOptions maps routes in Routes to their route options, such as
+	// "loadbalancing". Routes without options are not present.
+	RouteOptions map[string]map[string]string
+	// PrimaryRoutes are the routes in Routes that are marked as the
+	// application's primary route, in manifest order.
+	PrimaryRoutes []string
+	// EphemeralRoutes are the routes in Routes that are marked as ephemeral,
+	// in manifest order.
+	EphemeralRoutes []string
 	RoutePath       string
-	Services        []string
-	StackName       string
+	// RouteProtocol is the protocol given to the application's generated
+	// route.
+	RouteProtocol string
+	Services      []string
+	StackName     string
 }
 
 func (app Application) String() string {
@@ -68,12 +103,16 @@ func (app Application) MarshalYAML() (interface{}, error) {
 		Buildpack:               app.Buildpack.Value,
 		Command:                 app.Command.Value,
 		Docker:                  rawDockerInfo{Image: app.DockerImage, Username: app.DockerUsername},
+		DomainGUID:              app.DomainGUID,
 		EnvironmentVariables:    app.EnvironmentVariables,
 		HealthCheckHTTPEndpoint: app.HealthCheckHTTPEndpoint,
 		HealthCheckType:         app.HealthCheckType,
 		Name:                    app.Name,
 		NoRoute:                 app.NoRoute,
 		Path:                    app.Path,
+		InternalRoute:           app.InternalRoute,
+		RandomRoute:             app.RandomRoute,
+		RouteProtocol:           app.RouteProtocol,
 		Services:                app.Services,
 		StackName:               app.StackName,
 		Timeout:                 app.HealthCheckTimeout,
@@ -86,7 +125,30 @@ func (app Application) MarshalYAML() (interface{}, error) {
 	}
 
 	for _, route := range app.Routes {
-		m.Routes = append(m.Routes, rawManifestRoute{Route: route})
+		rawRoute := rawManifestRoute{Route: route}
+		if appPort, ok := app.RouteAppPorts[route]; ok {
+			rawRoute.AppPort = &appPort
+		}
+		if metadata, ok := app.RouteMetadata[route]; ok {
+			rawRoute.Metadata = &metadata
+		}
+		if affinity, ok := app.RouteSessionAffinity[route]; ok {
+			rawRoute.SessionAffinity = &affinity
+		}
+		if options, ok := app.RouteOptions[route]; ok {
+			rawRoute.Options = options
+		}
+		for _, primaryRoute := range app.PrimaryRoutes {
+			if primaryRoute == route {
+				rawRoute.Primary = true
+			}
+		}
+		for _, ephemeralRoute := range app.EphemeralRoutes {
+			if ephemeralRoute == route {
+				rawRoute.Ephemeral = true
+			}
+		}
+		m.Routes = append(m.Routes, rawRoute)
 	}
 
 	return m, nil
@@ -102,11 +164,15 @@ func (app *Application) UnmarshalYAML(unmarshaller func(interface{}) error) erro
 
 	app.DockerImage = m.Docker.Image
 	app.DockerUsername = m.Docker.Username
+	app.DomainGUID = m.DomainGUID
 	app.HealthCheckHTTPEndpoint = m.HealthCheckHTTPEndpoint
 	app.HealthCheckType = m.HealthCheckType
 	app.Name = m.Name
 	app.NoRoute = m.NoRoute
 	app.Path = m.Path
+	app.InternalRoute = m.InternalRoute
+	app.RandomRoute = m.RandomRoute
+	app.RouteProtocol = m.RouteProtocol
 	app.Services = m.Services
 	app.StackName = m.StackName
 	app.HealthCheckTimeout = m.Timeout
@@ -124,6 +190,36 @@ func (app *Application) UnmarshalYAML(unmarshaller func(interface{}) error) erro
 
 	for _, route := range m.Routes {
 		app.Routes = append(app.Routes, route.Route)
+		if route.AppPort != nil {
+			if app.RouteAppPorts == nil {
+				app.RouteAppPorts = map[string]int{}
+			}
+			app.RouteAppPorts[route.Route] = *route.AppPort
+		}
+		if route.Metadata != nil {
+			if app.RouteMetadata == nil {
+				app.RouteMetadata = map[string]Metadata{}
+			}
+			app.RouteMetadata[route.Route] = *route.Metadata
+		}
+		if route.SessionAffinity != nil {
+			if app.RouteSessionAffinity == nil {
+				app.RouteSessionAffinity = map[string]bool{}
+			}
+			app.RouteSessionAffinity[route.Route] = *route.SessionAffinity
+		}
+		if len(route.Options) > 0 {
+			if app.RouteOptions == nil {
+				app.RouteOptions = map[string]map[string]string{}
+			}
+			app.RouteOptions[route.Route] = route.Options
+		}
+		if route.Primary {
+			app.PrimaryRoutes = append(app.PrimaryRoutes, route.Route)
+		}
+		if route.Ephemeral {
+			app.EphemeralRoutes = append(app.EphemeralRoutes, route.Route)
+		}
 	}
 
 	// "null" values are identical to non-existant values in YAML. In order to
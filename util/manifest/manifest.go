@@ -9,6 +9,9 @@ import (
 
 type Manifest struct {
 	Applications []Application `yaml:"applications"`
+	// DefaultDomain is the domain given to the generated route of every
+	// application that does not name its own domain.
+	DefaultDomain string `yaml:"default-domain,omitempty"`
 }
 
 func (manifest *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -23,6 +26,7 @@ func (manifest *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error
 	}
 
 	manifest.Applications = raw.Applications
+	manifest.DefaultDomain = raw.DefaultDomain
 	return nil
 }
 
@@ -45,6 +49,7 @@ func ReadAndMergeManifests(pathToManifest string) ([]Application, error) {
 		if app.Path != "" && !filepath.IsAbs(app.Path) {
 			manifest.Applications[i].Path = filepath.Join(filepath.Dir(pathToManifest), app.Path)
 		}
+		manifest.Applications[i].DefaultDomain = manifest.DefaultDomain
 	}
 
 	// Merge all manifest files
@@ -52,10 +52,25 @@ applications:
   disk_quota: 1G
   instances: 0
   memory: 2G
+  domain-guid: some-domain-guid
+  internal-route: true
+  random-route: true
+  route-protocol: http2
   routes:
   - route: foo.bar.com
+    primary: true
   - route: baz.qux.com
+    session-affinity: true
+    ephemeral: true
+    options:
+      loadbalancing: least-connection
   - route: blep.blah.com/boop
+    app-port: 8080
+    metadata:
+      labels:
+        env: production
+      annotations:
+        owner: some-team
   services:
   - service_1
   - service_2
@@ -133,8 +148,25 @@ applications:
 							Value: 2048,
 							IsSet: true,
 						},
-						Routes:   []string{"foo.bar.com", "baz.qux.com", "blep.blah.com/boop"},
-						Services: []string{"service_1", "service_2"},
+						DomainGUID:    "some-domain-guid",
+						InternalRoute: true,
+						RandomRoute:   true,
+						Routes:        []string{"foo.bar.com", "baz.qux.com", "blep.blah.com/boop"},
+						RouteAppPorts: map[string]int{"blep.blah.com/boop": 8080},
+						RouteMetadata: map[string]Metadata{
+							"blep.blah.com/boop": {
+								Labels:      map[string]string{"env": "production"},
+								Annotations: map[string]string{"owner": "some-team"},
+							},
+						},
+						RouteSessionAffinity: map[string]bool{"baz.qux.com": true},
+						RouteOptions: map[string]map[string]string{
+							"baz.qux.com": {"loadbalancing": "least-connection"},
+						},
+						PrimaryRoutes:   []string{"foo.bar.com"},
+						EphemeralRoutes: []string{"baz.qux.com"},
+						RouteProtocol:   "http2",
+						Services:        []string{"service_1", "service_2"},
 					},
 					Application{
 						Name: "app-3",
@@ -161,6 +193,40 @@ applications:
 			})
 		})
 
+		Context("when the manifest has a default domain", func() {
+			var pathToManifest string
+
+			BeforeEach(func() {
+				manifest = `---
+default-domain: shared.example.com
+applications:
+- name: app-1
+- name: app-2
+  random-route: true
+`
+				tempFile, err := ioutil.TempFile("", "manifest-test-")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(tempFile.Close()).ToNot(HaveOccurred())
+				pathToManifest = tempFile.Name()
+
+				err = ioutil.WriteFile(pathToManifest, []byte(manifest), 0666)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(pathToManifest)).ToNot(HaveOccurred())
+			})
+
+			It("gives the default domain to every application", func() {
+				apps, err := ReadAndMergeManifests(pathToManifest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apps).To(ConsistOf(
+					Application{Name: "app-1", DefaultDomain: "shared.example.com"},
+					Application{Name: "app-2", RandomRoute: true, DefaultDomain: "shared.example.com"},
+				))
+			})
+		})
+
 		Context("when provided deprecated fields", func() {
 			DescribeTable("raises a UnsupportedFieldsError",
 				func(manifestProperty string, numberOfValues int) {
@@ -270,8 +336,21 @@ applications:
 						Value: 200,
 						IsSet: true,
 					},
-					NoRoute:            true,
-					Routes:             []string{"foo.bar.com", "baz.qux.com", "blep.blah.com/boop"},
+					DomainGUID:    "some-domain-guid",
+					NoRoute:       true,
+					RandomRoute:   true,
+					Routes:        []string{"foo.bar.com", "baz.qux.com", "blep.blah.com/boop"},
+					RouteAppPorts: map[string]int{"baz.qux.com": 9090},
+					RouteMetadata: map[string]Metadata{
+						"foo.bar.com": {Labels: map[string]string{"env": "production"}},
+					},
+					RouteSessionAffinity: map[string]bool{"blep.blah.com/boop": false},
+					RouteOptions: map[string]map[string]string{
+						"foo.bar.com": {"loadbalancing": "round-robin"},
+					},
+					PrimaryRoutes:      []string{"baz.qux.com"},
+					EphemeralRoutes:    []string{"blep.blah.com/boop"},
+					RouteProtocol:      "http2",
 					Services:           []string{"service_1", "service_2"},
 					StackName:          "some-stack",
 					HealthCheckTimeout: 120,
@@ -290,6 +369,7 @@ applications:
   docker:
     image: some-docker-image
     username: some-docker-username
+  domain-guid: some-domain-guid
   env:
     env_1: foo
     env_2: "182837403930483038"
@@ -300,10 +380,21 @@ applications:
   instances: 10
   memory: 200M
   no-route: true
+  random-route: true
+  route-protocol: http2
   routes:
   - route: foo.bar.com
+    metadata:
+      labels:
+        env: production
+    options:
+      loadbalancing: round-robin
   - route: baz.qux.com
+    app-port: 9090
+    primary: true
   - route: blep.blah.com/boop
+    session-affinity: false
+    ephemeral: true
   services:
   - service_1
   - service_2
@@ -0,0 +1,7 @@
+package manifest
+
+// Metadata is the labels and annotations declared for a route.
+type Metadata struct {
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
@@ -1,7 +1,8 @@
 package manifest
 
 type rawManifest struct {
-	Applications []Application `yaml:"applications"`
+	Applications  []Application `yaml:"applications"`
+	DefaultDomain string        `yaml:"default-domain"`
 
 	DeprecatedBuildpack               interface{} `yaml:"buildpack"`
 	DeprecatedCommand                 interface{} `yaml:"command"`
@@ -6,13 +6,17 @@ type rawManifestApplication struct {
 	Command                 string             `yaml:"command,omitempty"`
 	DiskQuota               string             `yaml:"disk_quota,omitempty"`
 	Docker                  rawDockerInfo      `yaml:"docker,omitempty"`
+	DomainGUID              string             `yaml:"domain-guid,omitempty"`
 	EnvironmentVariables    map[string]string  `yaml:"env,omitempty"`
 	HealthCheckHTTPEndpoint string             `yaml:"health-check-http-endpoint,omitempty"`
 	HealthCheckType         string             `yaml:"health-check-type,omitempty"`
 	Instances               *int               `yaml:"instances,omitempty"`
+	InternalRoute           bool               `yaml:"internal-route,omitempty"`
 	Memory                  string             `yaml:"memory,omitempty"`
 	NoRoute                 bool               `yaml:"no-route,omitempty"`
 	Path                    string             `yaml:"path,omitempty"`
+	RandomRoute             bool               `yaml:"random-route,omitempty"`
+	RouteProtocol           string             `yaml:"route-protocol,omitempty"`
 	Routes                  []rawManifestRoute `yaml:"routes,omitempty"`
 	Services                []string           `yaml:"services,omitempty"`
 	StackName               string             `yaml:"stack,omitempty"`
@@ -20,7 +24,13 @@ type rawManifestApplication struct {
 }
 
 type rawManifestRoute struct {
-	Route string `yaml:"route"`
+	Route           string            `yaml:"route"`
+	AppPort         *int              `yaml:"app-port,omitempty"`
+	Metadata        *Metadata         `yaml:"metadata,omitempty"`
+	SessionAffinity *bool             `yaml:"session-affinity,omitempty"`
+	Options         map[string]string `yaml:"options,omitempty"`
+	Primary         bool              `yaml:"primary,omitempty"`
+	Ephemeral       bool              `yaml:"ephemeral,omitempty"`
 }
 
 type rawDockerInfo struct {